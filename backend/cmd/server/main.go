@@ -6,7 +6,15 @@ import (
 	"github.com/bhati00/Fynelo/backend/config"
 	app "github.com/bhati00/Fynelo/backend/internal/bootstrap"
 	"github.com/bhati00/Fynelo/backend/internal/company"
+	"github.com/bhati00/Fynelo/backend/internal/contact"
+	"github.com/bhati00/Fynelo/backend/internal/fx"
 	"github.com/bhati00/Fynelo/backend/internal/icp"
+	"github.com/bhati00/Fynelo/backend/internal/lists"
+	"github.com/bhati00/Fynelo/backend/internal/notifications"
+	"github.com/bhati00/Fynelo/backend/internal/searches"
+	"github.com/bhati00/Fynelo/backend/internal/signals"
+	"github.com/bhati00/Fynelo/backend/internal/taxonomy"
+	"github.com/bhati00/Fynelo/backend/internal/webhook"
 	"github.com/bhati00/Fynelo/backend/pkg/database"
 	redisClient "github.com/bhati00/Fynelo/backend/pkg/redis"
 )
@@ -16,9 +24,20 @@ func main() {
 	cfg := config.LoadConfig()
 	_ = database.ConnectDatabase(cfg) // Connect and store in global DB variable
 
+	if err := fx.LoadFile(cfg.FXRatesPath); err != nil {
+		log.Printf("Failed to load FX rates, using bundled rates: %v", err)
+	}
+
 	log.Println("Running database migrations...")
+	taxonomy.Migrate()
 	company.Migrate()
+	contact.Migrate()
 	icp.Migrate()
+	lists.Migrate()
+	notifications.Migrate()
+	searches.Migrate()
+	signals.Migrate()
+	webhook.Migrate()
 	log.Println("Database migrations completed")
 	// Initialize Redis (graceful fallback if unavailable)
 	log.Println("Connecting to Redis...")
@@ -9,7 +9,18 @@ import (
 
 	"github.com/bhati00/Fynelo/backend/config"
 	"github.com/bhati00/Fynelo/backend/internal/company"
+	"github.com/bhati00/Fynelo/backend/internal/contact"
+	"github.com/bhati00/Fynelo/backend/internal/company/repositories"
+	"github.com/bhati00/Fynelo/backend/internal/company/service"
+	"github.com/bhati00/Fynelo/backend/internal/fx"
 	"github.com/bhati00/Fynelo/backend/internal/icp"
+	"github.com/bhati00/Fynelo/backend/internal/lists"
+	"github.com/bhati00/Fynelo/backend/internal/notifications"
+	"github.com/bhati00/Fynelo/backend/internal/queue"
+	"github.com/bhati00/Fynelo/backend/internal/searches"
+	"github.com/bhati00/Fynelo/backend/internal/signals"
+	"github.com/bhati00/Fynelo/backend/internal/taxonomy"
+	"github.com/bhati00/Fynelo/backend/internal/webhook"
 	"github.com/bhati00/Fynelo/backend/internal/worker"
 	"github.com/bhati00/Fynelo/backend/pkg/database"
 	redisClient "github.com/bhati00/Fynelo/backend/pkg/redis"
@@ -28,9 +39,20 @@ func main() {
 		log.Fatal("Failed to connect to database")
 	}
 
+	if err := fx.LoadFile(cfg.FXRatesPath); err != nil {
+		log.Printf("Failed to load FX rates, using bundled rates: %v", err)
+	}
+
 	log.Println("Running database migrations...")
+	taxonomy.Migrate()
 	company.Migrate()
+	contact.Migrate()
 	icp.Migrate()
+	lists.Migrate()
+	notifications.Migrate()
+	searches.Migrate()
+	signals.Migrate()
+	webhook.Migrate()
 	log.Println("Database migrations completed")
 
 	// Initialize Redis
@@ -49,6 +71,64 @@ func main() {
 	// Create worker
 	workerInstance := worker.NewWorker(redis, db)
 
+	// Periodic maintenance tasks
+	dedupeService := service.NewDedupeService(repositories.NewDuplicateRepository(db))
+	workerInstance.AddPeriodicTask(worker.PeriodicTask{
+		Name:     "duplicate-scan",
+		Interval: worker.DuplicateScanInterval,
+		Run: func(ctx context.Context) error {
+			_, err := dedupeService.ScanDuplicates(ctx)
+			return err
+		},
+	})
+	signalService := signals.NewService(signals.NewRepository(db), icp.NewRepository(db))
+	workerInstance.AddPeriodicTask(worker.PeriodicTask{
+		Name:     "signal-detect",
+		Interval: worker.SignalDetectInterval,
+		Run: func(ctx context.Context) error {
+			_, err := signalService.Detect(ctx)
+			return err
+		},
+	})
+	companyRepo := repositories.NewCompanyRepository(db)
+	companyService := service.NewCompanyService(companyRepo, service.NewEnrichmentService(
+		companyRepo,
+		repositories.NewRevenueRepository(db),
+		repositories.NewFundingRepository(db),
+		repositories.NewTechnologyRepository(db),
+		repositories.NewLocationRepository(db),
+		repositories.NewProvenanceRepository(db),
+	), queue.NewQueueService())
+	savedSearchService := searches.NewService(searches.NewRepository(db), companyService, notifications.NewService(notifications.NewRepository(db)))
+	workerInstance.AddPeriodicTask(worker.PeriodicTask{
+		Name:     "saved-searches",
+		Interval: worker.SavedSearchInterval,
+		Run: func(ctx context.Context) error {
+			_, err := savedSearchService.RunDue(ctx)
+			return err
+		},
+	})
+	// taxonomy terms are edited through the API server
+	workerInstance.AddPeriodicTask(worker.PeriodicTask{
+		Name:     "taxonomy-refresh",
+		Interval: worker.TaxonomyRefreshInterval,
+		Run: func(ctx context.Context) error {
+			return taxonomy.Reload(db.WithContext(ctx))
+		},
+	})
+	webhookService := webhook.NewService(webhook.NewRepository(db), nil)
+	workerInstance.AddPeriodicTask(worker.PeriodicTask{
+		Name:     "webhook-dispatch",
+		Interval: worker.WebhookDispatchInterval,
+		Run: func(ctx context.Context) error {
+			if _, err := webhookService.Relay(ctx); err != nil {
+				return err
+			}
+			_, err := webhookService.Dispatch(ctx)
+			return err
+		},
+	})
+
 	// Setup graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
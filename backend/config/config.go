@@ -7,13 +7,15 @@ type RedisConfig struct {
 	DB       int    `json:"db"`
 }
 type Config struct {
-	DBPath string      `json:"db_path"`
-	Redis  RedisConfig `json:"redis"`
+	DBPath      string      `json:"db_path"`
+	FXRatesPath string      `json:"fx_rates_path"` // optional CSV overlaid on the bundled FX rates
+	Redis       RedisConfig `json:"redis"`
 }
 
 func LoadConfig() Config {
 	return Config{
-		DBPath: "data/fynelo.db",
+		DBPath:      "data/fynelo.db",
+		FXRatesPath: "data/fx_rates.csv",
 		Redis: RedisConfig{
 			Host:     "localhost",
 			Port:     "6379",
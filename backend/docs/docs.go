@@ -72,9 +72,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/companies/search": {
+        "/companies/by-domain/{domain}": {
             "get": {
-                "description": "Search companies with various filters",
+                "description": "Get a company by its canonical domain. Scheme, www. prefix and paths are ignored, e.g. www.Acme.com resolves acme.com",
                 "consumes": [
                     "application/json"
                 ],
@@ -84,53 +84,87 @@ const docTemplate = `{
                 "tags": [
                     "Companies"
                 ],
-                "summary": "Search companies",
+                "summary": "Get company by domain",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search query (company name, website)",
-                        "name": "q",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Industry filter (e.g., technology, fintech)",
-                        "name": "industry",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Employee size range (e.g., 1-10, 11-50)",
-                        "name": "employee_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Location filter",
-                        "name": "location",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Funding stage (seed, series_a, etc.)",
-                        "name": "funding_stage",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Founded after year",
-                        "name": "founded_min",
-                        "in": "query"
+                        "description": "Company domain",
+                        "name": "domain",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Company"
+                        }
                     },
-                    {
-                        "type": "integer",
-                        "description": "Founded before year",
-                        "name": "founded_max",
-                        "in": "query"
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/companies/currencies": {
+            "get": {
+                "description": "Lists the currencies the FX table has rates for",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Companies"
+                ],
+                "summary": "List convertible currencies",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/companies/duplicates": {
+            "get": {
+                "description": "List likely duplicate company pairs found by the duplicate scan, highest score first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Companies"
+                ],
+                "summary": "List duplicate candidates",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Company status (active, closed, etc.)",
+                        "description": "Candidate status (pending, merged, dismissed; default: pending)",
                         "name": "status",
                         "in": "query"
                     },
@@ -151,17 +185,43 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.CompanySearchResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.DuplicateCandidate"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    }
+                }
+            }
+        },
+        "/companies/duplicates/scan": {
+            "post": {
+                "description": "Runs the duplicate detection job now (it also runs periodically in the worker)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Companies"
+                ],
+                "summary": "Scan for duplicate companies",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.DuplicateScanResult"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -175,9 +235,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/companies/{id}": {
-            "get": {
-                "description": "Get a company by its ID",
+        "/companies/duplicates/{id}/dismiss": {
+            "post": {
+                "description": "Marks a candidate pair as not a duplicate; later scans keep the decision",
                 "consumes": [
                     "application/json"
                 ],
@@ -187,11 +247,11 @@ const docTemplate = `{
                 "tags": [
                     "Companies"
                 ],
-                "summary": "Get company by ID",
+                "summary": "Dismiss a duplicate candidate",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Company ID",
+                        "description": "Candidate ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -201,7 +261,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Company"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -225,9 +288,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/icp": {
+        "/companies/enrichment": {
             "post": {
-                "description": "Creates a new Ideal Customer Profile for a given user",
+                "description": "Records what a source reports about a company. Each field is kept as an observation; it replaces the stored value only if it outranks the current one (source manual \u003e api \u003e scraped, then higher confidence, then more recent)",
                 "consumes": [
                     "application/json"
                 ],
@@ -235,25 +298,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ICP"
+                    "Companies"
                 ],
-                "summary": "Create a new ICP profile",
+                "summary": "Apply enrichment data",
                 "parameters": [
                     {
-                        "description": "ICP Profile Data",
-                        "name": "profile",
+                        "description": "Observed company data",
+                        "name": "enrichment",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/icp.ICPProfile"
+                            "$ref": "#/definitions/service.EnrichmentResult"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/icp.ICPProfile"
+                            "$ref": "#/definitions/service.EnrichmentOutcome"
                         }
                     },
                     "400": {
@@ -265,6 +328,22 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "The website belongs to another company, whose ID is returned as company_id",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -277,9 +356,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/icp/user/{user_id}": {
-            "get": {
-                "description": "Retrieves all ICP profiles associated with a given user ID",
+        "/companies/import": {
+            "post": {
+                "description": "Upsert companies by domain: a company whose website resolves to an existing domain updates that company instead of creating a new one. Companies without a website are matched by exact name. Each company's source (default: manual) decides whether its values replace stored ones. employees takes a headcount as reported (\"~250\", \"1k-5k\", \"500+\") and sets employee_count and, unless given, employee_size_id",
                 "consumes": [
                     "application/json"
                 ],
@@ -287,26 +366,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ICP"
+                    "Companies"
                 ],
-                "summary": "List all ICP profiles for a user",
+                "summary": "Import companies",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Companies to import (max 1000)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/company.ImportCompaniesRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/icp.ICPProfile"
-                            }
+                            "$ref": "#/definitions/service.ImportResult"
                         }
                     },
                     "400": {
@@ -330,9 +408,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/icp/{id}": {
-            "get": {
-                "description": "Fetches an ICP profile using its unique ID",
+        "/companies/industries/classify": {
+            "post": {
+                "description": "Maps NAICS or SIC codes and a free-text description to an industry with a confidence, the way imports and enrichment do, without storing anything",
                 "consumes": [
                     "application/json"
                 ],
@@ -340,23 +418,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ICP"
+                    "Companies"
                 ],
-                "summary": "Get an ICP profile by ID",
+                "summary": "Classify an industry",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ICP ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Codes and description",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/industry.Input"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/icp.ICPProfile"
+                            "$ref": "#/definitions/industry.Result"
                         }
                     },
                     "400": {
@@ -367,9 +447,32 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    }
+                }
+            }
+        },
+        "/companies/industries/reclassify": {
+            "post": {
+                "description": "Retries the unmapped values against the current taxonomy, dropping those that now map, and gives an industry to companies that have none, or are filed under Other, but have reported codes or text that now map",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Companies"
+                ],
+                "summary": "Reclassify industries",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.ReclassifyResult"
+                        }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -378,9 +481,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates an existing ICP profile by ID",
+            }
+        },
+        "/companies/industries/unmapped": {
+            "get": {
+                "description": "Lists the industry codes and descriptions imports and enrichment reported that could not be classified, most reported first. Map one by adding a taxonomy alias to an industry (e.g. \"naics:2361\" or the description), then reclassify.",
                 "consumes": [
                     "application/json"
                 ],
@@ -388,32 +493,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ICP"
+                    "Companies"
                 ],
-                "summary": "Update an ICP profile",
+                "summary": "List unmapped industry values",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "naics, sic or text",
+                        "name": "source",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "ICP ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Results limit (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
-                        "description": "Updated ICP Profile Data",
-                        "name": "profile",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/icp.ICPProfile"
-                        }
+                        "type": "integer",
+                        "description": "Results offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/icp.ICPProfile"
+                            "$ref": "#/definitions/company.UnmappedIndustriesResponse"
                         }
                     },
                     "400": {
@@ -435,9 +542,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/companies/industries/unmapped/{id}": {
             "delete": {
-                "description": "Deletes an ICP profile by ID",
+                "description": "Removes a value from the curation list; it comes back if it is reported again",
                 "consumes": [
                     "application/json"
                 ],
@@ -445,13 +554,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ICP"
+                    "Companies"
                 ],
-                "summary": "Delete an ICP profile",
+                "summary": "Dismiss an unmapped industry value",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ICP ID",
+                        "description": "Unmapped value ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -470,6 +579,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -482,9 +600,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs/stats": {
-            "get": {
-                "description": "Get current queue statistics and health",
+        "/companies/merge": {
+            "post": {
+                "description": "Merges companies into a survivor: child rows move over, missing fields are filled, merged IDs redirect to the survivor",
                 "consumes": [
                     "application/json"
                 ],
@@ -492,19 +610,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Queue"
+                    "Companies"
+                ],
+                "summary": "Merge companies",
+                "parameters": [
+                    {
+                        "description": "Survivor and companies to merge into it",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.MergeRequest"
+                        }
+                    }
                 ],
-                "summary": "Get queue statistics",
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.CompanyMerge"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "503": {
-                        "description": "Service Unavailable",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -515,9 +664,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs/user/{user_id}": {
+        "/companies/search": {
             "get": {
-                "description": "Get all jobs for a specific user",
+                "description": "Search companies with various filters",
                 "consumes": [
                     "application/json"
                 ],
@@ -525,26 +674,180 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Queue"
+                    "Companies"
                 ],
-                "summary": "Get user's jobs",
+                "summary": "Search companies",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query (company name, website), or an advanced query when q_mode=advanced",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "simple",
+                            "advanced"
+                        ],
+                        "type": "string",
+                        "description": "Query mode: simple (default) or advanced, e.g. industry:fintech AND (tech:kubernetes OR tech:terraform) AND founded:\u003e=2018 AND NOT status:closed",
+                        "name": "q_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Industry filter (e.g., technology, fintech)",
+                        "name": "industry",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Employee size range (e.g., 1-10, 11-50)",
+                        "name": "employee_size",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "path",
-                        "required": true
+                        "description": "At least this many employees (companies with a known employee count)",
+                        "name": "employees_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "At most this many employees",
+                        "name": "employees_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Location filter (free-text HQ location)",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Country name or ISO-3166 code, matched against any company location",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "State/region name or ISO-3166-2 code",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "City name",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Radius search center: place name (e.g. Berlin) or lat,lon",
+                        "name": "near",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Radius for near in km (default: 50, max: 1000)",
+                        "name": "radius_km",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Funding stage (seed, series_a, etc.)",
+                        "name": "funding_stage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Founded after year",
+                        "name": "founded_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Founded before year",
+                        "name": "founded_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Latest reported revenue of at least this many USD (converted at the year's FX rate)",
+                        "name": "revenue_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Latest reported revenue of at most this many USD",
+                        "name": "revenue_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Total disclosed funding of at least this many USD (converted at each round's FX rate)",
+                        "name": "funding_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Backed by this investor: an investor ID, or a name matching the investor's name or its first words (e.g. sequoia)",
+                        "name": "investor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "With investor, only count rounds the investor led",
+                        "name": "investor_lead",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Uses a technology of this category, e.g. crm, analytics, payments, cloud (see /companies/technologies/categories)",
+                        "name": "tech_category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Company status (active, closed, etc.)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "revenue",
+                            "funding"
+                        ],
+                        "type": "string",
+                        "description": "Order by latest revenue or total funding in USD, highest first",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results limit (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include facet counts (industry, size, status, funding stage, country, technologies, revenue band)",
+                        "name": "facets",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/queue.SearchJob"
-                            }
+                            "$ref": "#/definitions/service.CompanySearchResponse"
                         }
                     },
                     "400": {
@@ -556,8 +859,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "503": {
-                        "description": "Service Unavailable",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -568,9 +871,78 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs/{id}": {
+        "/companies/technologies/catalog": {
             "get": {
-                "description": "Get the status of a search job by its ID",
+                "description": "Lists the technologies of the catalog with their aliases, category and vendor. Technology names reported by imports and enrichment are stored in their catalog form, so \"k8s\" is stored as Kubernetes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Companies"
+                ],
+                "summary": "List catalog technologies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category code or name, e.g. crm",
+                        "name": "category",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/techcatalog.Technology"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/companies/technologies/categories": {
+            "get": {
+                "description": "Lists the categories of the technology catalog, accepted by the tech_category search filter and query field",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Companies"
+                ],
+                "summary": "List technology categories",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/techcatalog.Category"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/companies/{id}": {
+            "get": {
+                "description": "Get a company by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -578,13 +950,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Queue"
+                    "Companies"
                 ],
-                "summary": "Get job status by ID",
+                "summary": "Get company by ID",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Job ID",
+                        "type": "integer",
+                        "description": "Company ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -594,9 +966,12 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/queue.SearchJob"
+                            "$ref": "#/definitions/model.Company"
                         }
                     },
+                    "301": {
+                        "description": "Company was merged; Location points to the surviving company"
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -614,9 +989,260 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    }
+                }
+            }
+        },
+        "/companies/{id}/decision-makers": {
+            "get": {
+                "description": "Lists a company's contacts holding the buyer roles targeted by an ICP, most senior first. Without icp_id every buyer role except Other counts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contacts"
+                ],
+                "summary": "Decision-makers at a company",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Company ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
-                    "503": {
-                        "description": "Service Unavailable",
+                    {
+                        "type": "integer",
+                        "description": "ICP profile whose buyer roles to match",
+                        "name": "icp_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/contact.DecisionMakersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/companies/{id}/details": {
+            "get": {
+                "description": "Get a company with its revenues, funding rounds (with investors), technologies and locations, and metrics computed from them: total funding in USD, latest round and date, months since the last raise, revenue growth year over year, technologies by category, and headquarters vs office count",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Companies"
+                ],
+                "summary": "Get company with details and metrics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Company ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.CompanyDetails"
+                        }
+                    },
+                    "301": {
+                        "description": "Company was merged; Location points to the surviving company"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/companies/{id}/email-pattern": {
+            "get": {
+                "description": "Scores address patterns (first.last, flast, first, ...) against the known emails of contacts at the company's domain",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Email finder"
+                ],
+                "summary": "Infer a company's email pattern",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Company ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/emailfinder.PatternInference"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/companies/{id}/financials": {
+            "get": {
+                "description": "Lists a company's revenues and funding rounds as reported and converted to one currency: revenues at the FX rate of the middle of their year, funding rounds at the rate of their date. Rates come from the offline FX table; amounts in currencies it does not know are not converted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Companies"
+                ],
+                "summary": "Get revenue and funding history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Company ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO-4217 currency code (default: USD)",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.FinancialsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -626,133 +1252,7769 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "icp.ICPProfile": {
+        },
+        "/companies/{id}/history": {
+            "get": {
+                "description": "Lists recorded changes to a company and its revenues, funding rounds, technologies and locations, newest first. Values are JSON-encoded",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Companies"
+                ],
+                "summary": "Company change history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Company ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Limit to company, revenue, funding_round, technology or location",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Limit to one field, e.g. employee_size_id or status",
+                        "name": "field",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Changes at or after this time (RFC3339 or YYYY-MM-DD)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Changes at or before this time (RFC3339 or YYYY-MM-DD)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results limit (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.CompanyChange"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/companies/{id}/provenance": {
+            "get": {
+                "description": "Shows, per field, which observation the stored value came from and every other value sources have reported",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Companies"
+                ],
+                "summary": "Explain company data",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Company ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Limit to company, revenue, funding_round or technology",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Limit to one field, e.g. employee_size_id",
+                        "name": "field",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.FieldExplanation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/companies/{id}/snapshot": {
+            "get": {
+                "description": "Rebuilds a company and its child rows as they were at a point in time. A bare date means the end of that day (UTC)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Companies"
+                ],
+                "summary": "Company as of a date",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Company ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Point in time (RFC3339 or YYYY-MM-DD)",
+                        "name": "as_of",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.CompanySnapshot"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/contacts": {
+            "get": {
+                "description": "Searches contacts by name, title or email and filters by company, buyer role, seniority and department",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contacts"
+                ],
+                "summary": "Search contacts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Matched against name, title and email",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Company ID",
+                        "name": "company_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Buyer role ID",
+                        "name": "buyer_role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "c_suite, vp, director, manager or individual",
+                        "name": "seniority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "executive, engineering, it, product, marketing, sales, finance, hr, operations or other",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results limit (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/contact.SearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a person at a company. Seniority, department and buyer role are derived from the title unless given.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contacts"
+                ],
+                "summary": "Create a contact",
+                "parameters": [
+                    {
+                        "description": "Contact",
+                        "name": "contact",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contact.ContactRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/contact.Contact"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/contacts/{id}": {
+            "get": {
+                "description": "Fetches a contact and its company",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contacts"
+                ],
+                "summary": "Get a contact",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Contact ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/contact.Contact"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces a contact. Seniority, department and buyer role are re-derived from the title unless given.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contacts"
+                ],
+                "summary": "Update a contact",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Contact ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Contact",
+                        "name": "contact",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contact.ContactRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/contact.Contact"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a contact",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contacts"
+                ],
+                "summary": "Delete a contact",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Contact ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/contacts/{id}/email-candidates": {
+            "get": {
+                "description": "Generates possible addresses for a contact from the company's inferred email pattern, most likely first, and reports whether the domain accepts mail. Nothing is saved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Email finder"
+                ],
+                "summary": "Email candidates for a contact",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Contact ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/emailfinder.CandidatesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/contacts/{id}/find-email": {
+            "post": {
+                "description": "Picks the most likely candidate address, verifies its syntax and MX records and stores it with its status and confidence. Contacts with a provided email are not changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Email finder"
+                ],
+                "summary": "Find a contact's email",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Contact ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/emailfinder.EmailResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/contacts/{id}/verify-email": {
+            "post": {
+                "description": "Checks the contact's email syntax and MX records and stores the verification status and confidence",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Email finder"
+                ],
+                "summary": "Verify a contact's email",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Contact ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/emailfinder.EmailResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/icp": {
+            "post": {
+                "description": "Creates a new Ideal Customer Profile for a given user. Criteria are lists of IDs from the constants taxonomies, ISO country or region codes (names are accepted), funding stages and technology names; invalid values are reported per field.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ICP"
+                ],
+                "summary": "Create a new ICP profile",
+                "parameters": [
+                    {
+                        "description": "ICP Profile Data",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/icp.ICPProfile"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/icp.ICPProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/icp/analytics": {
+            "get": {
+                "description": "Totals the analytics metrics of every profile, archived ones included, over a period, ranked by qualified accounts, then list additions and matches",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ICP"
+                ],
+                "summary": "Compare the performance of ICP profiles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start, RFC 3339 or YYYY-MM-DD (default 30 days back)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End, RFC 3339 or YYYY-MM-DD (default now)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/icp.SummaryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/icp/lookalikes": {
+            "post": {
+                "description": "Analyzes the industries, sizes, funding rounds, technologies and locations of seed companies, such as the best customers, and proposes an unsaved draft ICP with the share of seeds holding each value. Other companies sharing any of those values are returned ranked by similarity (0-1).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ICP"
+                ],
+                "summary": "Propose an ICP from seed companies",
+                "parameters": [
+                    {
+                        "description": "Seed company IDs",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/icp.LookalikeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/icp.LookalikeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/icp/templates": {
+            "get": {
+                "description": "Lists the built-in starting points for new profiles, with their criteria spelled out using the taxonomy names",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ICP"
+                ],
+                "summary": "List the built-in ICP templates",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/icp.ICPTemplate"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/icp/templates/{key}": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ICP"
+                ],
+                "summary": "Create an ICP profile from a template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Profile name and owner",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/icp.TemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/icp.ICPProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/icp/user/{user_id}": {
+            "get": {
+                "description": "Retrieves all ICP profiles associated with a given user ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ICP"
+                ],
+                "summary": "List all ICP profiles for a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include archived profiles",
+                        "name": "include_archived",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/icp.ICPProfile"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/icp/{id}": {
+            "get": {
+                "description": "Fetches an ICP profile using its unique ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ICP"
+                ],
+                "summary": "Get an ICP profile by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ICP ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/icp.ICPProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces an existing ICP profile by ID. Criteria are validated as on create. A change to the name or criteria is kept as a new revision; archived profiles cannot be updated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ICP"
+                ],
+                "summary": "Update an ICP profile",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ICP ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated ICP Profile Data",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/icp.ICPProfile"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/icp.ICPProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Archives an ICP profile by ID. Archived profiles keep their revisions and runs, stay readable by ID and can be cloned or unarchived, but are left out of listings and cannot be updated or run.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ICP"
+                ],
+                "summary": "Archive an ICP profile",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ICP ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/icp/{id}/analytics": {
+            "get": {
+                "description": "Counts, per time bucket, the companies the profile's runs first matched, its runs, the enrichment jobs they queued and completed, and how many matched companies were added to prospect lists or moved to qualified after being matched. Buckets start at midnight UTC, on Mondays for weeks and on the first for months.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ICP"
+                ],
+                "summary": "Get the performance of an ICP profile",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ICP ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bucket size: day (default), week or month",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start, RFC 3339 or YYYY-MM-DD (default 30 days, 12 weeks or 12 months back)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End, RFC 3339 or YYYY-MM-DD (default now)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/icp.AnalyticsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/icp/{id}/clone": {
+            "post": {
+                "description": "Creates a new profile from the current or a given version of an existing one, archived or not",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ICP"
+                ],
+                "summary": "Clone an ICP profile",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ICP ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Clone name and source version",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/icp.CloneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/icp.ICPProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/icp/{id}/diff": {
+            "get": {
+                "description": "Lists the values added to and removed from each criterion, and the old and new text of the name and problem statement, between two versions. to defaults to the current version and from to the one before it; version 0 stands for an empty profile.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ICP"
+                ],
+                "summary": "Compare two revisions of an ICP profile",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ICP ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Earlier version",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Later version",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/icp.ICPDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/icp/{id}/revisions": {
+            "get": {
+                "description": "Lists the numbered snapshots of a profile's name and criteria, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ICP"
+                ],
+                "summary": "List the revisions of an ICP profile",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ICP ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revisions to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/icp.RevisionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/icp/{id}/revisions/{version}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ICP"
+                ],
+                "summary": "Get a revision of an ICP profile",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ICP ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/icp.ICPRevision"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/icp/{id}/run": {
+            "post": {
+                "description": "Translates the profile into an advanced company search, returns a page of the matching local companies and queues an enrichment job carrying the profile ID. Each run is recorded, attributing companies not matched by earlier runs to the profile. queued_job is omitted when the queue is unavailable. Profiles without company criteria are rejected, as are profiles whose criteria no longer resolve (e.g. a deactivated industry); those values are listed per field.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ICP"
+                ],
+                "summary": "Run an ICP profile as a search",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ICP ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Companies per page (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Companies to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/icp.RunResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/icp/{id}/runs": {
+            "get": {
+                "description": "Lists a profile's runs, newest first, with their local match counts and the outcome of their enrichment jobs",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ICP"
+                ],
+                "summary": "List the runs of an ICP profile",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ICP ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Runs to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/icp.RunsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/icp/{id}/unarchive": {
+            "post": {
+                "description": "Restores an archived ICP profile",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ICP"
+                ],
+                "summary": "Unarchive an ICP profile",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ICP ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/icp.ICPProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/investors": {
+            "get": {
+                "description": "Lists investors whose name contains q, those backing the most companies first, with the size of their portfolio. Investors are taken from the investors named on funding rounds",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Investors"
+                ],
+                "summary": "Search investors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Part of the investor name",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results limit (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/company.InvestorsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/investors/autocomplete": {
+            "get": {
+                "description": "Suggests investors with a word of their name starting with q, those whose name starts with it first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Investors"
+                ],
+                "summary": "Autocomplete investor names",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the investor name",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Suggestions limit (default: 10, max: 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/model.Investor"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/investors/{id}": {
+            "get": {
+                "description": "Returns an investor and its portfolio: the companies it backed, each with the rounds it took part in and whether it led them, most recently backed first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Investors"
+                ],
+                "summary": "Get an investor profile",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Investor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.InvestorProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/stats": {
+            "get": {
+                "description": "Get current queue statistics and health",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Queue"
+                ],
+                "summary": "Get queue statistics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/user/{user_id}": {
+            "get": {
+                "description": "Get all jobs for a specific user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Queue"
+                ],
+                "summary": "Get user's jobs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/queue.SearchJob"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}": {
+            "get": {
+                "description": "Get the status of a search job by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Queue"
+                ],
+                "summary": "Get job status by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/queue.SearchJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/lists": {
+            "get": {
+                "description": "Lists the caller's own lists and the lists shared with their team, most recently changed first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lists"
+                ],
+                "summary": "List prospect lists",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller's team",
+                        "name": "X-Team",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results limit (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/lists.ListsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a list owned by the caller. Shared lists are visible to everyone on the list's team, which defaults to the caller's.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lists"
+                ],
+                "summary": "Create a prospect list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User creating the list",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller's team",
+                        "name": "X-Team",
+                        "in": "header"
+                    },
+                    {
+                        "description": "List",
+                        "name": "list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/lists.ListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/lists.ProspectList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/{id}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lists"
+                ],
+                "summary": "Get a prospect list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller's team",
+                        "name": "X-Team",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "List ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/lists.ProspectList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces a list's name, description, team and sharing. Only the owner can change a list.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lists"
+                ],
+                "summary": "Update a prospect list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "List owner",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller's team",
+                        "name": "X-Team",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "List ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "List",
+                        "name": "list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/lists.ListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/lists.ProspectList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a list and its memberships. Only the owner can delete a list.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lists"
+                ],
+                "summary": "Delete a prospect list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "List owner",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "List ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/{id}/companies": {
+            "get": {
+                "description": "Lists a list's companies with their status, notes and tags, in the order they were added",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lists"
+                ],
+                "summary": "List the companies on a list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller's team",
+                        "name": "X-Team",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "List ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "new, contacted, qualified or disqualified",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only companies with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results limit (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/lists.MembersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds up to 1000 companies by ID. Companies already on the list keep their status, notes and tags; unknown IDs are reported as missing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lists"
+                ],
+                "summary": "Add companies to a list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller's team",
+                        "name": "X-Team",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "List ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Company IDs",
+                        "name": "companies",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/lists.CompanyIDsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/lists.AddResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lists"
+                ],
+                "summary": "Remove companies from a list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller's team",
+                        "name": "X-Team",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "List ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Company IDs",
+                        "name": "companies",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/lists.CompanyIDsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/lists.RemoveResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/{id}/companies/from-search": {
+            "post": {
+                "description": "Runs a company search with the same filters as /companies/search and adds up to max of the matches to the list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lists"
+                ],
+                "summary": "Add search results to a list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller's team",
+                        "name": "X-Team",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "List ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search query, or an advanced query when q_mode=advanced",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "simple",
+                            "advanced"
+                        ],
+                        "type": "string",
+                        "description": "Query mode: simple (default) or advanced",
+                        "name": "q_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Industry filter",
+                        "name": "industry",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Employee size range (e.g., 1-10, 11-50)",
+                        "name": "employee_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Location filter (free-text HQ location)",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Country name or ISO-3166 code",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "State/region name or ISO-3166-2 code",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "City name",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Radius search center: place name or lat,lon",
+                        "name": "near",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Radius for near in km",
+                        "name": "radius_km",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Funding stage",
+                        "name": "funding_stage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Founded after year",
+                        "name": "founded_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Founded before year",
+                        "name": "founded_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Company status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Most companies to add (default and max: 1000)",
+                        "name": "max",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/lists.AddResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/{id}/companies/{company_id}": {
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lists"
+                ],
+                "summary": "Remove a company from a list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller's team",
+                        "name": "X-Team",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "List ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Company ID",
+                        "name": "company_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Sets the status, notes or tags of a company on the list. Fields left out are unchanged; tags replace the existing ones.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lists"
+                ],
+                "summary": "Update a company on a list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller's team",
+                        "name": "X-Team",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "List ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Company ID",
+                        "name": "company_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Changes",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/lists.MemberUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/lists.ListMember"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/{id}/export": {
+            "get": {
+                "description": "Downloads every company on the list with its status, notes and tags, as CSV (default) or JSON",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Lists"
+                ],
+                "summary": "Export a list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller's team",
+                        "name": "X-Team",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "List ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "description": "csv (default) or json",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "new, contacted, qualified or disqualified",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only companies with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/lists.MembersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "get": {
+                "description": "Lists the caller's in-app notifications, newest first, with the number still unread",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "List notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only unread notifications",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results limit (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/notifications.ListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/read-all": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Mark all notifications as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/notifications.MarkReadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{id}/read": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Mark a notification as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/notifications.Notification"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/saved-searches": {
+            "get": {
+                "description": "Lists the caller's saved searches, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved searches"
+                ],
+                "summary": "List saved searches",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results limit (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/searches.ListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Saves a company search for the caller. The worker re-runs enabled searches hourly and notifies the owner of companies that did not match before; the first run records a baseline.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved searches"
+                ],
+                "summary": "Save a company search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Saved search",
+                        "name": "search",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/searches.SavedSearchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/searches.SavedSearch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/saved-searches/{id}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved searches"
+                ],
+                "summary": "Get a saved search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Saved search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/searches.SavedSearch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces a saved search's name, filters and enabled flag. Companies seen before stay seen.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved searches"
+                ],
+                "summary": "Update a saved search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Saved search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Saved search",
+                        "name": "search",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/searches.SavedSearchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/searches.SavedSearch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved searches"
+                ],
+                "summary": "Delete a saved search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Saved search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/saved-searches/{id}/run": {
+            "post": {
+                "description": "Evaluates the search immediately and returns the companies that are new since earlier runs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved searches"
+                ],
+                "summary": "Run a saved search now",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Saved search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/searches.RunDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/saved-searches/{id}/runs": {
+            "get": {
+                "description": "Lists past evaluations of the search, newest first, with the IDs of the companies new on each",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved searches"
+                ],
+                "summary": "List a saved search's runs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Saved search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results limit (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/searches.RunsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/saved-searches/{id}/runs/{run_id}": {
+            "get": {
+                "description": "Returns one evaluation of the search with the companies that were new on it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved searches"
+                ],
+                "summary": "Get a saved search run",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Saved search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Run ID",
+                        "name": "run_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/searches.RunDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/signals": {
+            "get": {
+                "description": "Lists detected buying signals (funding raised, headcount growth, technology added, location opened), newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Signals"
+                ],
+                "summary": "Buying signal feed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only companies matching this ICP profile",
+                        "name": "icp_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated signal types (funding_raised, headcount_growth, technology_added, location_opened)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only this company",
+                        "name": "company_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signals at or after this time (RFC3339 or YYYY-MM-DD)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results limit (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/signals.FeedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters, an ICP without company criteria, or ICP criteria that no longer resolve, listed per field",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/signals/detect": {
+            "post": {
+                "description": "Processes company changes recorded since the last run (this also runs periodically in the worker)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Signals"
+                ],
+                "summary": "Detect signals now",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/taxonomies": {
+            "get": {
+                "description": "Returns the industries, company sizes, business types and buyer roles companies, contacts and ICPs are classified with, each in display order. Parent IDs place a term under another one of the same taxonomy.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Taxonomies"
+                ],
+                "summary": "List all taxonomies",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/taxonomy.TaxonomiesResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/taxonomies/{kind}": {
+            "get": {
+                "description": "Returns the terms of a taxonomy in display order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Taxonomies"
+                ],
+                "summary": "List one taxonomy",
+                "parameters": [
+                    {
+                        "enum": [
+                            "industries",
+                            "company_sizes",
+                            "business_types",
+                            "buyer_roles"
+                        ],
+                        "type": "string",
+                        "description": "Taxonomy",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/taxonomy.Term"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a term under the given ID or the next unused one. Names and aliases must be unique within the taxonomy, ignoring case.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Taxonomies"
+                ],
+                "summary": "Add a term to a taxonomy",
+                "parameters": [
+                    {
+                        "enum": [
+                            "industries",
+                            "company_sizes",
+                            "business_types",
+                            "buyer_roles"
+                        ],
+                        "type": "string",
+                        "description": "Taxonomy",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Term",
+                        "name": "term",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/taxonomy.TermRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/taxonomy.Term"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/taxonomies/{kind}/{id}": {
+            "put": {
+                "description": "Replaces a term's name, aliases and parent, and its position when given. The ID cannot change.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Taxonomies"
+                ],
+                "summary": "Update a taxonomy term",
+                "parameters": [
+                    {
+                        "enum": [
+                            "industries",
+                            "company_sizes",
+                            "business_types",
+                            "buyer_roles"
+                        ],
+                        "type": "string",
+                        "description": "Taxonomy",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Term ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Term",
+                        "name": "term",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/taxonomy.TermRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/taxonomy.Term"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Hides a term added through the API. Builtin terms and terms with children cannot be deleted, and the ID is never reused.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Taxonomies"
+                ],
+                "summary": "Delete a taxonomy term",
+                "parameters": [
+                    {
+                        "enum": [
+                            "industries",
+                            "company_sizes",
+                            "business_types",
+                            "buyer_roles"
+                        ],
+                        "type": "string",
+                        "description": "Taxonomy",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Term ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "Lists all webhook endpoints (secrets are not returned)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhook endpoints",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/webhook.Endpoint"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Subscribes a URL to events (job.completed, job.failed, company.created, company.updated, signal.detected). Deliveries are POSTed as JSON with X-Fynelo-Event, X-Fynelo-Delivery, X-Fynelo-Timestamp and X-Fynelo-Signature headers; the signature is \"sha256=\" + hex HMAC-SHA256 of \"\u003ctimestamp\u003e.\u003cbody\u003e\" keyed with the secret. The secret is generated when omitted and only returned by this call.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Create a webhook endpoint",
+                "parameters": [
+                    {
+                        "description": "Endpoint",
+                        "name": "endpoint",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhook.EndpointRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/webhook.Endpoint"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "description": "Fetches a webhook endpoint by ID (the secret is not returned)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get a webhook endpoint",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Endpoint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webhook.Endpoint"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces an endpoint's URL, events, description and active flag. The secret is rotated only when a new one is given.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Update a webhook endpoint",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Endpoint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Endpoint",
+                        "name": "endpoint",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhook.EndpointRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webhook.Endpoint"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a webhook endpoint; its pending deliveries are marked failed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete a webhook endpoint",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Endpoint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "Lists an endpoint's deliveries newest first, with attempts, response status and errors",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Webhook delivery log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Endpoint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending, succeeded or failed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event type",
+                        "name": "event_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results limit (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webhook.DeliveryListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/test": {
+            "post": {
+                "description": "Sends a signed webhook.test event to the endpoint immediately and returns the logged delivery",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Test-fire a webhook endpoint",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Endpoint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webhook.Delivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "company.ImportCompaniesRequest": {
+            "type": "object",
+            "required": [
+                "companies"
+            ],
+            "properties": {
+                "companies": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.Company"
+                    }
+                }
+            }
+        },
+        "company.InvestorsResponse": {
+            "type": "object",
+            "properties": {
+                "investors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repositories.InvestorSummary"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "company.UnmappedIndustriesResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "unmapped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UnmappedIndustry"
+                    }
+                }
+            }
+        },
+        "contact.Contact": {
+            "type": "object",
+            "properties": {
+                "buyer_role": {
+                    "type": "integer"
+                },
+                "company": {
+                    "$ref": "#/definitions/model.Company"
+                },
+                "company_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "department": {
+                    "$ref": "#/definitions/contact.Department"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "email_checked_at": {
+                    "type": "string"
+                },
+                "email_confidence": {
+                    "description": "0..1",
+                    "type": "number"
+                },
+                "email_source": {
+                    "type": "string"
+                },
+                "email_status": {
+                    "description": "Email verification, reset whenever the email changes",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/contact.EmailStatus"
+                        }
+                    ]
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "linkedin_url": {
+                    "type": "string"
+                },
+                "seniority": {
+                    "$ref": "#/definitions/contact.Seniority"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "contact.ContactRequest": {
+            "type": "object",
+            "required": [
+                "company_id",
+                "first_name"
+            ],
+            "properties": {
+                "buyer_role": {
+                    "type": "integer"
+                },
+                "company_id": {
+                    "type": "integer"
+                },
+                "department": {
+                    "$ref": "#/definitions/contact.Department"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "linkedin_url": {
+                    "type": "string"
+                },
+                "seniority": {
+                    "$ref": "#/definitions/contact.Seniority"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "contact.DecisionMakersResponse": {
+            "type": "object",
+            "properties": {
+                "buyer_roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "company_id": {
+                    "type": "integer"
+                },
+                "contacts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contact.Contact"
+                    }
+                },
+                "icp_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "contact.Department": {
+            "type": "string",
+            "enum": [
+                "executive",
+                "engineering",
+                "it",
+                "product",
+                "marketing",
+                "sales",
+                "finance",
+                "hr",
+                "operations",
+                "other"
+            ],
+            "x-enum-varnames": [
+                "DepartmentExecutive",
+                "DepartmentEngineering",
+                "DepartmentIT",
+                "DepartmentProduct",
+                "DepartmentMarketing",
+                "DepartmentSales",
+                "DepartmentFinance",
+                "DepartmentHR",
+                "DepartmentOperations",
+                "DepartmentOther"
+            ]
+        },
+        "contact.EmailStatus": {
+            "type": "string",
+            "enum": [
+                "unverified",
+                "valid",
+                "invalid",
+                "no_mx",
+                "unknown"
+            ],
+            "x-enum-comments": {
+                "EmailInvalid": "malformed address",
+                "EmailNoMX": "the domain has no mail servers",
+                "EmailUnknown": "the DNS lookup failed; try again later",
+                "EmailUnverified": "not checked yet",
+                "EmailValid": "well-formed and the domain accepts mail"
+            },
+            "x-enum-descriptions": [
+                "not checked yet",
+                "well-formed and the domain accepts mail",
+                "malformed address",
+                "the domain has no mail servers",
+                "the DNS lookup failed; try again later"
+            ],
+            "x-enum-varnames": [
+                "EmailUnverified",
+                "EmailValid",
+                "EmailInvalid",
+                "EmailNoMX",
+                "EmailUnknown"
+            ]
+        },
+        "contact.SearchResponse": {
+            "type": "object",
+            "properties": {
+                "contacts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contact.Contact"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "contact.Seniority": {
+            "type": "string",
+            "enum": [
+                "c_suite",
+                "vp",
+                "director",
+                "manager",
+                "individual"
+            ],
+            "x-enum-comments": {
+                "SeniorityCSuite": "chiefs, founders, owners, presidents",
+                "SeniorityDirector": "including \"head of\"",
+                "SeniorityManager": "including leads"
+            },
+            "x-enum-descriptions": [
+                "chiefs, founders, owners, presidents",
+                "",
+                "including \"head of\"",
+                "including leads",
+                ""
+            ],
+            "x-enum-varnames": [
+                "SeniorityCSuite",
+                "SeniorityVP",
+                "SeniorityDirector",
+                "SeniorityManager",
+                "SeniorityIndividual"
+            ]
+        },
+        "emailfinder.Candidate": {
+            "type": "object",
+            "properties": {
+                "confidence": {
+                    "type": "number"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "pattern": {
+                    "type": "string"
+                }
+            }
+        },
+        "emailfinder.CandidatesResponse": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "description": "most likely first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/emailfinder.Candidate"
+                    }
+                },
+                "contact_id": {
+                    "type": "integer"
+                },
+                "domain": {
+                    "type": "string"
+                },
+                "domain_status": {
+                    "description": "valid, no_mx or unknown",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/contact.EmailStatus"
+                        }
+                    ]
+                },
+                "samples": {
+                    "type": "integer"
+                }
+            }
+        },
+        "emailfinder.EmailResult": {
+            "type": "object",
+            "properties": {
+                "contact": {
+                    "$ref": "#/definitions/contact.Contact"
+                },
+                "pattern": {
+                    "description": "set for inferred emails",
+                    "type": "string"
+                },
+                "verification": {
+                    "$ref": "#/definitions/emailfinder.Verification"
+                }
+            }
+        },
+        "emailfinder.PatternInference": {
+            "type": "object",
+            "properties": {
+                "company_id": {
+                    "type": "integer"
+                },
+                "domain": {
+                    "type": "string"
+                },
+                "patterns": {
+                    "description": "most likely first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/emailfinder.PatternScore"
+                    }
+                },
+                "samples": {
+                    "type": "integer"
+                }
+            }
+        },
+        "emailfinder.PatternScore": {
+            "type": "object",
+            "properties": {
+                "confidence": {
+                    "description": "0..1",
+                    "type": "number"
+                },
+                "matches": {
+                    "description": "known contacts whose email fits",
+                    "type": "integer"
+                },
+                "pattern": {
+                    "type": "string"
+                }
+            }
+        },
+        "emailfinder.Verification": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "mx_hosts": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/contact.EmailStatus"
+                }
+            }
+        },
+        "icp.AnalyticsBucket": {
+            "type": "object",
+            "properties": {
+                "jobs_completed": {
+                    "type": "integer"
+                },
+                "jobs_queued": {
+                    "type": "integer"
+                },
+                "list_additions": {
+                    "type": "integer"
+                },
+                "matches": {
+                    "type": "integer"
+                },
+                "qualified": {
+                    "type": "integer"
+                },
+                "runs": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
+        "icp.AnalyticsResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "icp_id": {
+                    "type": "integer"
+                },
+                "interval": {
+                    "type": "string"
+                },
+                "series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/icp.AnalyticsBucket"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "totals": {
+                    "$ref": "#/definitions/icp.ICPMetrics"
+                }
+            }
+        },
+        "icp.CloneRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "icp.FieldChange": {
+            "type": "object",
+            "properties": {
+                "added": {},
+                "field": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "removed": {},
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "icp.ICPCriteria": {
+            "type": "object",
+            "properties": {
+                "business_types": {
+                    "description": "constants.BusinessType*",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "buyer_roles": {
+                    "description": "constants.BuyerRole*",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "company_sizes": {
+                    "description": "constants.CompanySize*",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "excluded_technologies": {
+                    "description": "companies must use none of these",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "funding_stages": {
+                    "description": "model.FundingRoundType values",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "geographies": {
+                    "description": "ISO-3166 country or ISO-3166-2 region codes",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "industries": {
+                    "description": "constants.Industry*",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "problem_statement": {
+                    "type": "string"
+                },
+                "required_technologies": {
+                    "description": "companies must use all of these",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "revenue_bands": {
+                    "description": "constants.RevenueBand*",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "icp.ICPDiff": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/icp.FieldChange"
+                    }
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "icp_id": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "icp.ICPMetrics": {
+            "type": "object",
+            "properties": {
+                "jobs_completed": {
+                    "type": "integer"
+                },
+                "jobs_queued": {
+                    "type": "integer"
+                },
+                "list_additions": {
+                    "type": "integer"
+                },
+                "matches": {
+                    "type": "integer"
+                },
+                "qualified": {
+                    "type": "integer"
+                },
+                "runs": {
+                    "type": "integer"
+                }
+            }
+        },
+        "icp.ICPProfile": {
+            "type": "object",
+            "properties": {
+                "archived_at": {
+                    "description": "set instead of deleting",
+                    "type": "string"
+                },
+                "business_types": {
+                    "description": "constants.BusinessType*",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "buyer_roles": {
+                    "description": "constants.BuyerRole*",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "company_sizes": {
+                    "description": "constants.CompanySize*",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "excluded_technologies": {
+                    "description": "companies must use none of these",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "funding_stages": {
+                    "description": "model.FundingRoundType values",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "geographies": {
+                    "description": "ISO-3166 country or ISO-3166-2 region codes",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "industries": {
+                    "description": "constants.Industry*",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "problem_statement": {
+                    "type": "string"
+                },
+                "required_technologies": {
+                    "description": "companies must use all of these",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "revenue_bands": {
+                    "description": "constants.RevenueBand*",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "version": {
+                    "description": "latest revision",
+                    "type": "integer"
+                }
+            }
+        },
+        "icp.ICPRevision": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "criteria": {
+                    "$ref": "#/definitions/icp.ICPCriteria"
+                },
+                "icp_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "icp.ICPRun": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "icp_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "job_error": {
+                    "type": "string"
+                },
+                "job_finished_at": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "job_result_count": {
+                    "type": "integer"
+                },
+                "job_status": {
+                    "description": "queue.JobStatus values",
+                    "type": "string"
+                },
+                "matched": {
+                    "description": "local companies matching the profile",
+                    "type": "integer"
+                },
+                "new_matches": {
+                    "description": "matches not seen on earlier runs",
+                    "type": "integer"
+                },
+                "query": {
+                    "description": "advanced-mode search query",
+                    "type": "string"
+                }
+            }
+        },
+        "icp.ICPSummary": {
+            "type": "object",
+            "properties": {
+                "archived_at": {
+                    "type": "string"
+                },
+                "icp_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "totals": {
+                    "$ref": "#/definitions/icp.ICPMetrics"
+                }
+            }
+        },
+        "icp.ICPTemplate": {
+            "type": "object",
+            "properties": {
+                "criteria": {
+                    "$ref": "#/definitions/icp.ICPCriteria"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "summary": {
+                    "type": "string"
+                }
+            }
+        },
+        "icp.Lookalike": {
+            "type": "object",
+            "properties": {
+                "company": {
+                    "$ref": "#/definitions/model.Company"
+                },
+                "matched": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "similarity": {
+                    "type": "number"
+                }
+            }
+        },
+        "icp.LookalikeRequest": {
+            "type": "object",
+            "required": [
+                "company_ids"
+            ],
+            "properties": {
+                "company_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "limit": {
+                    "description": "lookalikes to return (default 20, max 100)",
+                    "type": "integer"
+                }
+            }
+        },
+        "icp.LookalikeResponse": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "description": "companies scored, all sharing a seed value",
+                    "type": "integer"
+                },
+                "draft": {
+                    "description": "unsaved; create it with POST /icp",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/icp.ICPProfile"
+                        }
+                    ]
+                },
+                "lookalikes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/icp.Lookalike"
+                    }
+                },
+                "missing_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "seed_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "weights": {
+                    "$ref": "#/definitions/icp.LookalikeWeights"
+                }
+            }
+        },
+        "icp.LookalikeWeights": {
+            "type": "object",
+            "properties": {
+                "company_sizes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                },
+                "funding_stages": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                },
+                "geographies": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                },
+                "industries": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                },
+                "technologies": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                }
+            }
+        },
+        "icp.RevisionsResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "revisions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/icp.ICPRevision"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "icp.RunResponse": {
+            "type": "object",
+            "properties": {
+                "companies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Company"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "queued_job": {
+                    "$ref": "#/definitions/service.QueuedJob"
+                },
+                "run": {
+                    "$ref": "#/definitions/icp.ICPRun"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "icp.RunsResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "runs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/icp.ICPRun"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "icp.SummaryResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "icps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/icp.ICPSummary"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "icp.TemplateRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "industry.Classification": {
+            "type": "object",
+            "properties": {
+                "confidence": {
+                    "description": "0..1",
+                    "type": "number"
+                },
+                "industry": {
+                    "type": "string"
+                },
+                "industry_id": {
+                    "type": "integer"
+                },
+                "match": {
+                    "description": "code prefix, name or keywords that matched",
+                    "type": "string"
+                },
+                "method": {
+                    "$ref": "#/definitions/industry.Method"
+                },
+                "source": {
+                    "$ref": "#/definitions/industry.Source"
+                }
+            }
+        },
+        "industry.Input": {
+            "type": "object",
+            "properties": {
+                "naics_code": {
+                    "type": "string"
+                },
+                "sic_code": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "industry.Method": {
+            "type": "string",
+            "enum": [
+                "code",
+                "taxonomy",
+                "keyword"
+            ],
+            "x-enum-comments": {
+                "MethodCode": "built-in NAICS or SIC prefix",
+                "MethodKeyword": "words in a description",
+                "MethodTaxonomy": "taxonomy name or alias"
+            },
+            "x-enum-descriptions": [
+                "built-in NAICS or SIC prefix",
+                "taxonomy name or alias",
+                "words in a description"
+            ],
+            "x-enum-varnames": [
+                "MethodCode",
+                "MethodTaxonomy",
+                "MethodKeyword"
+            ]
+        },
+        "industry.Result": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "description": "one per matched input, most confident first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/industry.Classification"
+                    }
+                },
+                "classification": {
+                    "$ref": "#/definitions/industry.Classification"
+                },
+                "unmapped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/industry.Value"
+                    }
+                }
+            }
+        },
+        "industry.Source": {
+            "type": "string",
+            "enum": [
+                "naics",
+                "sic",
+                "text"
+            ],
+            "x-enum-varnames": [
+                "SourceNAICS",
+                "SourceSIC",
+                "SourceText"
+            ]
+        },
+        "industry.Value": {
+            "type": "object",
+            "properties": {
+                "source": {
+                    "$ref": "#/definitions/industry.Source"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "lists.AddResult": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "integer"
+                },
+                "matched": {
+                    "type": "integer"
+                },
+                "missing": {
+                    "description": "no such company",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "skipped": {
+                    "description": "already on the list",
+                    "type": "integer"
+                }
+            }
+        },
+        "lists.CompanyIDsRequest": {
+            "type": "object",
+            "required": [
+                "company_ids"
+            ],
+            "properties": {
+                "company_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "lists.ListMember": {
+            "type": "object",
+            "properties": {
+                "added_by": {
+                    "type": "string"
+                },
+                "company": {
+                    "$ref": "#/definitions/model.Company"
+                },
+                "company_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "list_id": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/lists.MemberStatus"
+                },
+                "tags": {
+                    "description": "lowercased, sorted",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "lists.ListRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "shared": {
+                    "type": "boolean"
+                },
+                "team": {
+                    "type": "string"
+                }
+            }
+        },
+        "lists.ListsResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "lists": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/lists.ProspectList"
+                    }
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "lists.MemberStatus": {
+            "type": "string",
+            "enum": [
+                "new",
+                "contacted",
+                "qualified",
+                "disqualified"
+            ],
+            "x-enum-varnames": [
+                "StatusNew",
+                "StatusContacted",
+                "StatusQualified",
+                "StatusDisqualified"
+            ]
+        },
+        "lists.MemberUpdate": {
+            "type": "object",
+            "properties": {
+                "notes": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/lists.MemberStatus"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "lists.MembersResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/lists.ListMember"
+                    }
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "lists.ProspectList": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "member_count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "shared": {
+                    "type": "boolean"
+                },
+                "team": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "lists.RemoveResult": {
+            "type": "object",
+            "properties": {
+                "removed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ChangeOperation": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "delete"
+            ],
+            "x-enum-varnames": [
+                "ChangeCreate",
+                "ChangeUpdate",
+                "ChangeDelete"
+            ]
+        },
+        "model.Company": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "domain": {
+                    "description": "canonical host derived from Website",
+                    "type": "string"
+                },
+                "employee_count": {
+                    "description": "exact, or an estimate when EmployeeCountEstimated",
+                    "type": "integer"
+                },
+                "employee_count_at": {
+                    "description": "when the count was observed",
+                    "type": "string"
+                },
+                "employee_count_estimated": {
+                    "type": "boolean"
+                },
+                "employee_size_id": {
+                    "description": "Changed from EmployeeRange to use constants",
+                    "type": "integer"
+                },
+                "employees": {
+                    "description": "import input only: \"~250\", \"1k-5k\", \"500+\"",
+                    "type": "string"
+                },
+                "founded_year": {
+                    "type": "integer"
+                },
+                "funding_rounds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FundingRound"
+                    }
+                },
+                "hq_location": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "industry_id": {
+                    "description": "Changed to int to use constants",
+                    "type": "integer"
+                },
+                "industry_text": {
+                    "description": "free-text industry as reported",
+                    "type": "string"
+                },
+                "last_enriched_at": {
+                    "type": "string"
+                },
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Location"
+                    }
+                },
+                "naics_code": {
+                    "description": "as reported; mapped to IndustryID by the industry package",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revenues": {
+                    "description": "Relationships",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Revenue"
+                    }
+                },
+                "sic_code": {
+                    "description": "as reported",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "\"manual\", \"scraped\", \"api\"",
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.CompanyStatus"
+                },
+                "technologies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Technology"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CompanyChange": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "description": "user or job that made the change",
+                    "type": "string"
+                },
+                "changed_at": {
+                    "type": "string"
+                },
+                "company_id": {
+                    "type": "integer"
+                },
+                "entity_id": {
+                    "type": "integer"
+                },
+                "entity_type": {
+                    "$ref": "#/definitions/model.EntityType"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "new_value": {
+                    "type": "string"
+                },
+                "old_value": {
+                    "type": "string"
+                },
+                "operation": {
+                    "$ref": "#/definitions/model.ChangeOperation"
+                }
+            }
+        },
+        "model.CompanyMerge": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "merged_by": {
+                    "type": "string"
+                },
+                "merged_id": {
+                    "type": "integer"
+                },
+                "merged_name": {
+                    "type": "string"
+                },
+                "snapshot": {
+                    "description": "JSON of the merged company as it was before the merge",
+                    "type": "string"
+                },
+                "survivor_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CompanyStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "closed",
+                "acquired",
+                "ipo",
+                "private"
+            ],
+            "x-enum-varnames": [
+                "StatusActive",
+                "StatusClosed",
+                "StatusAcquired",
+                "StatusIPO",
+                "StatusPrivate"
+            ]
+        },
+        "model.DuplicateCandidate": {
+            "type": "object",
+            "properties": {
+                "cluster_id": {
+                    "description": "lowest company ID in the connected cluster",
+                    "type": "integer"
+                },
+                "company": {
+                    "$ref": "#/definitions/model.Company"
+                },
+                "company_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "duplicate": {
+                    "$ref": "#/definitions/model.Company"
+                },
+                "duplicate_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reasons": {
+                    "description": "comma-separated: domain, name, location",
+                    "type": "string"
+                },
+                "score": {
+                    "description": "0..1",
+                    "type": "number"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.DuplicateStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DuplicateStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "merged",
+                "dismissed"
+            ],
+            "x-enum-varnames": [
+                "DuplicatePending",
+                "DuplicateMerged",
+                "DuplicateDismissed"
+            ]
+        },
+        "model.EntityType": {
+            "type": "string",
+            "enum": [
+                "company",
+                "revenue",
+                "funding_round",
+                "technology",
+                "location",
+                "contact"
+            ],
+            "x-enum-varnames": [
+                "EntityCompany",
+                "EntityRevenue",
+                "EntityFundingRound",
+                "EntityTechnology",
+                "EntityLocation",
+                "EntityContact"
+            ]
+        },
+        "model.FieldProvenance": {
+            "type": "object",
+            "properties": {
+                "accepted": {
+                    "type": "boolean"
+                },
+                "company_id": {
+                    "type": "integer"
+                },
+                "confidence": {
+                    "description": "0..1",
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_key": {
+                    "description": "revenue year, funding round type or technology name; empty for company attributes",
+                    "type": "string"
+                },
+                "entity_type": {
+                    "$ref": "#/definitions/model.EntityType"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "observed_at": {
+                    "type": "string"
+                },
+                "provider": {
+                    "description": "e.g. the enrichment vendor",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "manual, api, scraped",
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FundingRound": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "nullable for undisclosed amounts",
+                    "type": "number"
+                },
+                "amount_usd": {
+                    "description": "Amount at the FX rate of Date",
+                    "type": "number"
+                },
+                "company_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "investors": {
+                    "description": "as reported: JSON or comma-separated; parsed into RoundInvestors",
+                    "type": "string"
+                },
+                "round_investors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FundingRoundInvestor"
+                    }
+                },
+                "round_type": {
+                    "$ref": "#/definitions/model.FundingRoundType"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FundingRoundInvestor": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "funding_round_id": {
+                    "type": "integer"
+                },
+                "investor": {
+                    "$ref": "#/definitions/model.Investor"
+                },
+                "investor_id": {
+                    "type": "integer"
+                },
+                "lead": {
+                    "description": "led the round rather than participated",
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.FundingRoundType": {
+            "type": "string",
+            "enum": [
+                "seed",
+                "series_a",
+                "series_b",
+                "series_c",
+                "series_d",
+                "ipo",
+                "acquisition"
+            ],
+            "x-enum-varnames": [
+                "RoundSeed",
+                "RoundSeriesA",
+                "RoundSeriesB",
+                "RoundSeriesC",
+                "RoundSeriesD",
+                "RoundIPO",
+                "RoundAcquisition"
+            ]
+        },
+        "model.Investor": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Location": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "company_id": {
+                    "type": "integer"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "country_code": {
+                    "description": "Normalized from the free-text fields above via the bundled gazetteer",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_headquarters": {
+                    "description": "Set on the location the company's hq_location names",
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "region_code": {
+                    "description": "ISO-3166-2, e.g. \"US-CA\"",
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Revenue": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "amount_usd": {
+                    "description": "Amount at the year's FX rate; nil for unknown currencies",
+                    "type": "number"
+                },
+                "company_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "USD, EUR, etc.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Technology": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "catalog category code, e.g. crm, cloud; nil when not known",
+                    "type": "string"
+                },
+                "company_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "technology_name": {
+                    "description": "canonical catalog name, or as reported when not in the catalog",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "vendor": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.UnmappedIndustry": {
+            "type": "object",
+            "properties": {
+                "company_id": {
+                    "description": "company it was last reported for",
+                    "type": "integer"
+                },
+                "first_seen_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "occurrences": {
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "naics, sic or text",
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "notifications.ListResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/notifications.Notification"
+                    }
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "unread": {
+                    "type": "integer"
+                }
+            }
+        },
+        "notifications.MarkReadResponse": {
+            "type": "object",
+            "properties": {
+                "marked": {
+                    "type": "integer"
+                }
+            }
+        },
+        "notifications.Notification": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "read_at": {
+                    "type": "string"
+                },
+                "recipient": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "queue.JobPriority": {
+            "type": "integer",
+            "enum": [
+                1,
+                2,
+                3,
+                4
+            ],
+            "x-enum-varnames": [
+                "PriorityLow",
+                "PriorityNormal",
+                "PriorityHigh",
+                "PriorityUrgent"
+            ]
+        },
+        "queue.JobStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "processing",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "StatusPending",
+                "StatusProcessing",
+                "StatusCompleted",
+                "StatusFailed"
+            ]
+        },
+        "queue.SearchFilters": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "employee_size": {
+                    "type": "string"
+                },
+                "employees_max": {
+                    "type": "integer"
+                },
+                "employees_min": {
+                    "type": "integer"
+                },
+                "founded_max": {
+                    "type": "integer"
+                },
+                "founded_min": {
+                    "type": "integer"
+                },
+                "funding_stage": {
+                    "type": "string"
+                },
+                "industry": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "queue.SearchJob": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error_msg": {
+                    "type": "string"
+                },
+                "filters": {
+                    "$ref": "#/definitions/queue.SearchFilters"
+                },
+                "icp_id": {
+                    "description": "ICP profile whose run queued the job",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "max_retries": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "$ref": "#/definitions/queue.JobPriority"
+                },
+                "processed_at": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "query_mode": {
+                    "description": "\"advanced\" when Query uses the search query language",
+                    "type": "string"
+                },
+                "result_count": {
+                    "type": "integer"
+                },
+                "retry_count": {
+                    "description": "For future use",
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/queue.JobStatus"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "repositories.FacetBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "repositories.InvestorSummary": {
+            "type": "object",
+            "properties": {
+                "companies": {
+                    "description": "portfolio companies",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lead_rounds": {
+                    "description": "rounds led",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rounds": {
+                    "description": "rounds taken part in",
+                    "type": "integer"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "repositories.PortfolioRound": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "amount_usd": {
+                    "type": "number"
+                },
+                "company_id": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "funding_round_id": {
+                    "type": "integer"
+                },
+                "lead": {
+                    "type": "boolean"
+                },
+                "round_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "repositories.SearchFacets": {
+            "type": "object",
+            "properties": {
+                "countries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repositories.FacetBucket"
+                    }
+                },
+                "employee_sizes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repositories.FacetBucket"
+                    }
+                },
+                "funding_stages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repositories.FacetBucket"
+                    }
+                },
+                "industries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repositories.FacetBucket"
+                    }
+                },
+                "revenue_bands": {
+                    "description": "latest reported revenue, in USD",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repositories.FacetBucket"
+                    }
+                },
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repositories.FacetBucket"
+                    }
+                },
+                "technologies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repositories.FacetBucket"
+                    }
+                }
+            }
+        },
+        "searches.ListResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "saved_searches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/searches.SavedSearch"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "searches.RunDetail": {
+            "type": "object",
+            "properties": {
+                "baseline": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "matched": {
+                    "type": "integer"
+                },
+                "new_companies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Company"
+                    }
+                },
+                "new_company_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "new_count": {
+                    "type": "integer"
+                },
+                "saved_search_id": {
+                    "type": "integer"
+                },
+                "truncated": {
+                    "description": "more companies matched than are tracked",
+                    "type": "boolean"
+                }
+            }
+        },
+        "searches.RunsResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "runs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/searches.SearchRun"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "searches.SavedSearch": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "re-run by the worker",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_run_at": {
+                    "type": "string"
+                },
+                "match_count": {
+                    "description": "matches on the last run",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "search": {
+                    "$ref": "#/definitions/service.CompanySearchRequest"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "searches.SavedSearchRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "enabled": {
+                    "description": "default true",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "search": {
+                    "$ref": "#/definitions/service.CompanySearchRequest"
+                }
+            }
+        },
+        "searches.SearchRun": {
+            "type": "object",
+            "properties": {
+                "baseline": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "matched": {
+                    "type": "integer"
+                },
+                "new_company_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "new_count": {
+                    "type": "integer"
+                },
+                "saved_search_id": {
+                    "type": "integer"
+                },
+                "truncated": {
+                    "description": "more companies matched than are tracked",
+                    "type": "boolean"
+                }
+            }
+        },
+        "service.CompanyDetails": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "domain": {
+                    "description": "canonical host derived from Website",
+                    "type": "string"
+                },
+                "employee_count": {
+                    "description": "exact, or an estimate when EmployeeCountEstimated",
+                    "type": "integer"
+                },
+                "employee_count_at": {
+                    "description": "when the count was observed",
+                    "type": "string"
+                },
+                "employee_count_estimated": {
+                    "type": "boolean"
+                },
+                "employee_size_id": {
+                    "description": "Changed from EmployeeRange to use constants",
+                    "type": "integer"
+                },
+                "employees": {
+                    "description": "import input only: \"~250\", \"1k-5k\", \"500+\"",
+                    "type": "string"
+                },
+                "founded_year": {
+                    "type": "integer"
+                },
+                "funding_rounds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FundingRound"
+                    }
+                },
+                "hq_location": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "industry_id": {
+                    "description": "Changed to int to use constants",
+                    "type": "integer"
+                },
+                "industry_text": {
+                    "description": "free-text industry as reported",
+                    "type": "string"
+                },
+                "last_enriched_at": {
+                    "type": "string"
+                },
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Location"
+                    }
+                },
+                "metrics": {
+                    "$ref": "#/definitions/service.CompanyMetrics"
+                },
+                "naics_code": {
+                    "description": "as reported; mapped to IndustryID by the industry package",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revenues": {
+                    "description": "Relationships",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Revenue"
+                    }
+                },
+                "sic_code": {
+                    "description": "as reported",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "\"manual\", \"scraped\", \"api\"",
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.CompanyStatus"
+                },
+                "technologies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Technology"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.CompanyMetrics": {
+            "type": "object",
+            "properties": {
+                "funding_rounds": {
+                    "type": "integer"
+                },
+                "headquarters_count": {
+                    "description": "locations flagged is_headquarters",
+                    "type": "integer"
+                },
+                "last_raised_at": {
+                    "description": "date of the latest dated round",
+                    "type": "string"
+                },
+                "latest_revenue_year": {
+                    "type": "integer"
+                },
+                "latest_round": {
+                    "description": "latest dated round, or the last recorded if none is dated",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/service.LatestRound"
+                        }
+                    ]
+                },
+                "months_since_last_raise": {
+                    "description": "whole months since LastRaisedAt",
+                    "type": "integer"
+                },
+                "office_count": {
+                    "description": "the other locations",
+                    "type": "integer"
+                },
+                "revenue_growth_yoy": {
+                    "description": "percent change of the latest year over the one before; nil without both",
+                    "type": "number"
+                },
+                "technology_categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.TechnologyCategory"
+                    }
+                },
+                "total_funding_usd": {
+                    "description": "of the rounds with a disclosed amount and known FX rate",
+                    "type": "number"
+                }
+            }
+        },
+        "service.CompanySearchRequest": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "description": "city name",
+                    "type": "string"
+                },
+                "country": {
+                    "description": "country name or ISO code",
+                    "type": "string"
+                },
+                "employee_size": {
+                    "type": "string"
+                },
+                "employees_max": {
+                    "type": "integer"
+                },
+                "employees_min": {
+                    "description": "employee count range",
+                    "type": "integer"
+                },
+                "founded_max": {
+                    "type": "integer"
+                },
+                "founded_min": {
+                    "type": "integer"
+                },
+                "funding_min": {
+                    "description": "total disclosed funding, USD",
+                    "type": "number"
+                },
+                "funding_stage": {
+                    "type": "string"
+                },
+                "industry": {
+                    "type": "string"
+                },
+                "investor": {
+                    "description": "investor ID or name",
+                    "type": "string"
+                },
+                "investor_lead": {
+                    "description": "only rounds the investor led",
+                    "type": "boolean"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "near": {
+                    "description": "place name (\"Berlin\") or \"lat,lon\"",
+                    "type": "string"
+                },
+                "q": {
+                    "type": "string"
+                },
+                "q_mode": {
+                    "type": "string"
+                },
+                "radius_km": {
+                    "description": "radius for near (default 50 km)",
+                    "type": "number"
+                },
+                "revenue_max": {
+                    "type": "number"
+                },
+                "revenue_min": {
+                    "description": "latest reported revenue, USD",
+                    "type": "number"
+                },
+                "sort": {
+                    "description": "revenue or funding, highest first",
+                    "type": "string"
+                },
+                "state": {
+                    "description": "state/region name or ISO-3166-2 code",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tech_category": {
+                    "description": "technology category code or name, e.g. crm",
+                    "type": "string"
+                }
+            }
+        },
+        "service.CompanySearchResponse": {
+            "type": "object",
+            "properties": {
+                "companies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Company"
+                    }
+                },
+                "facets": {
+                    "$ref": "#/definitions/repositories.SearchFacets"
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "queued_jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.QueuedJob"
+                    }
+                },
+                "search_time": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.CompanySnapshot": {
+            "type": "object",
+            "properties": {
+                "as_of": {
+                    "type": "string"
+                },
+                "company": {
+                    "$ref": "#/definitions/model.Company"
+                }
+            }
+        },
+        "service.ConvertedFundingRound": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "converted": {
+                    "type": "number"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "investors": {
+                    "type": "string"
+                },
+                "rate": {
+                    "description": "units of the requested currency per unit of Currency",
+                    "type": "number"
+                },
+                "rate_date": {
+                    "description": "date of the FX table entry used",
+                    "type": "string"
+                },
+                "round_type": {
+                    "$ref": "#/definitions/model.FundingRoundType"
+                }
+            }
+        },
+        "service.ConvertedRevenue": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "converted": {
+                    "type": "number"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "rate": {
+                    "description": "units of the requested currency per unit of Currency",
+                    "type": "number"
+                },
+                "rate_date": {
+                    "description": "date of the FX table entry used",
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.DuplicateScanResult": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "type": "integer"
+                },
+                "clusters": {
+                    "type": "integer"
+                },
+                "companies_scanned": {
+                    "type": "integer"
+                },
+                "pairs_compared": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.EnrichmentOutcome": {
+            "type": "object",
+            "properties": {
+                "accepted": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "company": {
+                    "$ref": "#/definitions/model.Company"
+                },
+                "created": {
+                    "type": "boolean"
+                },
+                "industry": {
+                    "description": "classification of the reported codes and industry text",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/industry.Result"
+                        }
+                    ]
+                },
+                "rejected": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "service.EnrichmentResult": {
             "type": "object",
+            "required": [
+                "source"
+            ],
             "properties": {
-                "business_type": {
+                "company_id": {
+                    "description": "optional; otherwise matched by website domain, then by name",
                     "type": "integer"
                 },
-                "buyer_roles": {
+                "confidence": {
+                    "description": "0..1 (default 0.8, manual 1)",
+                    "type": "number"
+                },
+                "employee_count": {
                     "type": "integer"
                 },
-                "company_size": {
+                "employee_count_estimated": {
+                    "type": "boolean"
+                },
+                "employee_size_id": {
+                    "description": "otherwise derived from the employee count",
                     "type": "integer"
                 },
-                "created_at": {
+                "employees": {
+                    "description": "headcount as reported: \"250\", \"~250\", \"1k-5k\", \"500+\"; sets employee_count",
                     "type": "string"
                 },
-                "id": {
+                "founded_year": {
                     "type": "integer"
                 },
+                "funding_rounds": {
+                    "description": "matched by round type and date",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FundingRound"
+                    }
+                },
+                "hq_location": {
+                    "type": "string"
+                },
                 "industry": {
+                    "description": "free text, kept as industry_text",
                     "type": "string"
                 },
-                "problem_statement": {
+                "industry_id": {
+                    "description": "otherwise classified from the fields below",
+                    "type": "integer"
+                },
+                "locations": {
+                    "description": "matched by city, state and country; is_headquarters follows hq_location",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Location"
+                    }
+                },
+                "naics_code": {
+                    "description": "kept as reported",
                     "type": "string"
                 },
-                "updated_at": {
+                "name": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "integer"
+                "observed_at": {
+                    "description": "default now",
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "revenues": {
+                    "description": "matched by year",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Revenue"
+                    }
+                },
+                "sic_code": {
+                    "description": "kept as reported",
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.CompanyStatus"
+                },
+                "technologies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "website": {
+                    "type": "string"
                 }
             }
         },
-        "model.Company": {
+        "service.FieldExplanation": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "current": {
+                    "$ref": "#/definitions/model.FieldProvenance"
+                },
+                "entity_key": {
                     "type": "string"
                 },
-                "employee_size_id": {
-                    "description": "Changed from EmployeeRange to use constants",
-                    "type": "integer"
+                "entity_type": {
+                    "$ref": "#/definitions/model.EntityType"
                 },
-                "founded_year": {
+                "field": {
+                    "type": "string"
+                },
+                "observations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FieldProvenance"
+                    }
+                }
+            }
+        },
+        "service.FinancialsResponse": {
+            "type": "object",
+            "properties": {
+                "company_id": {
                     "type": "integer"
                 },
+                "currency": {
+                    "type": "string"
+                },
                 "funding_rounds": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.FundingRound"
+                        "$ref": "#/definitions/service.ConvertedFundingRound"
                     }
                 },
-                "hq_location": {
+                "revenues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ConvertedRevenue"
+                    }
+                },
+                "total_funding": {
+                    "description": "of the converted rounds",
+                    "type": "number"
+                }
+            }
+        },
+        "service.ImportFailure": {
+            "type": "object",
+            "properties": {
+                "error": {
                     "type": "string"
                 },
-                "id": {
+                "existing_company_id": {
+                    "description": "company already using the domain, on a domain conflict",
                     "type": "integer"
                 },
-                "industry_id": {
-                    "description": "Changed to int to use constants",
+                "index": {
                     "type": "integer"
                 },
-                "last_enriched_at": {
+                "name": {
                     "type": "string"
+                }
+            }
+        },
+        "service.ImportResult": {
+            "type": "object",
+            "properties": {
+                "companies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Company"
+                    }
                 },
-                "locations": {
+                "created": {
+                    "type": "integer"
+                },
+                "failed": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Location"
+                        "$ref": "#/definitions/service.ImportFailure"
                     }
                 },
-                "name": {
-                    "type": "string"
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.InvestorProfile": {
+            "type": "object",
+            "properties": {
+                "companies": {
+                    "type": "integer"
                 },
-                "revenues": {
-                    "description": "Relationships",
+                "investor": {
+                    "$ref": "#/definitions/model.Investor"
+                },
+                "lead_rounds": {
+                    "type": "integer"
+                },
+                "portfolio": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Revenue"
+                        "$ref": "#/definitions/service.PortfolioCompany"
                     }
                 },
-                "source": {
-                    "description": "\"manual\", \"scraped\", \"api\"",
+                "rounds": {
+                    "type": "integer"
+                },
+                "total_usd": {
+                    "description": "disclosed size of the rounds taken part in",
+                    "type": "number"
+                }
+            }
+        },
+        "service.LatestRound": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "amount_usd": {
+                    "type": "number"
+                },
+                "currency": {
                     "type": "string"
                 },
-                "status": {
-                    "$ref": "#/definitions/model.CompanyStatus"
+                "date": {
+                    "type": "string"
                 },
-                "technologies": {
+                "id": {
+                    "type": "integer"
+                },
+                "round_type": {
+                    "$ref": "#/definitions/model.FundingRoundType"
+                }
+            }
+        },
+        "service.MergeRequest": {
+            "type": "object",
+            "required": [
+                "merged_ids",
+                "survivor_id"
+            ],
+            "properties": {
+                "merged_by": {
+                    "type": "string"
+                },
+                "merged_ids": {
                     "type": "array",
+                    "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/model.Technology"
+                        "type": "integer"
                     }
                 },
-                "updated_at": {
+                "survivor_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.PortfolioCompany": {
+            "type": "object",
+            "properties": {
+                "company": {
+                    "$ref": "#/definitions/model.Company"
+                },
+                "last_round": {
+                    "$ref": "#/definitions/repositories.PortfolioRound"
+                },
+                "lead": {
+                    "description": "led at least one of the rounds",
+                    "type": "boolean"
+                },
+                "rounds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repositories.PortfolioRound"
+                    }
+                }
+            }
+        },
+        "service.QueuedJob": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
-                "website": {
+                "id": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "model.CompanyStatus": {
-            "type": "string",
-            "enum": [
-                "active",
-                "closed",
-                "acquired",
-                "ipo",
-                "private"
-            ],
-            "x-enum-varnames": [
-                "StatusActive",
-                "StatusClosed",
-                "StatusAcquired",
-                "StatusIPO",
-                "StatusPrivate"
-            ]
+        "service.ReclassifyResult": {
+            "type": "object",
+            "properties": {
+                "companies": {
+                    "description": "companies that were given an industry or moved out of Other",
+                    "type": "integer"
+                },
+                "resolved": {
+                    "description": "unmapped values that now map, removed from the list",
+                    "type": "integer"
+                }
+            }
+        },
+        "service.TechnologyCategory": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "technologies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "signals.FeedResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "signals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/signals.Signal"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
         },
-        "model.FundingRound": {
+        "signals.Signal": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "description": "nullable for undisclosed amounts",
-                    "type": "number"
+                "change_id": {
+                    "type": "integer"
                 },
                 "company": {
                     "$ref": "#/definitions/model.Company"
@@ -763,65 +9025,96 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
-                "currency": {
-                    "type": "string"
-                },
-                "date": {
+                "details": {
+                    "description": "JSON with type-specific fields",
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "investors": {
-                    "description": "JSON string or comma-separated",
+                "occurred_at": {
                     "type": "string"
                 },
-                "round_type": {
-                    "$ref": "#/definitions/model.FundingRoundType"
-                },
-                "updated_at": {
+                "title": {
                     "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/signals.SignalType"
                 }
             }
         },
-        "model.FundingRoundType": {
+        "signals.SignalType": {
             "type": "string",
             "enum": [
-                "seed",
-                "series_a",
-                "series_b",
-                "series_c",
-                "series_d",
-                "ipo",
-                "acquisition"
+                "funding_raised",
+                "headcount_growth",
+                "technology_added",
+                "location_opened"
             ],
             "x-enum-varnames": [
-                "RoundSeed",
-                "RoundSeriesA",
-                "RoundSeriesB",
-                "RoundSeriesC",
-                "RoundSeriesD",
-                "RoundIPO",
-                "RoundAcquisition"
+                "SignalFundingRaised",
+                "SignalHeadcountGrowth",
+                "SignalTechnologyAdded",
+                "SignalLocationOpened"
             ]
         },
-        "model.Location": {
+        "taxonomy.Kind": {
+            "type": "string",
+            "enum": [
+                "industries",
+                "company_sizes",
+                "business_types",
+                "buyer_roles"
+            ],
+            "x-enum-varnames": [
+                "Industries",
+                "CompanySizes",
+                "BusinessTypes",
+                "BuyerRoles"
+            ]
+        },
+        "taxonomy.TaxonomiesResponse": {
             "type": "object",
             "properties": {
-                "address": {
-                    "type": "string"
+                "business_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/taxonomy.Term"
+                    }
                 },
-                "city": {
-                    "type": "string"
+                "buyer_roles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/taxonomy.Term"
+                    }
                 },
-                "company": {
-                    "$ref": "#/definitions/model.Company"
+                "company_sizes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/taxonomy.Term"
+                    }
                 },
-                "company_id": {
-                    "type": "integer"
+                "industries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/taxonomy.Term"
+                    }
+                }
+            }
+        },
+        "taxonomy.Term": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "description": "lowercased",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "country": {
-                    "type": "string"
+                "builtin": {
+                    "description": "seeded; cannot be deleted",
+                    "type": "boolean"
                 },
                 "created_at": {
                     "type": "string"
@@ -829,216 +9122,266 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
-                "postal_code": {
-                    "type": "string"
+                "kind": {
+                    "$ref": "#/definitions/taxonomy.Kind"
                 },
-                "state": {
+                "name": {
                     "type": "string"
                 },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "description": "display order",
+                    "type": "integer"
+                },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "model.Revenue": {
+        "taxonomy.TermRequest": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "amount": {
-                    "type": "number"
-                },
-                "company": {
-                    "$ref": "#/definitions/model.Company"
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "company_id": {
+                "id": {
                     "type": "integer"
                 },
-                "created_at": {
-                    "type": "string"
-                },
-                "currency": {
-                    "description": "USD, EUR, etc.",
+                "name": {
                     "type": "string"
                 },
-                "id": {
+                "parent_id": {
                     "type": "integer"
                 },
-                "updated_at": {
-                    "type": "string"
-                },
-                "year": {
+                "position": {
                     "type": "integer"
                 }
             }
         },
-        "model.Technology": {
+        "techcatalog.Category": {
             "type": "object",
             "properties": {
-                "company": {
-                    "$ref": "#/definitions/model.Company"
-                },
-                "company_id": {
-                    "type": "integer"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "technology_name": {
+                "code": {
                     "type": "string"
                 },
-                "updated_at": {
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "queue.JobPriority": {
-            "type": "integer",
-            "enum": [
-                1,
-                2,
-                3,
-                4
-            ],
-            "x-enum-varnames": [
-                "PriorityLow",
-                "PriorityNormal",
-                "PriorityHigh",
-                "PriorityUrgent"
-            ]
-        },
-        "queue.JobStatus": {
-            "type": "string",
-            "enum": [
-                "pending",
-                "processing",
-                "completed",
-                "failed"
-            ],
-            "x-enum-varnames": [
-                "StatusPending",
-                "StatusProcessing",
-                "StatusCompleted",
-                "StatusFailed"
-            ]
-        },
-        "queue.SearchFilters": {
+        "techcatalog.Technology": {
             "type": "object",
             "properties": {
-                "employee_size": {
-                    "type": "string"
-                },
-                "founded_max": {
-                    "type": "integer"
-                },
-                "founded_min": {
-                    "type": "integer"
-                },
-                "funding_stage": {
-                    "type": "string"
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "industry": {
+                "category": {
                     "type": "string"
                 },
-                "location": {
+                "name": {
                     "type": "string"
                 },
-                "status": {
+                "vendor": {
                     "type": "string"
                 }
             }
         },
-        "queue.SearchJob": {
+        "webhook.Delivery": {
             "type": "object",
             "properties": {
-                "completed_at": {
-                    "type": "string"
+                "attempts": {
+                    "type": "integer"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "error_msg": {
+                "delivered_at": {
                     "type": "string"
                 },
-                "filters": {
-                    "$ref": "#/definitions/queue.SearchFilters"
+                "endpoint_id": {
+                    "type": "integer"
                 },
-                "id": {
+                "error": {
                     "type": "string"
                 },
-                "max_retries": {
+                "event_id": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "$ref": "#/definitions/webhook.EventType"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "priority": {
-                    "$ref": "#/definitions/queue.JobPriority"
+                "last_attempt_at": {
+                    "type": "string"
                 },
-                "processed_at": {
+                "next_attempt_at": {
                     "type": "string"
                 },
-                "query": {
+                "payload": {
+                    "description": "JSON body sent to the endpoint",
                     "type": "string"
                 },
-                "result_count": {
-                    "type": "integer"
+                "response_body": {
+                    "description": "truncated",
+                    "type": "string"
                 },
-                "retry_count": {
-                    "description": "For future use",
+                "response_status": {
                     "type": "integer"
                 },
                 "status": {
-                    "$ref": "#/definitions/queue.JobStatus"
+                    "$ref": "#/definitions/webhook.DeliveryStatus"
                 },
-                "user_id": {
-                    "type": "integer"
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "service.CompanySearchResponse": {
+        "webhook.DeliveryListResponse": {
             "type": "object",
             "properties": {
-                "companies": {
+                "deliveries": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Company"
+                        "$ref": "#/definitions/webhook.Delivery"
                     }
                 },
-                "has_more": {
-                    "type": "boolean"
-                },
                 "limit": {
                     "type": "integer"
                 },
                 "offset": {
                     "type": "integer"
                 },
-                "queued_jobs": {
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "webhook.DeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "succeeded",
+                "failed"
+            ],
+            "x-enum-comments": {
+                "DeliveryFailed": "attempts exhausted, or endpoint gone"
+            },
+            "x-enum-descriptions": [
+                "",
+                "",
+                "attempts exhausted, or endpoint gone"
+            ],
+            "x-enum-varnames": [
+                "DeliveryPending",
+                "DeliverySucceeded",
+                "DeliveryFailed"
+            ]
+        },
+        "webhook.Endpoint": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "events": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/service.QueuedJob"
+                        "$ref": "#/definitions/webhook.EventType"
                     }
                 },
-                "search_time": {
+                "id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "description": "only returned when the endpoint is created",
                     "type": "string"
                 },
-                "total": {
-                    "type": "integer"
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "service.QueuedJob": {
+        "webhook.EndpointRequest": {
             "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
             "properties": {
-                "created_at": {
+                "active": {
+                    "description": "defaults to true",
+                    "type": "boolean"
+                },
+                "description": {
                     "type": "string"
                 },
-                "id": {
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/webhook.EventType"
+                    }
+                },
+                "secret": {
+                    "description": "generated when empty on create, kept when empty on update",
                     "type": "string"
                 },
-                "status": {
+                "url": {
                     "type": "string"
                 }
             }
+        },
+        "webhook.EventType": {
+            "type": "string",
+            "enum": [
+                "job.completed",
+                "job.failed",
+                "company.created",
+                "company.updated",
+                "signal.detected",
+                "webhook.test"
+            ],
+            "x-enum-comments": {
+                "EventTest": "sent by the test-fire endpoint only"
+            },
+            "x-enum-descriptions": [
+                "",
+                "",
+                "",
+                "",
+                "",
+                "sent by the test-fire endpoint only"
+            ],
+            "x-enum-varnames": [
+                "EventJobCompleted",
+                "EventJobFailed",
+                "EventCompanyCreated",
+                "EventCompanyUpdated",
+                "EventSignalDetected",
+                "EventTest"
+            ]
         }
     }
 }`
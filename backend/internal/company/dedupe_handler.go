@@ -0,0 +1,122 @@
+package company
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/company/service"
+	"github.com/bhati00/Fynelo/backend/pkg/pagination"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ListDuplicatesHandler godoc
+// @Summary List duplicate candidates
+// @Description List likely duplicate company pairs found by the duplicate scan, highest score first
+// @Tags Companies
+// @Accept json
+// @Produce json
+// @Param status query string false "Candidate status (pending, merged, dismissed; default: pending)"
+// @Param limit query int false "Results limit (default: 20, max: 100)"
+// @Param offset query int false "Results offset (default: 0)"
+// @Success 200 {array} model.DuplicateCandidate
+// @Failure 500 {object} map[string]string
+// @Router /companies/duplicates [get]
+func (h *Handler) ListDuplicatesHandler(c *gin.Context) {
+	limit, offset := pagination.FromQuery(c)
+	status := model.DuplicateStatus(c.DefaultQuery("status", string(model.DuplicatePending)))
+
+	candidates, err := h.dedupeService.ListCandidates(c.Request.Context(), status, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch duplicate candidates"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"candidates": candidates,
+		"limit":      limit,
+		"offset":     offset,
+	})
+}
+
+// ScanDuplicatesHandler godoc
+// @Summary Scan for duplicate companies
+// @Description Runs the duplicate detection job now (it also runs periodically in the worker)
+// @Tags Companies
+// @Accept json
+// @Produce json
+// @Success 200 {object} service.DuplicateScanResult
+// @Failure 500 {object} map[string]string
+// @Router /companies/duplicates/scan [post]
+func (h *Handler) ScanDuplicatesHandler(c *gin.Context) {
+	result, err := h.dedupeService.ScanDuplicates(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to scan for duplicates"})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// DismissDuplicateHandler godoc
+// @Summary Dismiss a duplicate candidate
+// @Description Marks a candidate pair as not a duplicate; later scans keep the decision
+// @Tags Companies
+// @Accept json
+// @Produce json
+// @Param id path int true "Candidate ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /companies/duplicates/{id}/dismiss [post]
+func (h *Handler) DismissDuplicateHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid candidate ID"})
+		return
+	}
+
+	if err := h.dedupeService.DismissCandidate(c.Request.Context(), uint(id)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Candidate not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to dismiss candidate"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Candidate dismissed"})
+}
+
+// MergeCompaniesHandler godoc
+// @Summary Merge companies
+// @Description Merges companies into a survivor: child rows move over, missing fields are filled, merged IDs redirect to the survivor
+// @Tags Companies
+// @Accept json
+// @Produce json
+// @Param merge body service.MergeRequest true "Survivor and companies to merge into it"
+// @Success 200 {array} model.CompanyMerge
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /companies/merge [post]
+func (h *Handler) MergeCompaniesHandler(c *gin.Context) {
+	var req service.MergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	merges, err := h.dedupeService.MergeCompanies(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Company not found"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"merges": merges})
+}
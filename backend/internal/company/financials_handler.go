@@ -0,0 +1,58 @@
+package company
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/bhati00/Fynelo/backend/internal/fx"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetFinancialsHandler godoc
+// @Summary Get revenue and funding history
+// @Description Lists a company's revenues and funding rounds as reported and converted to one currency: revenues at the FX rate of the middle of their year, funding rounds at the rate of their date. Rates come from the offline FX table; amounts in currencies it does not know are not converted
+// @Tags Companies
+// @Accept json
+// @Produce json
+// @Param id path int true "Company ID"
+// @Param currency query string false "ISO-4217 currency code (default: USD)"
+// @Success 200 {object} service.FinancialsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /companies/{id}/financials [get]
+func (h *Handler) GetFinancialsHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company ID"})
+		return
+	}
+
+	financials, err := h.financialsService.Financials(c.Request.Context(), uint(id), c.Query("currency"))
+	if err != nil {
+		switch {
+		case errors.Is(err, fx.ErrUnknownCurrency):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "currencies": h.financialsService.Currencies()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Company not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch financials"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, financials)
+}
+
+// ListCurrenciesHandler godoc
+// @Summary List convertible currencies
+// @Description Lists the currencies the FX table has rates for
+// @Tags Companies
+// @Produce json
+// @Success 200 {object} map[string][]string
+// @Router /companies/currencies [get]
+func (h *Handler) ListCurrenciesHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"currencies": h.financialsService.Currencies()})
+}
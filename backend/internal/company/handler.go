@@ -1,20 +1,41 @@
 package company
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/company/querylang"
 	"github.com/bhati00/Fynelo/backend/internal/company/service"
+	"github.com/bhati00/Fynelo/backend/pkg/domain"
+	"github.com/bhati00/Fynelo/backend/pkg/pagination"
+	"gorm.io/gorm"
 )
 
 type Handler struct {
-	companyService service.CompanyService
+	companyService    service.CompanyService
+	dedupeService     service.DedupeService
+	enrichmentService service.EnrichmentService
+	historyService    service.HistoryService
+	industryService   service.IndustryService
+	financialsService service.FinancialsService
+	investorService   service.InvestorService
+	technologyService service.TechnologyService
 }
 
-func NewHandler(companyService service.CompanyService) *Handler {
+func NewHandler(companyService service.CompanyService, dedupeService service.DedupeService, enrichmentService service.EnrichmentService, historyService service.HistoryService, industryService service.IndustryService, financialsService service.FinancialsService, investorService service.InvestorService, technologyService service.TechnologyService) *Handler {
 	return &Handler{
-		companyService: companyService,
+		companyService:    companyService,
+		dedupeService:     dedupeService,
+		enrichmentService: enrichmentService,
+		historyService:    historyService,
+		industryService:   industryService,
+		financialsService: financialsService,
+		investorService:   investorService,
+		technologyService: technologyService,
 	}
 }
 
@@ -24,16 +45,32 @@ func NewHandler(companyService service.CompanyService) *Handler {
 // @Tags Companies
 // @Accept json
 // @Produce json
-// @Param q query string false "Search query (company name, website)"
+// @Param q query string false "Search query (company name, website), or an advanced query when q_mode=advanced"
+// @Param q_mode query string false "Query mode: simple (default) or advanced, e.g. industry:fintech AND (tech:kubernetes OR tech:terraform) AND founded:>=2018 AND NOT status:closed" Enums(simple, advanced)
 // @Param industry query string false "Industry filter (e.g., technology, fintech)"
 // @Param employee_size query string false "Employee size range (e.g., 1-10, 11-50)"
-// @Param location query string false "Location filter"
+// @Param employees_min query int false "At least this many employees (companies with a known employee count)"
+// @Param employees_max query int false "At most this many employees"
+// @Param location query string false "Location filter (free-text HQ location)"
+// @Param country query string false "Country name or ISO-3166 code, matched against any company location"
+// @Param state query string false "State/region name or ISO-3166-2 code"
+// @Param city query string false "City name"
+// @Param near query string false "Radius search center: place name (e.g. Berlin) or lat,lon"
+// @Param radius_km query number false "Radius for near in km (default: 50, max: 1000)"
 // @Param funding_stage query string false "Funding stage (seed, series_a, etc.)"
 // @Param founded_min query int false "Founded after year"
 // @Param founded_max query int false "Founded before year"
+// @Param revenue_min query number false "Latest reported revenue of at least this many USD (converted at the year's FX rate)"
+// @Param revenue_max query number false "Latest reported revenue of at most this many USD"
+// @Param funding_min query number false "Total disclosed funding of at least this many USD (converted at each round's FX rate)"
+// @Param investor query string false "Backed by this investor: an investor ID, or a name matching the investor's name or its first words (e.g. sequoia)"
+// @Param investor_lead query bool false "With investor, only count rounds the investor led"
+// @Param tech_category query string false "Uses a technology of this category, e.g. crm, analytics, payments, cloud (see /companies/technologies/categories)"
 // @Param status query string false "Company status (active, closed, etc.)"
+// @Param sort query string false "Order by latest revenue or total funding in USD, highest first" Enums(revenue, funding)
 // @Param limit query int false "Results limit (default: 20, max: 100)"
 // @Param offset query int false "Results offset (default: 0)"
+// @Param facets query bool false "Include facet counts (industry, size, status, funding stage, country, technologies, revenue band)"
 // @Success 200 {object} service.CompanySearchResponse
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -48,6 +85,15 @@ func (h *Handler) SearchCompaniesHandler(c *gin.Context) {
 	
 	response, err := h.companyService.SearchCompanies(c.Request.Context(), req)
 	if err != nil {
+		var syntaxErr *querylang.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": syntaxErr.Error(), "position": syntaxErr.Pos})
+			return
+		}
+		if service.IsSearchValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search companies"})
 		return
 	}
@@ -63,6 +109,7 @@ func (h *Handler) SearchCompaniesHandler(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Company ID"
 // @Success 200 {object} model.Company
+// @Success 301 "Company was merged; Location points to the surviving company"
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Router /companies/{id} [get]
@@ -76,6 +123,11 @@ func (h *Handler) GetCompanyHandler(c *gin.Context) {
 	
 	company, err := h.companyService.GetCompanyByID(c.Request.Context(), uint(id))
 	if err != nil {
+		if survivorID, merged := h.dedupeService.ResolveMergedID(c.Request.Context(), uint(id)); merged {
+			location := strings.TrimSuffix(c.Request.URL.Path, idStr) + strconv.FormatUint(uint64(survivorID), 10)
+			c.Redirect(http.StatusMovedPermanently, location)
+			return
+		}
 		c.JSON(http.StatusNotFound, gin.H{"error": "Company not found"})
 		return
 	}
@@ -83,6 +135,45 @@ func (h *Handler) GetCompanyHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, company)
 }
 
+// GetCompanyDetailsHandler godoc
+// @Summary Get company with details and metrics
+// @Description Get a company with its revenues, funding rounds (with investors), technologies and locations, and metrics computed from them: total funding in USD, latest round and date, months since the last raise, revenue growth year over year, technologies by category, and headquarters vs office count
+// @Tags Companies
+// @Accept json
+// @Produce json
+// @Param id path int true "Company ID"
+// @Success 200 {object} service.CompanyDetails
+// @Success 301 "Company was merged; Location points to the surviving company"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /companies/{id}/details [get]
+func (h *Handler) GetCompanyDetailsHandler(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company ID"})
+		return
+	}
+
+	details, err := h.companyService.GetCompanyWithDetails(c.Request.Context(), uint(id))
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch company"})
+			return
+		}
+		if survivorID, merged := h.dedupeService.ResolveMergedID(c.Request.Context(), uint(id)); merged {
+			location := strings.TrimSuffix(c.Request.URL.Path, idStr+"/details") + strconv.FormatUint(uint64(survivorID), 10) + "/details"
+			c.Redirect(http.StatusMovedPermanently, location)
+			return
+		}
+		c.JSON(http.StatusNotFound, gin.H{"error": "Company not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, details)
+}
+
 // ListCompaniesHandler godoc
 // @Summary List companies
 // @Description List companies with pagination
@@ -95,24 +186,8 @@ func (h *Handler) GetCompanyHandler(c *gin.Context) {
 // @Failure 500 {object} map[string]string
 // @Router /companies [get]
 func (h *Handler) ListCompaniesHandler(c *gin.Context) {
-	limit := 20
-	offset := 0
-	
-	if l := c.Query("limit"); l != "" {
-		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
-			limit = parsed
-			if limit > 100 {
-				limit = 100
-			}
-		}
-	}
-	
-	if o := c.Query("offset"); o != "" {
-		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
-			offset = parsed
-		}
-	}
-	
+	limit, offset := pagination.FromQuery(c)
+
 	companies, err := h.companyService.ListCompanies(c.Request.Context(), limit, offset)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch companies"})
@@ -124,4 +199,61 @@ func (h *Handler) ListCompaniesHandler(c *gin.Context) {
 		"limit":     limit,
 		"offset":    offset,
 	})
-}
\ No newline at end of file
+}
+
+// GetCompanyByDomainHandler godoc
+// @Summary Get company by domain
+// @Description Get a company by its canonical domain. Scheme, www. prefix and paths are ignored, e.g. www.Acme.com resolves acme.com
+// @Tags Companies
+// @Accept json
+// @Produce json
+// @Param domain path string true "Company domain"
+// @Success 200 {object} model.Company
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /companies/by-domain/{domain} [get]
+func (h *Handler) GetCompanyByDomainHandler(c *gin.Context) {
+	company, err := h.companyService.GetCompanyByDomain(c.Request.Context(), c.Param("domain"))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidDomain) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid domain"})
+			return
+		}
+		c.JSON(http.StatusNotFound, gin.H{"error": "Company not found"})
+		return
+	}
+	
+	c.JSON(http.StatusOK, company)
+}
+
+// ImportCompaniesRequest is the body of the company import endpoint.
+type ImportCompaniesRequest struct {
+	Companies []model.Company `json:"companies" binding:"required,min=1,max=1000"`
+}
+
+// ImportCompaniesHandler godoc
+// @Summary Import companies
+// @Description Upsert companies by domain: a company whose website resolves to an existing domain updates that company instead of creating a new one. Companies without a website are matched by exact name. Each company's source (default: manual) decides whether its values replace stored ones. employees takes a headcount as reported ("~250", "1k-5k", "500+") and sets employee_count and, unless given, employee_size_id
+// @Tags Companies
+// @Accept json
+// @Produce json
+// @Param request body ImportCompaniesRequest true "Companies to import (max 1000)"
+// @Success 200 {object} service.ImportResult
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /companies/import [post]
+func (h *Handler) ImportCompaniesHandler(c *gin.Context) {
+	var req ImportCompaniesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	
+	result, err := h.companyService.ImportCompanies(c.Request.Context(), req.Companies)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import companies"})
+		return
+	}
+	
+	c.JSON(http.StatusOK, result)
+}
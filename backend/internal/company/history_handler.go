@@ -0,0 +1,128 @@
+package company
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/company/repositories"
+	"github.com/bhati00/Fynelo/backend/internal/company/service"
+	"github.com/bhati00/Fynelo/backend/pkg/pagination"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetHistoryHandler godoc
+// @Summary Company change history
+// @Description Lists recorded changes to a company and its revenues, funding rounds, technologies and locations, newest first. Values are JSON-encoded
+// @Tags Companies
+// @Accept json
+// @Produce json
+// @Param id path int true "Company ID"
+// @Param entity_type query string false "Limit to company, revenue, funding_round, technology or location"
+// @Param field query string false "Limit to one field, e.g. employee_size_id or status"
+// @Param since query string false "Changes at or after this time (RFC3339 or YYYY-MM-DD)"
+// @Param until query string false "Changes at or before this time (RFC3339 or YYYY-MM-DD)"
+// @Param limit query int false "Results limit (default: 20, max: 100)"
+// @Param offset query int false "Results offset (default: 0)"
+// @Success 200 {array} model.CompanyChange
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /companies/{id}/history [get]
+func (h *Handler) GetHistoryHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company ID"})
+		return
+	}
+
+	filter := repositories.HistoryFilter{
+		EntityType: model.EntityType(c.Query("entity_type")),
+		Field:      c.Query("field"),
+	}
+	if v := c.Query("since"); v != "" {
+		since, err := parseTimeParam(v, false)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid since"})
+			return
+		}
+		filter.Since = &since
+	}
+	if v := c.Query("until"); v != "" {
+		until, err := parseTimeParam(v, true)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid until"})
+			return
+		}
+		filter.Until = &until
+	}
+	limit, offset := pagination.FromQuery(c)
+
+	changes, err := h.historyService.ListHistory(c.Request.Context(), uint(id), filter, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"changes": changes,
+		"limit":   limit,
+		"offset":  offset,
+	})
+}
+
+// GetSnapshotHandler godoc
+// @Summary Company as of a date
+// @Description Rebuilds a company and its child rows as they were at a point in time. A bare date means the end of that day (UTC)
+// @Tags Companies
+// @Accept json
+// @Produce json
+// @Param id path int true "Company ID"
+// @Param as_of query string true "Point in time (RFC3339 or YYYY-MM-DD)"
+// @Success 200 {object} service.CompanySnapshot
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /companies/{id}/snapshot [get]
+func (h *Handler) GetSnapshotHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company ID"})
+		return
+	}
+	asOf, err := parseTimeParam(c.Query("as_of"), true)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "as_of must be RFC3339 or YYYY-MM-DD"})
+		return
+	}
+
+	snapshot, err := h.historyService.SnapshotAt(c.Request.Context(), uint(id), asOf)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, service.ErrNotExisted) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build snapshot"})
+		return
+	}
+
+	c.JSON(http.StatusOK, snapshot)
+}
+
+// parseTimeParam accepts RFC3339 or a bare date; endOfDay moves a bare date
+// to the last instant of that day.
+func parseTimeParam(v string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t, nil
+}
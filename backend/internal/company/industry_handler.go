@@ -0,0 +1,120 @@
+package company
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/company/service"
+	"github.com/bhati00/Fynelo/backend/internal/industry"
+	"github.com/bhati00/Fynelo/backend/pkg/pagination"
+	"github.com/gin-gonic/gin"
+)
+
+// UnmappedIndustriesResponse is a page of the industry values awaiting curation.
+type UnmappedIndustriesResponse struct {
+	Unmapped []model.UnmappedIndustry `json:"unmapped"`
+	Total    int64                    `json:"total"`
+	Limit    int                      `json:"limit"`
+	Offset   int                      `json:"offset"`
+}
+
+// ClassifyIndustryHandler godoc
+// @Summary Classify an industry
+// @Description Maps NAICS or SIC codes and a free-text description to an industry with a confidence, the way imports and enrichment do, without storing anything
+// @Tags Companies
+// @Accept json
+// @Produce json
+// @Param input body industry.Input true "Codes and description"
+// @Success 200 {object} industry.Result
+// @Failure 400 {object} map[string]string
+// @Router /companies/industries/classify [post]
+func (h *Handler) ClassifyIndustryHandler(c *gin.Context) {
+	var req industry.Input
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.industryService.Classify(req))
+}
+
+// ListUnmappedIndustriesHandler godoc
+// @Summary List unmapped industry values
+// @Description Lists the industry codes and descriptions imports and enrichment reported that could not be classified, most reported first. Map one by adding a taxonomy alias to an industry (e.g. "naics:2361" or the description), then reclassify.
+// @Tags Companies
+// @Accept json
+// @Produce json
+// @Param source query string false "naics, sic or text"
+// @Param limit query int false "Results limit (default: 20, max: 100)"
+// @Param offset query int false "Results offset (default: 0)"
+// @Success 200 {object} UnmappedIndustriesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /companies/industries/unmapped [get]
+func (h *Handler) ListUnmappedIndustriesHandler(c *gin.Context) {
+	limit, offset := pagination.FromQuery(c)
+
+	list, total, err := h.industryService.ListUnmapped(c.Request.Context(), c.Query("source"), limit, offset)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidIndustrySource) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch unmapped industries"})
+		return
+	}
+
+	c.JSON(http.StatusOK, UnmappedIndustriesResponse{Unmapped: list, Total: total, Limit: limit, Offset: offset})
+}
+
+// DismissUnmappedIndustryHandler godoc
+// @Summary Dismiss an unmapped industry value
+// @Description Removes a value from the curation list; it comes back if it is reported again
+// @Tags Companies
+// @Accept json
+// @Produce json
+// @Param id path int true "Unmapped value ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /companies/industries/unmapped/{id} [delete]
+func (h *Handler) DismissUnmappedIndustryHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid unmapped value ID"})
+		return
+	}
+
+	if err := h.industryService.DismissUnmapped(c.Request.Context(), uint(id)); err != nil {
+		if errors.Is(err, service.ErrUnmappedNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to dismiss unmapped value"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ReclassifyIndustriesHandler godoc
+// @Summary Reclassify industries
+// @Description Retries the unmapped values against the current taxonomy, dropping those that now map, and gives an industry to companies that have none, or are filed under Other, but have reported codes or text that now map
+// @Tags Companies
+// @Accept json
+// @Produce json
+// @Success 200 {object} service.ReclassifyResult
+// @Failure 500 {object} map[string]string
+// @Router /companies/industries/reclassify [post]
+func (h *Handler) ReclassifyIndustriesHandler(c *gin.Context) {
+	result, err := h.industryService.Reclassify(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reclassify industries"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,109 @@
+package company
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/company/repositories"
+	"github.com/bhati00/Fynelo/backend/pkg/pagination"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// InvestorsResponse is a page of investors matching a search.
+type InvestorsResponse struct {
+	Investors []repositories.InvestorSummary `json:"investors"`
+	Total     int64                          `json:"total"`
+	Limit     int                            `json:"limit"`
+	Offset    int                            `json:"offset"`
+}
+
+// SearchInvestorsHandler godoc
+// @Summary Search investors
+// @Description Lists investors whose name contains q, those backing the most companies first, with the size of their portfolio. Investors are taken from the investors named on funding rounds
+// @Tags Investors
+// @Accept json
+// @Produce json
+// @Param q query string false "Part of the investor name"
+// @Param limit query int false "Results limit (default: 20, max: 100)"
+// @Param offset query int false "Results offset (default: 0)"
+// @Success 200 {object} InvestorsResponse
+// @Failure 500 {object} map[string]string
+// @Router /investors [get]
+func (h *Handler) SearchInvestorsHandler(c *gin.Context) {
+	limit, offset := pagination.FromQuery(c)
+
+	list, total, err := h.investorService.Search(c.Request.Context(), c.Query("q"), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search investors"})
+		return
+	}
+	if list == nil {
+		list = []repositories.InvestorSummary{}
+	}
+
+	c.JSON(http.StatusOK, InvestorsResponse{Investors: list, Total: total, Limit: limit, Offset: offset})
+}
+
+// AutocompleteInvestorsHandler godoc
+// @Summary Autocomplete investor names
+// @Description Suggests investors with a word of their name starting with q, those whose name starts with it first
+// @Tags Investors
+// @Accept json
+// @Produce json
+// @Param q query string true "Start of the investor name"
+// @Param limit query int false "Suggestions limit (default: 10, max: 20)"
+// @Success 200 {object} map[string][]model.Investor
+// @Failure 500 {object} map[string]string
+// @Router /investors/autocomplete [get]
+func (h *Handler) AutocompleteInvestorsHandler(c *gin.Context) {
+	limit := 10
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 {
+		limit = l
+	}
+
+	list, err := h.investorService.Autocomplete(c.Request.Context(), c.Query("q"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to autocomplete investors"})
+		return
+	}
+	if list == nil {
+		list = []model.Investor{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"investors": list})
+}
+
+// GetInvestorHandler godoc
+// @Summary Get an investor profile
+// @Description Returns an investor and its portfolio: the companies it backed, each with the rounds it took part in and whether it led them, most recently backed first
+// @Tags Investors
+// @Accept json
+// @Produce json
+// @Param id path int true "Investor ID"
+// @Success 200 {object} service.InvestorProfile
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /investors/{id} [get]
+func (h *Handler) GetInvestorHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid investor ID"})
+		return
+	}
+
+	profile, err := h.investorService.Profile(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Investor not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch investor"})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
@@ -1,10 +1,182 @@
 package company
 
 import (
+	"log"
+
 	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/company/repositories"
+	"github.com/bhati00/Fynelo/backend/internal/company/service"
+	"github.com/bhati00/Fynelo/backend/internal/fx"
 	"github.com/bhati00/Fynelo/backend/pkg/database"
+	"github.com/bhati00/Fynelo/backend/pkg/domain"
+
+	"gorm.io/gorm"
 )
 
 func Migrate() {
-	database.DB.AutoMigrate(&model.Company{}, &model.Location{}, &model.Revenue{}, &model.FundingRound{}, &model.Technology{})
+	database.DB.AutoMigrate(&model.Company{}, &model.Location{}, &model.Revenue{}, &model.FundingRound{}, &model.Technology{},
+		&model.DuplicateCandidate{}, &model.CompanyMerge{}, &model.FieldProvenance{},
+		&model.CompanyChange{}, &model.UnmappedIndustry{}, &model.Investor{}, &model.FundingRoundInvestor{})
+	backfillLocationCodes()
+	backfillDomains()
+	convertAmountsToUSD()
+	linkInvestors()
+	normalizeTechnologies()
+	flagHeadquarters()
+}
+
+// flagHeadquarters flags the location named by the hq_location of companies
+// stored before locations carried the flag.
+func flagHeadquarters() {
+	var companies []model.Company
+	err := database.DB.Preload("Locations").
+		Where("hq_location IS NOT NULL AND hq_location <> ''").
+		Where("id IN (SELECT company_id FROM locations WHERE deleted_at IS NULL)").
+		Where("id NOT IN (SELECT company_id FROM locations WHERE is_headquarters AND deleted_at IS NULL)").
+		FindInBatches(&companies, backfillBatchSize, func(tx *gorm.DB, batch int) error {
+			for _, c := range companies {
+				i := service.HeadquartersIndex(c.Locations, *c.HQLocation)
+				if i < 0 {
+					continue
+				}
+				if err := database.DB.Model(&c.Locations[i]).UpdateColumn("is_headquarters", true).Error; err != nil {
+					log.Printf("Headquarters backfill: location %d: %v", c.Locations[i].ID, err)
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		log.Printf("Headquarters backfill stopped: %v", err)
+	}
+}
+
+// normalizeTechnologies puts technologies stored before the catalog in its
+// form and deletes the duplicates that leaves.
+func normalizeTechnologies() {
+	if err := repositories.NormalizeTechnologies(database.DB, backfillBatchSize); err != nil {
+		log.Printf("Technology normalization stopped: %v", err)
+	}
+}
+
+// linkInvestors creates investors and their links for funding rounds whose
+// investors text was stored before investors were tracked.
+func linkInvestors() {
+	var rounds []model.FundingRound
+	if err := database.DB.
+		Where("investors IS NOT NULL AND investors <> ''").
+		Where("id NOT IN (SELECT funding_round_id FROM funding_round_investors)").
+		Find(&rounds).Error; err != nil {
+		log.Printf("Investor backfill skipped: %v", err)
+		return
+	}
+	for i := range rounds {
+		if err := repositories.LinkRoundInvestors(database.DB, &rounds[i]); err != nil {
+			log.Printf("Investor backfill: funding round %d: %v", rounds[i].ID, err)
+		}
+	}
+}
+
+// convertAmountsToUSD brings the USD amounts of revenues and funding rounds
+// in line with the FX rates loaded at startup, which may have changed since
+// the amounts were stored. Rows are read in batches.
+func convertAmountsToUSD() {
+	rates := fx.Default()
+	var revenues []model.Revenue
+	err := database.DB.FindInBatches(&revenues, backfillBatchSize, func(tx *gorm.DB, batch int) error {
+		for _, r := range revenues {
+			usd := rates.ToUSD(r.Amount, r.Currency, r.RateDate())
+			if sameAmount(usd, r.AmountUSD) {
+				continue
+			}
+			if err := database.DB.Model(&r).UpdateColumn("amount_usd", usd).Error; err != nil {
+				log.Printf("USD conversion: revenue %d: %v", r.ID, err)
+			}
+		}
+		return nil
+	}).Error
+	if err != nil {
+		log.Printf("USD conversion of revenues stopped: %v", err)
+	}
+
+	var rounds []model.FundingRound
+	err = database.DB.Where("amount IS NOT NULL").FindInBatches(&rounds, backfillBatchSize, func(tx *gorm.DB, batch int) error {
+		for _, f := range rounds {
+			currency := f.Currency
+			if currency == "" {
+				currency = fx.USD
+			}
+			usd := rates.ToUSD(*f.Amount, currency, f.RateDate())
+			if sameAmount(usd, f.AmountUSD) {
+				continue
+			}
+			if err := database.DB.Model(&f).UpdateColumn("amount_usd", usd).Error; err != nil {
+				log.Printf("USD conversion: funding round %d: %v", f.ID, err)
+			}
+		}
+		return nil
+	}).Error
+	if err != nil {
+		log.Printf("USD conversion of funding rounds stopped: %v", err)
+	}
+}
+
+// Rows read at a time by the backfills
+const backfillBatchSize = 500
+
+func sameAmount(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// backfillDomains derives domains for companies stored before the domain
+// column existed. Companies whose domain is already taken are left without
+// one and show up in the duplicate scan instead.
+func backfillDomains() {
+	var companies []model.Company
+	if err := database.DB.Where("domain IS NULL AND website IS NOT NULL").Find(&companies).Error; err != nil {
+		log.Printf("Domain backfill skipped: %v", err)
+		return
+	}
+	for _, c := range companies {
+		host, err := domain.Canonicalize(*c.Website)
+		if err != nil {
+			continue
+		}
+		var taken int64
+		if err := database.DB.Model(&model.Company{}).Where("domain = ?", host).Count(&taken).Error; err != nil {
+			log.Printf("Domain backfill: company %d: %v", c.ID, err)
+			continue
+		}
+		if taken > 0 {
+			log.Printf("Domain backfill: %s already used, leaving company %d without a domain", host, c.ID)
+			continue
+		}
+		if err := database.DB.Model(&c).UpdateColumn("domain", host).Error; err != nil {
+			log.Printf("Domain backfill: company %d: %v", c.ID, err)
+		}
+	}
+}
+
+// backfillLocationCodes normalizes locations stored before country and
+// region codes existed.
+func backfillLocationCodes() {
+	var locations []model.Location
+	if err := database.DB.Where("country_code IS NULL").Find(&locations).Error; err != nil {
+		log.Printf("Location backfill skipped: %v", err)
+		return
+	}
+	for i := range locations {
+		locations[i].Normalize()
+		if locations[i].CountryCode == nil {
+			continue
+		}
+		database.DB.Model(&locations[i]).UpdateColumns(map[string]interface{}{
+			"country_code": locations[i].CountryCode,
+			"region_code":  locations[i].RegionCode,
+			"latitude":     locations[i].Latitude,
+			"longitude":    locations[i].Longitude,
+		})
+	}
 }
@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+type DuplicateStatus string
+
+const (
+	DuplicatePending   DuplicateStatus = "pending"
+	DuplicateMerged    DuplicateStatus = "merged"
+	DuplicateDismissed DuplicateStatus = "dismissed"
+)
+
+// DuplicateCandidate is a pair of companies that the duplicate scan believes
+// describe the same real-world entity. CompanyID is always the lower ID.
+type DuplicateCandidate struct {
+	ID          uint            `gorm:"primaryKey" json:"id"`
+	CompanyID   uint            `gorm:"not null;uniqueIndex:idx_duplicate_pair" json:"company_id"`
+	DuplicateID uint            `gorm:"not null;uniqueIndex:idx_duplicate_pair" json:"duplicate_id"`
+	ClusterID   uint            `gorm:"index" json:"cluster_id"`          // lowest company ID in the connected cluster
+	Score       float64         `json:"score"`                            // 0..1
+	Reasons     string          `gorm:"type:varchar(100)" json:"reasons"` // comma-separated: domain, name, location
+	Status      DuplicateStatus `gorm:"type:varchar(20);default:'pending';index" json:"status"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+
+	Company   Company `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+	Duplicate Company `gorm:"foreignKey:DuplicateID" json:"duplicate,omitempty"`
+}
+
+// CompanyMerge records a company that was folded into a surviving company.
+// Lookups of MergedID are redirected to SurvivorID.
+type CompanyMerge struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	MergedID   uint      `gorm:"not null;uniqueIndex" json:"merged_id"`
+	SurvivorID uint      `gorm:"not null;index" json:"survivor_id"`
+	MergedName string    `json:"merged_name"`
+	Snapshot   string    `gorm:"type:text" json:"snapshot"` // JSON of the merged company as it was before the merge
+	MergedBy   string    `gorm:"type:varchar(100)" json:"merged_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (DuplicateCandidate) TableName() string {
+	return "duplicate_candidates"
+}
+
+func (CompanyMerge) TableName() string {
+	return "company_merges"
+}
@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+type ChangeOperation string
+
+const (
+	ChangeCreate ChangeOperation = "create"
+	ChangeUpdate ChangeOperation = "update"
+	ChangeDelete ChangeOperation = "delete"
+)
+
+// WholeRow is the Field of create and delete changes, whose values hold the
+// entire row rather than a single field.
+const WholeRow = "*"
+
+// CompanyChange is one recorded change to a company or one of its child rows.
+// Values are JSON-encoded as they appear in the API, so a company can be
+// rebuilt as of any point in time by undoing later changes.
+type CompanyChange struct {
+	ID         uint            `gorm:"primaryKey" json:"id"`
+	CompanyID  uint            `gorm:"not null;index:idx_company_changes" json:"company_id"`
+	EntityType EntityType      `gorm:"type:varchar(20);not null" json:"entity_type"`
+	EntityID   uint            `gorm:"not null" json:"entity_id"`
+	Operation  ChangeOperation `gorm:"type:varchar(10);not null" json:"operation"`
+	Field      string          `gorm:"type:varchar(50);not null" json:"field"`
+	OldValue   *string         `gorm:"type:text" json:"old_value"`
+	NewValue   *string         `gorm:"type:text" json:"new_value"`
+	Actor      string          `gorm:"type:varchar(100)" json:"actor"` // user or job that made the change
+	ChangedAt  time.Time       `gorm:"not null;index:idx_company_changes" json:"changed_at"`
+}
+
+func (CompanyChange) TableName() string {
+	return "company_changes"
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// UnmappedIndustry is an industry code or description the classifier could
+// not map, with how often it was reported, so it can be curated by adding a
+// taxonomy alias. Value is lowercased.
+type UnmappedIndustry struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Source      string    `gorm:"type:varchar(10);not null;uniqueIndex:idx_unmapped_industries_value" json:"source"` // naics, sic or text
+	Value       string    `gorm:"type:varchar(200);not null;uniqueIndex:idx_unmapped_industries_value" json:"value"`
+	Occurrences int64     `gorm:"not null;default:1" json:"occurrences"`
+	CompanyID   uint      `json:"company_id"` // company it was last reported for
+	FirstSeenAt time.Time `gorm:"not null" json:"first_seen_at"`
+	LastSeenAt  time.Time `gorm:"not null;index" json:"last_seen_at"`
+}
+
+func (UnmappedIndustry) TableName() string {
+	return "unmapped_industries"
+}
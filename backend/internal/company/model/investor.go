@@ -0,0 +1,140 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// Investor is a firm or person that backs companies through funding rounds.
+// NormalizedName identifies the investor across the spellings sources use.
+type Investor struct {
+	ID             uint           `gorm:"primaryKey" json:"id"`
+	Name           string         `gorm:"type:varchar(200);not null" json:"name"`
+	NormalizedName string         `gorm:"type:varchar(200);not null;uniqueIndex:idx_investors_normalized_name,where:deleted_at IS NULL" json:"-"`
+	Website        *string        `json:"website"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// FundingRoundInvestor links an investor to a round it took part in.
+type FundingRoundInvestor struct {
+	FundingRoundID uint      `gorm:"primaryKey;autoIncrement:false" json:"funding_round_id"`
+	InvestorID     uint      `gorm:"primaryKey;autoIncrement:false;index" json:"investor_id"`
+	Lead           bool      `gorm:"not null;default:false" json:"lead"` // led the round rather than participated
+	CreatedAt      time.Time `json:"created_at"`
+
+	Investor Investor `gorm:"foreignKey:InvestorID" json:"investor,omitempty"`
+}
+
+func (Investor) TableName() string {
+	return "investors"
+}
+
+func (FundingRoundInvestor) TableName() string {
+	return "funding_round_investors"
+}
+
+// InvestorMention is an investor named in a round's investors text.
+type InvestorMention struct {
+	Name string `json:"name"`
+	Lead bool   `json:"lead"`
+}
+
+// Legal-form words dropped from the end of investor names when matching
+var investorSuffixes = map[string]bool{"llc": true, "lp": true, "llp": true, "inc": true, "ltd": true, "plc": true, "gmbh": true}
+
+// NormalizeInvestorName lowercases a name, drops punctuation and a trailing
+// legal form, so "Sequoia Capital, L.P." and "sequoia capital" match.
+func NormalizeInvestorName(name string) string {
+	name = strings.ToLower(name)
+	name = strings.NewReplacer("l.l.c.", "llc", "l.l.p.", "llp", "l.p.", "lp").Replace(name)
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '&'
+	})
+	for len(words) > 1 && investorSuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+	return strings.Join(words, " ")
+}
+
+// ParseInvestors reads a round's investors text: a JSON array of names or
+// of {"name", "lead"} objects, or names separated by commas, semicolons,
+// pipes or new lines. A name marked "(lead)" or prefixed "led by" led the
+// round. Mentions are returned once each, in order.
+func ParseInvestors(text string) []InvestorMention {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+
+	var mentions []InvestorMention
+	if strings.HasPrefix(text, "[") {
+		var names []string
+		var objects []struct {
+			Name string `json:"name"`
+			Lead bool   `json:"lead"`
+			Role string `json:"role"`
+		}
+		if json.Unmarshal([]byte(text), &names) == nil {
+			for _, n := range names {
+				mentions = append(mentions, investorMention(n))
+			}
+		} else if json.Unmarshal([]byte(text), &objects) == nil {
+			for _, o := range objects {
+				m := investorMention(o.Name)
+				m.Lead = m.Lead || o.Lead || strings.EqualFold(o.Role, "lead")
+				mentions = append(mentions, m)
+			}
+		}
+	}
+	if mentions == nil {
+		parts := strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ';' || r == '|' || r == '\n' })
+		for _, p := range parts {
+			m := investorMention(p)
+			// "Sequoia Capital, LLC": a legal form split off by the comma
+			if investorSuffixes[NormalizeInvestorName(m.Name)] && len(mentions) > 0 {
+				continue
+			}
+			mentions = append(mentions, m)
+		}
+	}
+
+	seen := map[string]int{}
+	out := []InvestorMention{}
+	for _, m := range mentions {
+		key := NormalizeInvestorName(m.Name)
+		if key == "" {
+			continue
+		}
+		if i, ok := seen[key]; ok {
+			out[i].Lead = out[i].Lead || m.Lead
+			continue
+		}
+		seen[key] = len(out)
+		out = append(out, m)
+	}
+	return out
+}
+
+// investorMention strips lead markers from one name. Markers are matched on
+// the name itself, as lowercasing can change its length.
+func investorMention(name string) InvestorMention {
+	const ledBy = "led by "
+	name = strings.TrimSpace(name)
+	m := InvestorMention{}
+	if len(name) >= len(ledBy) && strings.EqualFold(name[:len(ledBy)], ledBy) {
+		name, m.Lead = strings.TrimSpace(name[len(ledBy):]), true
+	}
+	if i := strings.LastIndex(name, "("); i > 0 && strings.HasSuffix(name, ")") {
+		if note := strings.ToLower(name[i+1 : len(name)-1]); strings.Contains(note, "lead") || strings.TrimSpace(note) == "led" {
+			name, m.Lead = strings.TrimSpace(name[:i]), true
+		}
+	}
+	m.Name = strings.Trim(name, " \t\"'")
+	return m
+}
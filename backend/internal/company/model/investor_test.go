@@ -0,0 +1,63 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestNormalizeInvestorName(t *testing.T) {
+	for _, tc := range []struct {
+		name, want string
+	}{
+		{"Sequoia Capital", "sequoia capital"},
+		{"Sequoia Capital, L.P.", "sequoia capital"},
+		{"  SEQUOIA   capital llc ", "sequoia capital"},
+		{"Andreessen Horowitz (a16z)", "andreessen horowitz a16z"},
+		{"Bain & Co. Ltd", "bain & co"},
+		{"Y Combinator Inc. LLC", "y combinator"},
+		{"LLC", "llc"}, // a lone legal form is kept
+		{"Índice Ventures", "índice ventures"},
+		{"", ""},
+	} {
+		if got := NormalizeInvestorName(tc.name); got != tc.want {
+			t.Errorf("NormalizeInvestorName(%q) = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestParseInvestors(t *testing.T) {
+	for _, tc := range []struct {
+		text string
+		want []InvestorMention
+	}{
+		{"", nil},
+		{"Sequoia Capital, Accel", []InvestorMention{{Name: "Sequoia Capital"}, {Name: "Accel"}}},
+		{"Sequoia Capital; Accel | Index\nBenchmark", []InvestorMention{{Name: "Sequoia Capital"}, {Name: "Accel"}, {Name: "Index"}, {Name: "Benchmark"}}},
+		{"Sequoia Capital, LLC, Accel", []InvestorMention{{Name: "Sequoia Capital"}, {Name: "Accel"}}},
+		{"Sequoia (lead), Accel", []InvestorMention{{Name: "Sequoia", Lead: true}, {Name: "Accel"}}},
+		{"Accel (Co-Lead), Index (led)", []InvestorMention{{Name: "Accel", Lead: true}, {Name: "Index", Lead: true}}},
+		{"Led by Sequoia, Accel", []InvestorMention{{Name: "Sequoia", Lead: true}, {Name: "Accel"}}},
+		{"led by  Sequoia (lead)", []InvestorMention{{Name: "Sequoia", Lead: true}}},
+		{"Andreessen Horowitz (a16z)", []InvestorMention{{Name: "Andreessen Horowitz (a16z)"}}},
+		{`"Accel", 'Index'`, []InvestorMention{{Name: "Accel"}, {Name: "Index"}}},
+		{"Accel, accel inc, Accel (lead)", []InvestorMention{{Name: "Accel", Lead: true}}},
+		{`["Sequoia", "Accel (lead)"]`, []InvestorMention{{Name: "Sequoia"}, {Name: "Accel", Lead: true}}},
+		{`[{"name": "Sequoia", "lead": true}, {"name": "Accel", "role": "Lead"}, {"name": "Index"}]`,
+			[]InvestorMention{{Name: "Sequoia", Lead: true}, {Name: "Accel", Lead: true}, {Name: "Index"}}},
+		{"ȺȺȺȺȺȺȺȺȺȺȺȺ (lead)", []InvestorMention{{Name: "ȺȺȺȺȺȺȺȺȺȺȺȺ", Lead: true}}},
+		{"İİİİİİİİİİ Capital (lead)", []InvestorMention{{Name: "İİİİİİİİİİ Capital", Lead: true}}},
+		{"LED BY İnvest", []InvestorMention{{Name: "İnvest", Lead: true}}},
+		{"(lead)", []InvestorMention{{Name: "(lead)"}}},
+	} {
+		got := ParseInvestors(tc.text)
+		if !reflect.DeepEqual(got, tc.want) && !(len(got) == 0 && len(tc.want) == 0) {
+			t.Errorf("ParseInvestors(%q) = %+v, want %+v", tc.text, got, tc.want)
+		}
+		for _, m := range got {
+			if !utf8.ValidString(m.Name) {
+				t.Errorf("ParseInvestors(%q) returned invalid UTF-8 name %q", tc.text, m.Name)
+			}
+		}
+	}
+}
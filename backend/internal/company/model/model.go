@@ -2,9 +2,18 @@ package model
 
 // backend/internal/company/model.go
 import (
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/bhati00/Fynelo/backend/internal/constants"
+	"github.com/bhati00/Fynelo/backend/internal/fx"
+	"github.com/bhati00/Fynelo/backend/internal/geo"
+	"github.com/bhati00/Fynelo/backend/internal/headcount"
+	"github.com/bhati00/Fynelo/backend/internal/industry"
+	"github.com/bhati00/Fynelo/backend/internal/taxonomy"
+	"github.com/bhati00/Fynelo/backend/internal/techcatalog"
+	"github.com/bhati00/Fynelo/backend/pkg/domain"
 	"gorm.io/gorm"
 )
 
@@ -35,9 +44,17 @@ type Company struct {
 	ID               uint           `gorm:"primaryKey" json:"id"`
 	Name             string         `gorm:"not null;index" json:"name"`
 	Website          *string        `json:"website"`
+	Domain           *string        `gorm:"type:varchar(255);uniqueIndex:idx_companies_domain,where:deleted_at IS NULL" json:"domain"` // canonical host derived from Website
 	HQLocation       *string        `json:"hq_location"`
 	IndustryID       *int           `json:"industry_id"`        // Changed to int to use constants
+	NAICSCode        *string        `gorm:"type:varchar(10);index" json:"naics_code"` // as reported; mapped to IndustryID by the industry package
+	SICCode          *string        `gorm:"type:varchar(10);index" json:"sic_code"` // as reported
+	IndustryText     *string        `gorm:"type:varchar(200)" json:"industry_text"` // free-text industry as reported
 	EmployeeSizeID   *int           `json:"employee_size_id"`   // Changed from EmployeeRange to use constants
+	EmployeeCount    *int           `gorm:"index" json:"employee_count"`              // exact, or an estimate when EmployeeCountEstimated
+	EmployeeCountEstimated bool     `gorm:"not null;default:false" json:"employee_count_estimated"`
+	EmployeeCountAt  *time.Time     `json:"employee_count_at"`                            // when the count was observed
+	Employees        string         `gorm:"-" json:"employees,omitempty"`                   // import input only: "~250", "1k-5k", "500+"
 	FoundedYear      *int           `json:"founded_year"`
 	Status           CompanyStatus  `gorm:"type:varchar(20);default:'active'" json:"status"`
 	Source           string         `gorm:"type:varchar(50)" json:"source"` // "manual", "scraped", "api"
@@ -46,6 +63,9 @@ type Company struct {
 	UpdatedAt        time.Time      `json:"updated_at"`
 	DeletedAt        gorm.DeletedAt `gorm:"index" json:"-"`
 
+	storedWebsite *string // website as loaded or last saved; see WebsiteChanged
+	stored        bool
+
 	// Relationships
 	Revenues         []Revenue      `gorm:"foreignKey:CompanyID" json:"revenues,omitempty"`
 	FundingRounds    []FundingRound `gorm:"foreignKey:CompanyID" json:"funding_rounds,omitempty"`
@@ -59,9 +79,10 @@ type Company struct {
 type Revenue struct {
 	ID        uint           `gorm:"primaryKey" json:"id"`
 	CompanyID uint           `gorm:"not null;index" json:"company_id"`
-	Company   Company        `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+	Company   Company        `gorm:"foreignKey:CompanyID" json:"-"`
 	Currency  string         `gorm:"type:varchar(3);not null" json:"currency"` // USD, EUR, etc.
 	Amount    float64        `gorm:"not null" json:"amount"`
+	AmountUSD *float64       `gorm:"index" json:"amount_usd"` // Amount at the year's FX rate; nil for unknown currencies
 	Year      int            `gorm:"not null" json:"year"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
@@ -72,23 +93,28 @@ type Revenue struct {
 type FundingRound struct {
 	ID        uint             `gorm:"primaryKey" json:"id"`
 	CompanyID uint             `gorm:"not null;index" json:"company_id"`
-	Company   Company          `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+	Company   Company          `gorm:"foreignKey:CompanyID" json:"-"`
 	RoundType FundingRoundType `gorm:"type:varchar(20);not null" json:"round_type"`
 	Amount    *float64         `json:"amount"` // nullable for undisclosed amounts
+	AmountUSD *float64         `gorm:"index" json:"amount_usd"` // Amount at the FX rate of Date
 	Currency  string           `gorm:"type:varchar(3);default:'USD'" json:"currency"`
 	Date      *time.Time       `json:"date"`
-	Investors string           `gorm:"type:text" json:"investors"` // JSON string or comma-separated
+	Investors string           `gorm:"type:text" json:"investors"` // as reported: JSON or comma-separated; parsed into RoundInvestors
 	CreatedAt time.Time        `json:"created_at"`
 	UpdatedAt time.Time        `json:"updated_at"`
 	DeletedAt gorm.DeletedAt   `gorm:"index" json:"-"`
+
+	RoundInvestors []FundingRoundInvestor `gorm:"foreignKey:FundingRoundID" json:"round_investors,omitempty"`
 }
 
 // Technology represents technologies used by companies
 type Technology struct {
 	ID             uint           `gorm:"primaryKey" json:"id"`
 	CompanyID      uint           `gorm:"not null;index" json:"company_id"`
-	Company        Company        `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
-	TechnologyName string         `gorm:"not null" json:"technology_name"`
+	Company        Company        `gorm:"foreignKey:CompanyID" json:"-"`
+	TechnologyName string         `gorm:"not null" json:"technology_name"`        // canonical catalog name, or as reported when not in the catalog
+	Category       *string        `gorm:"type:varchar(50);index" json:"category"` // catalog category code, e.g. crm, cloud; nil when not known
+	Vendor         *string        `gorm:"type:varchar(100)" json:"vendor"`
 	CreatedAt      time.Time      `json:"created_at"`
 	UpdatedAt      time.Time      `json:"updated_at"`
 	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
@@ -98,12 +124,22 @@ type Technology struct {
 type Location struct {
 	ID         uint           `gorm:"primaryKey" json:"id"`
 	CompanyID  uint           `gorm:"not null;index" json:"company_id"`
-	Company    Company        `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+	Company    Company        `gorm:"foreignKey:CompanyID" json:"-"`
 	Address    *string        `json:"address"`
 	City       *string        `json:"city"`
 	State      *string        `json:"state"`
 	Country    *string        `json:"country"`
 	PostalCode *string        `json:"postal_code"`
+
+	// Set on the location the company's hq_location names
+	IsHeadquarters bool `gorm:"not null;default:false" json:"is_headquarters"`
+
+	// Normalized from the free-text fields above via the bundled gazetteer
+	CountryCode *string  `gorm:"type:varchar(2);index" json:"country_code"` // ISO-3166-1 alpha-2
+	RegionCode  *string  `gorm:"type:varchar(6);index" json:"region_code"`  // ISO-3166-2, e.g. "US-CA"
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+
 	CreatedAt  time.Time      `json:"created_at"`
 	UpdatedAt  time.Time      `json:"updated_at"`
 	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
@@ -135,22 +171,235 @@ func (c *Company) GetIndustryName() string {
 	if c.IndustryID == nil {
 		return "Unknown"
 	}
-	return constants.GetIndustryName(*c.IndustryID)
+	return taxonomy.Default().Name(taxonomy.Industries, *c.IndustryID)
 }
 
 func (c *Company) GetEmployeeSizeRange() string {
 	if c.EmployeeSizeID == nil {
 		return "Unknown"
 	}
-	return constants.GetCompanySizeRange(*c.EmployeeSizeID)
+	return taxonomy.Default().Name(taxonomy.CompanySizes, *c.EmployeeSizeID)
 }
 
+// SetIndustryByName classifies a free-text industry, falling back to
+// IndustryOther, and keeps the text as reported.
 func (c *Company) SetIndustryByName(industryName string) {
-	industryID := constants.GetIndustryID(industryName)
+	industryID := constants.IndustryOther
+	if result := industry.Classify(industry.Input{Text: industryName}); result.Classification != nil {
+		industryID = result.Classification.IndustryID
+	}
 	c.IndustryID = &industryID
+	c.IndustryText = &industryName
+}
+
+// SetEmployeeSizeByRange sets the size from a size name or a headcount such
+// as "~250" or "1k-5k"; a headcount also sets EmployeeCount.
+func (c *Company) SetEmployeeSizeByRange(sizeRange string) error {
+	if sizeID, ok := taxonomy.Default().Lookup(taxonomy.CompanySizes, sizeRange); ok {
+		c.EmployeeSizeID = &sizeID
+		return nil
+	}
+	r, err := headcount.Parse(sizeRange)
+	if err != nil {
+		return err
+	}
+	count, now := r.Count(), time.Now()
+	c.EmployeeCount, c.EmployeeCountEstimated, c.EmployeeCountAt = &count, r.Estimated, &now
+	if sizeID, ok := headcount.Bucket(r); ok {
+		c.EmployeeSizeID = &sizeID
+	}
+	return nil
+}
+
+// BeforeSave derives the canonical domain from the website when the website
+// changed. A website that cannot be parsed leaves the company without a
+// domain; so does the domain backfill when the host belongs to another
+// company, and saving the company again keeps it that way.
+func (c *Company) BeforeSave(tx *gorm.DB) error {
+	if !c.WebsiteChanged() {
+		return nil
+	}
+	c.Domain = nil
+	if c.Website != nil {
+		if host, err := domain.Canonicalize(*c.Website); err == nil {
+			c.Domain = &host
+		}
+	}
+	return nil
+}
+
+// AfterSave remembers the saved website.
+func (c *Company) AfterSave(tx *gorm.DB) error {
+	c.rememberWebsite()
+	return nil
+}
+
+// AfterFind remembers the loaded website.
+func (c *Company) AfterFind(tx *gorm.DB) error {
+	c.rememberWebsite()
+	return nil
+}
+
+// WebsiteChanged reports whether the website differs from the one the
+// company was loaded or last saved with. It is true for companies that were
+// neither.
+func (c *Company) WebsiteChanged() bool {
+	if !c.stored {
+		return true
+	}
+	if c.Website == nil || c.storedWebsite == nil {
+		return c.Website != c.storedWebsite
+	}
+	return *c.Website != *c.storedWebsite
+}
+
+func (c *Company) rememberWebsite() {
+	c.storedWebsite, c.stored = nil, true
+	if c.Website != nil {
+		website := *c.Website
+		c.storedWebsite = &website
+	}
+}
+
+// BeforeSave normalizes the currency and converts the amount to USD.
+func (r *Revenue) BeforeSave(tx *gorm.DB) error {
+	r.Currency = fx.Normalize(r.Currency)
+	r.AmountUSD = fx.Default().ToUSD(r.Amount, r.Currency, r.RateDate())
+	return nil
+}
+
+// RateDate is the date whose FX rate converts the revenue: the middle of its year.
+func (r *Revenue) RateDate() time.Time {
+	return time.Date(r.Year, time.July, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// BeforeSave normalizes the currency and converts the amount to USD.
+func (f *FundingRound) BeforeSave(tx *gorm.DB) error {
+	if f.Currency != "" {
+		f.Currency = fx.Normalize(f.Currency)
+	}
+	f.AmountUSD = nil
+	if f.Amount != nil {
+		currency := f.Currency
+		if currency == "" {
+			currency = fx.USD
+		}
+		f.AmountUSD = fx.Default().ToUSD(*f.Amount, currency, f.RateDate())
+	}
+	return nil
+}
+
+// RateDate is the date whose FX rate converts the round: its date, or when
+// it was recorded if the date is unknown.
+func (f *FundingRound) RateDate() time.Time {
+	switch {
+	case f.Date != nil:
+		return *f.Date
+	case !f.CreatedAt.IsZero():
+		return f.CreatedAt
+	default:
+		return time.Now()
+	}
+}
+
+// BeforeSave puts the technology in its catalog form.
+func (t *Technology) BeforeSave(tx *gorm.DB) error {
+	t.Normalize()
+	return nil
+}
+
+// Normalize replaces the name with its catalog name and takes the category
+// and vendor from the catalog. Technologies the catalog does not know keep
+// their name and a category given as a catalog category code or name.
+func (t *Technology) Normalize() {
+	catalog := techcatalog.Default()
+	entry, ok := catalog.Lookup(t.TechnologyName)
+	if !ok {
+		t.TechnologyName = catalog.Canonical(t.TechnologyName)
+		category, ok := catalog.Category(deref(t.Category))
+		t.Category = nil
+		if ok {
+			t.Category = &category.Code
+		}
+		return
+	}
+	t.TechnologyName, t.Category, t.Vendor = entry.Name, &entry.Category, nil
+	if entry.Vendor != "" {
+		t.Vendor = &entry.Vendor
+	}
+}
+
+// BeforeSave fills country/region codes and coordinates that were not
+// provided explicitly, based on the free-text city, state and country. When
+// a stored location moves to another city, state or country, the codes and
+// coordinates derived from the old place are recomputed.
+func (l *Location) BeforeSave(tx *gorm.DB) error {
+	if l.ID != 0 {
+		var stored Location
+		err := tx.Session(&gorm.Session{NewDB: true}).Unscoped().
+			Select("city", "state", "country", "country_code", "region_code", "latitude", "longitude").
+			Take(&stored, l.ID).Error
+		switch {
+		case err == nil:
+			l.clearStaleCodes(&stored)
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+	}
+	l.Normalize()
+	return nil
+}
+
+// clearStaleCodes clears the codes and coordinates still holding the values
+// stored for another place, so Normalize derives them from the new one.
+// Values the caller changed along with the place are kept.
+func (l *Location) clearStaleCodes(stored *Location) {
+	if samePlaceText(l.City, stored.City) && samePlaceText(l.State, stored.State) && samePlaceText(l.Country, stored.Country) {
+		return
+	}
+	if sameString(l.CountryCode, stored.CountryCode) {
+		l.CountryCode = nil
+	}
+	if sameString(l.RegionCode, stored.RegionCode) {
+		l.RegionCode = nil
+	}
+	if sameFloat(l.Latitude, stored.Latitude) && sameFloat(l.Longitude, stored.Longitude) {
+		l.Latitude, l.Longitude = nil, nil
+	}
+}
+
+// Normalize resolves the location against the gazetteer without overwriting
+// codes or coordinates that are already set.
+func (l *Location) Normalize() {
+	place := geo.Default().Normalize(deref(l.City), deref(l.State), deref(l.Country))
+	if l.CountryCode == nil && place.CountryCode != "" {
+		l.CountryCode = &place.CountryCode
+	}
+	if l.RegionCode == nil && place.RegionCode != "" {
+		l.RegionCode = &place.RegionCode
+	}
+	if place.City != nil && l.Latitude == nil && l.Longitude == nil {
+		lat, lon := place.City.Latitude, place.City.Longitude
+		l.Latitude = &lat
+		l.Longitude = &lon
+	}
+}
+
+func samePlaceText(a, b *string) bool {
+	return strings.EqualFold(strings.TrimSpace(deref(a)), strings.TrimSpace(deref(b)))
+}
+
+func sameString(a, b *string) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+func sameFloat(a, b *float64) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
 }
 
-func (c *Company) SetEmployeeSizeByRange(sizeRange string) {
-	sizeID := constants.GetCompanySizeID(sizeRange)
-	c.EmployeeSizeID = &sizeID
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
 }
@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+// Data sources, from highest to lowest precedence when observations conflict.
+const (
+	SourceManual  = "manual"
+	SourceAPI     = "api"
+	SourceScraped = "scraped"
+)
+
+type EntityType string
+
+const (
+	EntityCompany      EntityType = "company"
+	EntityRevenue      EntityType = "revenue"
+	EntityFundingRound EntityType = "funding_round"
+	EntityTechnology   EntityType = "technology"
+	EntityLocation     EntityType = "location"
+	EntityContact      EntityType = "contact"
+)
+
+// FieldProvenance is one observation of a single field: who reported which
+// value, when, and how sure they were. Every observation is kept; the one
+// whose value is currently stored on the company is marked Accepted.
+type FieldProvenance struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	CompanyID  uint       `gorm:"not null;index:idx_provenance_field" json:"company_id"`
+	EntityType EntityType `gorm:"type:varchar(20);not null;index:idx_provenance_field" json:"entity_type"`
+	EntityKey  string     `gorm:"type:varchar(100);index:idx_provenance_field" json:"entity_key,omitempty"` // revenue year, funding round type or technology name; empty for company attributes
+	Field      string     `gorm:"type:varchar(50);not null;index:idx_provenance_field" json:"field"`
+	Value      string     `gorm:"type:text" json:"value"`
+	Source     string     `gorm:"type:varchar(50);not null" json:"source"`     // manual, api, scraped
+	Provider   string     `gorm:"type:varchar(100)" json:"provider,omitempty"` // e.g. the enrichment vendor
+	Confidence float64    `json:"confidence"`                                  // 0..1
+	ObservedAt time.Time  `gorm:"not null" json:"observed_at"`
+	Accepted   bool       `gorm:"index" json:"accepted"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (FieldProvenance) TableName() string {
+	return "field_provenance"
+}
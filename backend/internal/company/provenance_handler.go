@@ -0,0 +1,88 @@
+package company
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/company/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ApplyEnrichmentHandler godoc
+// @Summary Apply enrichment data
+// @Description Records what a source reports about a company. Each field is kept as an observation; it replaces the stored value only if it outranks the current one (source manual > api > scraped, then higher confidence, then more recent)
+// @Tags Companies
+// @Accept json
+// @Produce json
+// @Param enrichment body service.EnrichmentResult true "Observed company data"
+// @Success 200 {object} service.EnrichmentOutcome
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]interface{} "The website belongs to another company, whose ID is returned as company_id"
+// @Failure 500 {object} map[string]string
+// @Router /companies/enrichment [post]
+func (h *Handler) ApplyEnrichmentHandler(c *gin.Context) {
+	var req service.EnrichmentResult
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	outcome, err := h.enrichmentService.ApplyEnrichment(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Company not found"})
+			return
+		}
+		var conflict *service.DomainConflictError
+		if errors.As(err, &conflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "company_id": conflict.CompanyID})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outcome)
+}
+
+// GetProvenanceHandler godoc
+// @Summary Explain company data
+// @Description Shows, per field, which observation the stored value came from and every other value sources have reported
+// @Tags Companies
+// @Accept json
+// @Produce json
+// @Param id path int true "Company ID"
+// @Param entity_type query string false "Limit to company, revenue, funding_round or technology"
+// @Param field query string false "Limit to one field, e.g. employee_size_id"
+// @Success 200 {array} service.FieldExplanation
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /companies/{id}/provenance [get]
+func (h *Handler) GetProvenanceHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company ID"})
+		return
+	}
+
+	entityType := model.EntityType(c.Query("entity_type"))
+	explanations, err := h.enrichmentService.ExplainCompany(c.Request.Context(), uint(id), entityType, c.Query("field"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Company not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch provenance"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"company_id": id,
+		"fields":     explanations,
+	})
+}
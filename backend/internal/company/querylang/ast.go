@@ -0,0 +1,146 @@
+// Package querylang implements the advanced company search language, e.g.
+//
+//	industry:fintech AND (tech:kubernetes OR tech:terraform) AND founded:>=2018 AND NOT status:closed
+//
+// Employee counts are matched with headcount:1k-5k or employees:>=100, and
+// backers with investor:"sequoia capital" or lead_investor:accel. tech:k8s
+// matches Kubernetes through the technology catalog, and tech_category:crm
+// companies using any CRM.
+//
+// Queries are parsed into an AST, validated against the company taxonomies and
+// compiled to a SQL fragment that the company repository adds to its search query.
+package querylang
+
+// Node is any expression in a parsed query.
+type Node interface {
+	node()
+}
+
+// AndNode matches companies that satisfy both sides.
+type AndNode struct {
+	Left  Node
+	Right Node
+}
+
+// OrNode matches companies that satisfy either side.
+type OrNode struct {
+	Left  Node
+	Right Node
+}
+
+// NotNode matches companies that do not satisfy Expr.
+type NotNode struct {
+	Expr Node
+}
+
+// TermNode is a single field comparison such as founded:>=2018.
+type TermNode struct {
+	Field Field
+	Op    Operator
+	Value string // raw value as typed
+	Pos   int    // offset of the term in the input
+
+	arg interface{} // value resolved during parsing (IDs, ints, lowercased strings)
+}
+
+func (*AndNode) node()  {}
+func (*OrNode) node()   {}
+func (*NotNode) node()  {}
+func (*TermNode) node() {}
+
+// Operator is a comparison operator in a term.
+type Operator string
+
+const (
+	OpEq  Operator = "="
+	OpGt  Operator = ">"
+	OpGte Operator = ">="
+	OpLt  Operator = "<"
+	OpLte Operator = "<="
+)
+
+// Field identifies a searchable company attribute.
+type Field string
+
+const (
+	FieldText      Field = "text" // bare words, matched against name and website
+	FieldName      Field = "name"
+	FieldIndustry  Field = "industry"
+	FieldSize      Field = "size"
+	FieldHeadcount Field = "headcount" // employee count; size and employees with an operator mean this
+	FieldStatus    Field = "status"
+	FieldFounded   Field = "founded"
+	FieldLocation  Field = "location"
+	FieldCountry   Field = "country"
+	FieldState     Field = "state"
+	FieldCity      Field = "city"
+	FieldFunding   Field = "funding"
+	FieldTech      Field = "tech"          // technology, in its catalog form
+	FieldTechGroup Field = "tech_category" // catalog category of a technology
+	FieldRevenue   Field = "revenue"       // revenue band of the latest reported year, in USD
+	FieldInvestor  Field = "investor"
+	FieldLead      Field = "lead_investor" // investor that led a round
+)
+
+// fieldAliases maps every accepted field spelling to its canonical field.
+var fieldAliases = map[string]Field{
+	"name":           FieldName,
+	"industry":       FieldIndustry,
+	"size":           FieldSize,
+	"employees":      FieldSize,
+	"employee_size":  FieldSize,
+	"headcount":      FieldHeadcount,
+	"employee_count": FieldHeadcount,
+	"status":         FieldStatus,
+	"founded":        FieldFounded,
+	"location":       FieldLocation,
+	"hq":             FieldLocation,
+	"country":        FieldCountry,
+	"state":          FieldState,
+	"region":         FieldState,
+	"city":           FieldCity,
+	"funding":        FieldFunding,
+	"stage":          FieldFunding,
+	"funding_stage":  FieldFunding,
+	"tech":           FieldTech,
+	"technology":     FieldTech,
+	"tech_category":  FieldTechGroup,
+	"revenue":        FieldRevenue,
+	"investor":       FieldInvestor,
+	"backed_by":      FieldInvestor,
+	"lead_investor":  FieldLead,
+	"led_by":         FieldLead,
+}
+
+// And joins nodes with AND, skipping nils. It returns nil when no nodes are given.
+func And(nodes ...Node) Node {
+	return join(nodes, func(l, r Node) Node { return &AndNode{Left: l, Right: r} })
+}
+
+// Or joins nodes with OR, skipping nils. It returns nil when no nodes are given.
+func Or(nodes ...Node) Node {
+	return join(nodes, func(l, r Node) Node { return &OrNode{Left: l, Right: r} })
+}
+
+func join(nodes []Node, combine func(l, r Node) Node) Node {
+	present := make([]Node, 0, len(nodes))
+	for _, n := range nodes {
+		if n != nil {
+			present = append(present, n)
+		}
+	}
+	return balance(present, combine)
+}
+
+// balance combines nodes into a tree of logarithmic depth; AND and OR are
+// associative, so the shape does not change what it matches.
+func balance(nodes []Node, combine func(l, r Node) Node) Node {
+	switch len(nodes) {
+	case 0:
+		return nil
+	case 1:
+		return nodes[0]
+	}
+	mid := len(nodes) / 2
+	return combine(balance(nodes[:mid], combine), balance(nodes[mid:], combine))
+}
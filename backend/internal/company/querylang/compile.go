@@ -0,0 +1,122 @@
+package querylang
+
+import (
+	"strings"
+
+	"github.com/bhati00/Fynelo/backend/internal/constants"
+	"github.com/bhati00/Fynelo/backend/internal/headcount"
+)
+
+// LatestRevenueUSD selects a company's latest reported revenue in USD, the
+// amount revenue bands, filters and sorts compare.
+const LatestRevenueUSD = "(SELECT r.amount_usd FROM revenues r WHERE r.company_id = companies.id AND r.deleted_at IS NULL ORDER BY r.year DESC, r.id DESC LIMIT 1)"
+
+// Compile turns a parsed query into a SQL condition over the companies table
+// and its bind arguments. Child-table fields compile to subqueries, so the
+// condition never multiplies company rows.
+func Compile(n Node) (string, []interface{}) {
+	var sb strings.Builder
+	var args []interface{}
+	compile(n, &sb, &args)
+	return sb.String(), args
+}
+
+func compile(n Node, sb *strings.Builder, args *[]interface{}) {
+	switch n := n.(type) {
+	case *AndNode:
+		sb.WriteString("(")
+		compile(n.Left, sb, args)
+		sb.WriteString(" AND ")
+		compile(n.Right, sb, args)
+		sb.WriteString(")")
+	case *OrNode:
+		sb.WriteString("(")
+		compile(n.Left, sb, args)
+		sb.WriteString(" OR ")
+		compile(n.Right, sb, args)
+		sb.WriteString(")")
+	case *NotNode:
+		sb.WriteString("NOT ")
+		compile(n.Expr, sb, args)
+	case *TermNode:
+		compileTerm(n, sb, args)
+	}
+}
+
+func compileTerm(t *TermNode, sb *strings.Builder, args *[]interface{}) {
+	switch t.Field {
+	case FieldText:
+		like := "%" + t.arg.(string) + "%"
+		sb.WriteString("(LOWER(companies.name) LIKE ? OR LOWER(COALESCE(companies.website, '')) LIKE ?)")
+		*args = append(*args, like, like)
+	case FieldName:
+		sb.WriteString("LOWER(companies.name) LIKE ?")
+		*args = append(*args, "%"+t.arg.(string)+"%")
+	case FieldLocation:
+		sb.WriteString("LOWER(COALESCE(companies.hq_location, '')) LIKE ?")
+		*args = append(*args, "%"+t.arg.(string)+"%")
+	case FieldIndustry:
+		sb.WriteString("COALESCE(companies.industry_id, 0) = ?")
+		*args = append(*args, t.arg)
+	case FieldSize:
+		sb.WriteString("COALESCE(companies.employee_size_id, 0) = ?")
+		*args = append(*args, t.arg)
+	case FieldHeadcount:
+		r := t.arg.(headcount.Range)
+		switch {
+		case t.Op != OpEq:
+			sb.WriteString("(companies.employee_count IS NOT NULL AND companies.employee_count " + string(t.Op) + " ?)")
+			*args = append(*args, r.Min)
+		case r.Max == 0:
+			sb.WriteString("(companies.employee_count IS NOT NULL AND companies.employee_count >= ?)")
+			*args = append(*args, r.Min)
+		default:
+			sb.WriteString("(companies.employee_count IS NOT NULL AND companies.employee_count BETWEEN ? AND ?)")
+			*args = append(*args, r.Min, r.Max)
+		}
+	case FieldStatus:
+		sb.WriteString("companies.status = ?")
+		*args = append(*args, t.arg)
+	case FieldFounded:
+		sb.WriteString("(companies.founded_year IS NOT NULL AND companies.founded_year " + string(t.Op) + " ?)")
+		*args = append(*args, t.arg)
+	case FieldFunding:
+		sb.WriteString("companies.id IN (SELECT company_id FROM funding_rounds WHERE round_type = ? AND deleted_at IS NULL)")
+		*args = append(*args, t.arg)
+	case FieldCountry:
+		sb.WriteString("companies.id IN (SELECT company_id FROM locations WHERE country_code = ? AND deleted_at IS NULL)")
+		*args = append(*args, t.arg)
+	case FieldState:
+		sb.WriteString("companies.id IN (SELECT company_id FROM locations WHERE region_code = ? AND deleted_at IS NULL)")
+		*args = append(*args, t.arg)
+	case FieldCity:
+		sb.WriteString("companies.id IN (SELECT company_id FROM locations WHERE LOWER(city) = ? AND deleted_at IS NULL)")
+		*args = append(*args, t.arg)
+	case FieldRevenue:
+		bounds := constants.RevenueBandBounds[t.arg.(int)]
+		sb.WriteString("(" + LatestRevenueUSD + " >= ?")
+		*args = append(*args, bounds[0])
+		if bounds[1] > 0 {
+			sb.WriteString(" AND " + LatestRevenueUSD + " < ?")
+			*args = append(*args, bounds[1])
+		}
+		sb.WriteString(")")
+	case FieldInvestor, FieldLead:
+		// the name, or its first words: investor:sequoia matches Sequoia Capital
+		sb.WriteString("companies.id IN (SELECT fr.company_id FROM funding_round_investors fri " +
+			"JOIN funding_rounds fr ON fr.id = fri.funding_round_id AND fr.deleted_at IS NULL " +
+			"JOIN investors i ON i.id = fri.investor_id AND i.deleted_at IS NULL " +
+			"WHERE (i.normalized_name = ? OR i.normalized_name LIKE ?)")
+		if t.Field == FieldLead {
+			sb.WriteString(" AND fri.lead")
+		}
+		sb.WriteString(")")
+		*args = append(*args, t.arg, t.arg.(string)+" %")
+	case FieldTechGroup:
+		sb.WriteString("companies.id IN (SELECT company_id FROM technologies WHERE category = ? AND deleted_at IS NULL)")
+		*args = append(*args, t.arg)
+	case FieldTech:
+		sb.WriteString("companies.id IN (SELECT company_id FROM technologies WHERE LOWER(technology_name) = ? AND deleted_at IS NULL)")
+		*args = append(*args, t.arg)
+	}
+}
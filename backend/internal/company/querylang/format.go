@@ -0,0 +1,73 @@
+package querylang
+
+import "strings"
+
+// Format renders a node as query text that Parse turns back into an
+// equivalent expression, e.g. for handing a built expression to a job that
+// only carries query strings. A nil node formats as "".
+func Format(n Node) string {
+	var sb strings.Builder
+	format(n, &sb)
+	return sb.String()
+}
+
+func format(n Node, sb *strings.Builder) {
+	switch n := n.(type) {
+	case *AndNode:
+		// AND binds tighter than OR, so OR operands need parentheses
+		formatGrouped(n.Left, sb, isOr)
+		sb.WriteString(" AND ")
+		formatGrouped(n.Right, sb, isOr)
+	case *OrNode:
+		format(n.Left, sb)
+		sb.WriteString(" OR ")
+		format(n.Right, sb)
+	case *NotNode:
+		sb.WriteString("NOT ")
+		formatGrouped(n.Expr, sb, func(n Node) bool { return isOr(n) || isAnd(n) })
+	case *TermNode:
+		if n.Field != FieldText {
+			sb.WriteString(string(n.Field))
+			sb.WriteString(":")
+			if n.Op != OpEq {
+				sb.WriteString(string(n.Op))
+			}
+		}
+		sb.WriteString(quote(n.Value))
+	}
+}
+
+func formatGrouped(n Node, sb *strings.Builder, needsParens func(Node) bool) {
+	if needsParens(n) {
+		sb.WriteString("(")
+		format(n, sb)
+		sb.WriteString(")")
+		return
+	}
+	format(n, sb)
+}
+
+func isOr(n Node) bool {
+	_, ok := n.(*OrNode)
+	return ok
+}
+
+func isAnd(n Node) bool {
+	_, ok := n.(*AndNode)
+	return ok
+}
+
+// quote returns value as is when the lexer reads it as a single word, and as
+// a quoted string otherwise.
+func quote(value string) string {
+	plain := value != "" && value != "AND" && value != "OR" && value != "NOT"
+	for i := 0; plain && i < len(value); i++ {
+		if isDelimiter(value[i]) {
+			plain = false
+		}
+	}
+	if plain {
+		return value
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
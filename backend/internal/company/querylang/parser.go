@@ -0,0 +1,351 @@
+package querylang
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/constants"
+	"github.com/bhati00/Fynelo/backend/internal/geo"
+	"github.com/bhati00/Fynelo/backend/internal/headcount"
+	"github.com/bhati00/Fynelo/backend/internal/taxonomy"
+	"github.com/bhati00/Fynelo/backend/internal/techcatalog"
+)
+
+// Grammar:
+//
+//	query   = or EOF
+//	or      = and { "OR" and }
+//	and     = unary { ["AND"] unary }     adjacent terms are implicitly ANDed
+//	unary   = "NOT" unary | primary
+//	primary = "(" or ")" | term
+//	term    = field ":" [op] value | value
+//	value   = word | quoted string
+
+// Limits on a query's size. The terms of an ICP with every criterion at its
+// limit (7 criteria of 50 values) stay within maxTerms.
+const (
+	maxQueryLength = 20000 // bytes
+	maxTerms       = 500
+	maxDepth       = 20 // nested parentheses and NOTs
+)
+
+// Parse parses and validates an advanced query. Errors are *SyntaxError values.
+// Chains of AND and OR are built as balanced trees, so long queries do not
+// compile to deeply nested SQL.
+func Parse(input string) (Node, error) {
+	if len(input) > maxQueryLength {
+		return nil, errorAt(maxQueryLength, "query is longer than %d characters", maxQueryLength)
+	}
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokEOF {
+		return nil, errorAt(0, "query is empty")
+	}
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		if t.kind == tokRParen {
+			return nil, errorAt(t.pos, "unexpected ')' without matching '('")
+		}
+		return nil, errorAt(t.pos, "unexpected %s", t.kind)
+	}
+	return n, nil
+}
+
+// NewTerm builds a validated term for programmatic query construction.
+func NewTerm(field Field, op Operator, value string) (*TermNode, error) {
+	t := &TermNode{Field: field, Op: op, Value: value}
+	if err := t.resolve(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	terms  int // terms parsed so far
+	depth  int // open parentheses and NOTs
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) parseOr() (Node, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	operands := []Node{first}
+	for p.peek().kind == tokOr {
+		p.next()
+		operand, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+	}
+	return Or(operands...), nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	first, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	operands := []Node{first}
+	for {
+		switch p.peek().kind {
+		case tokAnd:
+			p.next()
+		case tokWord, tokString, tokLParen, tokNot:
+			// implicit AND
+		default:
+			return And(operands...), nil
+		}
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+	}
+}
+
+// enter opens a nesting level at pos; leave closes it.
+func (p *parser) enter(pos int) error {
+	if p.depth++; p.depth > maxDepth {
+		return errorAt(pos, "query is nested more than %d levels deep", maxDepth)
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	if t := p.peek(); t.kind == tokNot {
+		p.next()
+		if err := p.enter(t.pos); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &NotNode{Expr: expr}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	t := p.peek()
+	switch t.kind {
+	case tokLParen:
+		p.next()
+		if err := p.enter(t.pos); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.peek(); closing.kind != tokRParen {
+			return nil, errorAt(closing.pos, "expected ')' to close '(' at position %d", t.pos)
+		}
+		p.next()
+		return n, nil
+	case tokWord, tokString:
+		return p.parseTerm()
+	case tokEOF:
+		return nil, errorAt(t.pos, "unexpected end of query, expected a term")
+	}
+	return nil, errorAt(t.pos, "unexpected %s, expected a term", t.kind)
+}
+
+func (p *parser) parseTerm() (Node, error) {
+	first := p.next()
+	if p.terms++; p.terms > maxTerms {
+		return nil, errorAt(first.pos, "query has more than %d terms", maxTerms)
+	}
+	if first.kind == tokString || p.peek().kind != tokColon {
+		// bare word or phrase: free-text match on name and website
+		term := &TermNode{Field: FieldText, Op: OpEq, Value: first.text, Pos: first.pos}
+		if err := term.resolve(); err != nil {
+			return nil, err
+		}
+		return term, nil
+	}
+
+	field, ok := fieldAliases[strings.ToLower(first.text)]
+	if !ok {
+		return nil, errorAt(first.pos, "unknown field %q", first.text)
+	}
+	p.next() // colon
+
+	op := OpEq
+	if t := p.peek(); t.kind == tokOp {
+		op = Operator(p.next().text)
+	}
+
+	value := p.next()
+	if value.kind != tokWord && value.kind != tokString {
+		return nil, errorAt(value.pos, "expected a value for field %q", first.text)
+	}
+
+	// sizes are buckets; comparing them means comparing employee counts
+	if field == FieldSize && op != OpEq {
+		field = FieldHeadcount
+	}
+
+	term := &TermNode{Field: field, Op: op, Value: value.text, Pos: first.pos}
+	if err := term.resolve(); err != nil {
+		// point at the value, which is what the user has to fix
+		err.Pos = value.pos
+		return nil, err
+	}
+	return term, nil
+}
+
+// resolve validates the operator and converts the raw value for compilation.
+func (t *TermNode) resolve() *SyntaxError {
+	if t.Op != OpEq && t.Field != FieldFounded && t.Field != FieldHeadcount {
+		return errorAt(t.Pos, "operator %q is not supported for field %q", t.Op, t.Field)
+	}
+
+	value := strings.TrimSpace(t.Value)
+	lower := strings.ToLower(value)
+	if value == "" {
+		return errorAt(t.Pos, "empty value for field %q", t.Field)
+	}
+
+	switch t.Field {
+	case FieldText, FieldName, FieldLocation, FieldCity:
+		t.arg = lower
+	case FieldTech:
+		t.arg = strings.ToLower(techcatalog.Default().Canonical(value))
+	case FieldTechGroup:
+		category, ok := techcatalog.Default().Category(value)
+		if !ok {
+			return errorAt(t.Pos, "unknown technology category %q", value)
+		}
+		t.arg = category.Code
+	case FieldCountry:
+		country, ok := geo.Default().Country(value)
+		if !ok {
+			return errorAt(t.Pos, "unknown country %q", value)
+		}
+		t.arg = country.Code
+	case FieldState:
+		region, ok := geo.Default().Region(value, "")
+		if !ok {
+			return errorAt(t.Pos, "unknown or ambiguous state %q", value)
+		}
+		t.arg = region.Code
+	case FieldIndustry:
+		if id, ok := taxonomy.Default().Lookup(taxonomy.Industries, value); ok {
+			t.arg = id
+		} else if id, err := strconv.Atoi(value); err == nil && taxonomy.Default().Valid(taxonomy.Industries, id) {
+			t.arg = id
+		} else {
+			return errorAt(t.Pos, "unknown industry %q", value)
+		}
+	case FieldSize:
+		if id, ok := taxonomy.Default().Lookup(taxonomy.CompanySizes, value); ok {
+			t.arg = id
+		} else if id, err := strconv.Atoi(value); err == nil && taxonomy.Default().Valid(taxonomy.CompanySizes, id) {
+			t.arg = id
+		} else if r, err := headcount.Parse(value); err == nil {
+			// a count or range that is not a size name, e.g. employees:1k-5k
+			t.Field, t.arg = FieldHeadcount, r
+		} else {
+			return errorAt(t.Pos, "unknown employee size %q", value)
+		}
+	case FieldHeadcount:
+		r, err := headcount.Parse(value)
+		if err != nil {
+			return errorAt(t.Pos, "headcount expects a count or range such as 250, 1k-5k or 500+, got %q", value)
+		}
+		if t.Op != OpEq && (r.Max == 0 || r.Max != r.Min) {
+			return errorAt(t.Pos, "operator %q needs a single count, got %q", t.Op, value)
+		}
+		t.arg = r
+	case FieldRevenue:
+		if id, ok := constants.RevenueBandNamesToID[lower]; ok {
+			t.arg = id
+		} else if id, err := strconv.Atoi(value); err == nil && constants.RevenueBandNames[id] != "" {
+			t.arg = id
+		} else {
+			return errorAt(t.Pos, "unknown revenue band %q", value)
+		}
+	case FieldInvestor, FieldLead:
+		name := model.NormalizeInvestorName(value)
+		if name == "" {
+			return errorAt(t.Pos, "invalid investor name %q", value)
+		}
+		t.arg = name
+	case FieldStatus:
+		if !isOneOf(lower, companyStatuses) {
+			return errorAt(t.Pos, "unknown status %q", value)
+		}
+		t.arg = lower
+	case FieldFunding:
+		if !isOneOf(lower, fundingRoundTypes) {
+			return errorAt(t.Pos, "unknown funding stage %q", value)
+		}
+		t.arg = lower
+	case FieldFounded:
+		year, err := strconv.Atoi(value)
+		if err != nil {
+			return errorAt(t.Pos, "founded expects a year, got %q", value)
+		}
+		t.arg = year
+	default:
+		return errorAt(t.Pos, "unknown field %q", t.Field)
+	}
+	return nil
+}
+
+var companyStatuses = []string{
+	string(model.StatusActive),
+	string(model.StatusClosed),
+	string(model.StatusAcquired),
+	string(model.StatusIPO),
+	string(model.StatusPrivate),
+}
+
+var fundingRoundTypes = []string{
+	string(model.RoundSeed),
+	string(model.RoundSeriesA),
+	string(model.RoundSeriesB),
+	string(model.RoundSeriesC),
+	string(model.RoundSeriesD),
+	string(model.RoundIPO),
+	string(model.RoundAcquisition),
+}
+
+func isOneOf(v string, options []string) bool {
+	for _, o := range options {
+		if v == o {
+			return true
+		}
+	}
+	return false
+}
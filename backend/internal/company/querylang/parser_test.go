@@ -0,0 +1,234 @@
+package querylang
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// sexpr renders a node with its structure visible, e.g.
+// (and text:acme (not status:closed)).
+func sexpr(n Node) string {
+	switch n := n.(type) {
+	case *AndNode:
+		return "(and " + sexpr(n.Left) + " " + sexpr(n.Right) + ")"
+	case *OrNode:
+		return "(or " + sexpr(n.Left) + " " + sexpr(n.Right) + ")"
+	case *NotNode:
+		return "(not " + sexpr(n.Expr) + ")"
+	case *TermNode:
+		op := ""
+		if n.Op != OpEq {
+			op = string(n.Op)
+		}
+		return string(n.Field) + ":" + op + n.Value
+	}
+	return fmt.Sprintf("%T", n)
+}
+
+func depth(n Node) int {
+	switch n := n.(type) {
+	case *AndNode:
+		return 1 + max(depth(n.Left), depth(n.Right))
+	case *OrNode:
+		return 1 + max(depth(n.Left), depth(n.Right))
+	case *NotNode:
+		return 1 + depth(n.Expr)
+	}
+	return 0
+}
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		query, want string
+	}{
+		{"acme", "text:acme"},
+		{`"acme corp"`, "text:acme corp"},
+		{"industry:fintech", "industry:fintech"},
+		{"founded:>=2018", "founded:>=2018"},
+		{"employees:>=100", "headcount:>=100"},
+		{"employees:1k-5k", "headcount:1k-5k"},
+		{"TECH:k8s", "tech:k8s"},
+
+		// implicit AND
+		{"acme tech:kubernetes", "(and text:acme tech:kubernetes)"},
+		{"acme AND tech:kubernetes", "(and text:acme tech:kubernetes)"},
+		{"acme (tech:go OR tech:rust)", "(and text:acme (or tech:go tech:rust))"},
+
+		// AND binds tighter than OR
+		{"a OR b c", "(or text:a (and text:b text:c))"},
+		{"a b OR c", "(or (and text:a text:b) text:c)"},
+		{"(a OR b) c", "(and (or text:a text:b) text:c)"},
+
+		// NOT binds tighter than AND and OR
+		{"NOT status:closed tech:go", "(and (not status:closed) tech:go)"},
+		{"NOT status:closed OR tech:go", "(or (not status:closed) tech:go)"},
+		{"NOT (status:closed OR tech:go)", "(not (or status:closed tech:go))"},
+		{"NOT NOT acme", "(not (not text:acme))"},
+		{"acme NOT tech:go", "(and text:acme (not tech:go))"},
+
+		// keywords are upper case only
+		{"rock and roll", "(and text:rock (and text:and text:roll))"},
+		{`"AND"`, "text:AND"},
+
+		// chains are balanced
+		{"a b c d", "(and (and text:a text:b) (and text:c text:d))"},
+		{"a OR b OR c", "(or text:a (or text:b text:c))"},
+	} {
+		n, err := Parse(tc.query)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tc.query, err)
+			continue
+		}
+		if got := sexpr(n); got != tc.want {
+			t.Errorf("Parse(%q) = %s, want %s", tc.query, got, tc.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tc := range []struct {
+		query string
+		pos   int
+		msg   string
+	}{
+		{"", 0, "query is empty"},
+		{"   ", 0, "query is empty"},
+		{`name:"acme`, 5, "unterminated quoted string"},
+		{`acme "corp`, 5, "unterminated quoted string"},
+		{"acme)", 4, "unexpected ')' without matching '('"},
+		{"(acme", 5, "expected ')' to close '(' at position 0"},
+		{"acme OR", 7, "unexpected end of query, expected a term"},
+		{"acme AND OR b", 9, "unexpected OR, expected a term"},
+		{"NOT", 3, "unexpected end of query, expected a term"},
+		{"()", 1, "unexpected ')', expected a term"},
+		{"name:", 5, "expected a value for field \"name\""},
+		{"name:(acme)", 5, "expected a value for field \"name\""},
+
+		// unknown fields and values
+		{"colour:red", 0, `unknown field "colour"`},
+		{"acme color:red", 5, `unknown field "color"`},
+		{"industry:underwater-basket-weaving", 9, `unknown industry "underwater-basket-weaving"`},
+		{"size:gigantic", 5, `unknown employee size "gigantic"`},
+		{"status:zombie", 7, `unknown status "zombie"`},
+		{"funding:series-z", 8, `unknown funding stage "series-z"`},
+		{"country:atlantis", 8, `unknown country "atlantis"`},
+		{"revenue:1b-2b", 8, `unknown revenue band "1b-2b"`},
+		{"tech_category:teleportation", 14, `unknown technology category "teleportation"`},
+		{"founded:soon", 8, `founded expects a year, got "soon"`},
+		{"status:>active", 8, `operator ">" is not supported for field "status"`},
+		{"headcount:>=1k-5k", 12, `operator ">=" needs a single count, got "1k-5k"`},
+		{`name:"  "`, 5, `empty value for field "name"`},
+	} {
+		_, err := Parse(tc.query)
+		var syntax *SyntaxError
+		if !errors.As(err, &syntax) {
+			t.Errorf("Parse(%q) = %v, want a SyntaxError", tc.query, err)
+			continue
+		}
+		if syntax.Pos != tc.pos || syntax.Msg != tc.msg {
+			t.Errorf("Parse(%q) = %q at %d, want %q at %d", tc.query, syntax.Msg, syntax.Pos, tc.msg, tc.pos)
+		}
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	words := func(n int) string { return strings.TrimSpace(strings.Repeat("acme ", n)) }
+
+	n, err := Parse(words(maxTerms))
+	if err != nil {
+		t.Fatalf("%d terms: %v", maxTerms, err)
+	}
+	if d := depth(n); d > 10 {
+		t.Errorf("%d implicitly ANDed terms nest %d levels deep", maxTerms, d)
+	}
+
+	for _, tc := range []struct {
+		name, query string
+		pos         int
+		msg         string
+	}{
+		{"terms", words(1200), maxTerms * 5, fmt.Sprintf("query has more than %d terms", maxTerms)},
+		{"parentheses", strings.Repeat("(", maxDepth+1) + "acme" + strings.Repeat(")", maxDepth+1), maxDepth, fmt.Sprintf("query is nested more than %d levels deep", maxDepth)},
+		{"nots", strings.Repeat("NOT ", maxDepth+1) + "acme", maxDepth * 4, fmt.Sprintf("query is nested more than %d levels deep", maxDepth)},
+		{"length", "name:" + strings.Repeat("a", maxQueryLength), maxQueryLength, fmt.Sprintf("query is longer than %d characters", maxQueryLength)},
+	} {
+		_, err := Parse(tc.query)
+		var syntax *SyntaxError
+		if !errors.As(err, &syntax) || syntax.Pos != tc.pos || syntax.Msg != tc.msg {
+			t.Errorf("%s: err = %v, want %q at %d", tc.name, err, tc.msg, tc.pos)
+		}
+	}
+
+	nested := strings.Repeat("(", maxDepth) + "acme" + strings.Repeat(")", maxDepth)
+	if _, err := Parse(nested); err != nil {
+		t.Errorf("%d nested parentheses: %v", maxDepth, err)
+	}
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	for _, query := range []string{
+		"acme",
+		`"acme corp" tech:k8s`,
+		"industry:fintech AND (tech:kubernetes OR tech:terraform) AND founded:>=2018 AND NOT status:closed",
+		"a OR b c",
+		"(a OR b) c",
+		"NOT (a OR b)",
+		"NOT (a b)",
+		"NOT NOT a",
+		`name:"O'Neil \"Bros\"" OR name:"AND"`,
+		`investor:"sequoia capital" lead_investor:accel`,
+		"employees:>=100 headcount:1k-5k size:51-200",
+		"country:US OR state:US-CA city:berlin",
+		"revenue:10m-50m funding:seed tech_category:crm",
+	} {
+		first, err := Parse(query)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", query, err)
+			continue
+		}
+		text := Format(first)
+		second, err := Parse(text)
+		if err != nil {
+			t.Errorf("Parse(Format(%q)) = Parse(%q): %v", query, text, err)
+			continue
+		}
+		if sexpr(first) != sexpr(second) {
+			t.Errorf("%q formats as %q, which parses as %s, want %s", query, text, sexpr(second), sexpr(first))
+		}
+		if !reflect.DeepEqual(first, withoutPositions(second, first)) {
+			t.Errorf("%q: round trip changed resolved values", query)
+		}
+	}
+}
+
+// withoutPositions copies the term positions of want into got, which only
+// differ because formatting changes the spacing.
+func withoutPositions(got, want Node) Node {
+	switch g := got.(type) {
+	case *AndNode:
+		w := want.(*AndNode)
+		return &AndNode{Left: withoutPositions(g.Left, w.Left), Right: withoutPositions(g.Right, w.Right)}
+	case *OrNode:
+		w := want.(*OrNode)
+		return &OrNode{Left: withoutPositions(g.Left, w.Left), Right: withoutPositions(g.Right, w.Right)}
+	case *NotNode:
+		return &NotNode{Expr: withoutPositions(g.Expr, want.(*NotNode).Expr)}
+	case *TermNode:
+		t := *g
+		t.Pos = want.(*TermNode).Pos
+		return &t
+	}
+	return got
+}
+
+func TestFormatNil(t *testing.T) {
+	if got := Format(nil); got != "" {
+		t.Errorf("Format(nil) = %q, want empty", got)
+	}
+	if And(nil, nil) != nil || Or() != nil {
+		t.Error("joining no nodes should give nil")
+	}
+}
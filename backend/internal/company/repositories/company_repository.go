@@ -2,36 +2,105 @@ package repositories
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"sort"
 	"strings"
 
 	models "github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/company/querylang"
+	"github.com/bhati00/Fynelo/backend/internal/constants"
+	"github.com/bhati00/Fynelo/backend/internal/geo"
 
 	"gorm.io/gorm"
 )
 
 type CompanySearchParams struct {
-	Query          string // Company name, website search
-	IndustryID     *int   // Industry filter
-	EmployeeSizeID *int   // Employee size filter
-	Location       string // Location filter
-	FundingStage   string // Funding stage filter
-	FoundedMin     *int   // Founded after year
-	FoundedMax     *int   // Founded before year
-	Status         string // Company status
-	Limit          int    // Pagination limit
-	Offset         int    // Pagination offset
+	Query          string     // Company name, website search
+	IndustryID     *int       // Industry filter
+	EmployeeSizeID *int       // Employee size filter
+	EmployeesMin   *int       // At least this many employees
+	EmployeesMax   *int       // At most this many employees
+	Location       string     // Location filter
+	CountryCode    string     // ISO-3166-1 alpha-2, matched against any company location
+	RegionCode     string     // ISO-3166-2, matched against any company location
+	Cities         []string   // City names (lowercase), matched against any company location
+	Near           *GeoRadius // Radius filter around a point
+	FundingStage   string     // Funding stage filter
+	FoundedMin     *int       // Founded after year
+	FoundedMax     *int       // Founded before year
+	RevenueMinUSD  *float64   // Latest reported revenue, in USD, at least
+	RevenueMaxUSD  *float64   // Latest reported revenue, in USD, at most
+	FundingMinUSD  *float64   // Total disclosed funding, in USD, at least
+	InvestorID     *uint      // Backed by this investor
+	Investor       string     // Backed by an investor with this normalized name, or one starting with its words
+	InvestorLead   bool       // Only rounds the investor led count
+	TechCategory   string     // Uses a technology of this catalog category code
+	Status         string     // Company status
+	Limit          int        // Pagination limit
+	Offset         int        // Pagination offset
+	Sort           string     // SortRevenue, SortFunding or "" for storage order
+
+	Expression querylang.Node // Parsed advanced query, ANDed with the filters above
+}
+
+// GeoRadius restricts results to companies with a location within RadiusKm of a point.
+type GeoRadius struct {
+	Latitude  float64
+	Longitude float64
+	RadiusKm  float64
 }
 
+// FacetBucket is a single value and the number of matching companies for it.
+type FacetBucket struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+	Count int64  `json:"count"`
+}
+
+// SearchFacets holds per-attribute counts for a search result set. Each facet is
+// computed with its own filter removed, so the counts answer "what if I picked
+// this value instead" for the other filters currently applied.
+type SearchFacets struct {
+	Industries    []FacetBucket `json:"industries"`
+	EmployeeSizes []FacetBucket `json:"employee_sizes"`
+	Statuses      []FacetBucket `json:"statuses"`
+	FundingStages []FacetBucket `json:"funding_stages"`
+	Countries     []FacetBucket `json:"countries"`
+	Technologies  []FacetBucket `json:"technologies"`
+	RevenueBands  []FacetBucket `json:"revenue_bands"` // latest reported revenue, in USD
+}
+
+// Search orders; companies without the value sort last
+const (
+	SortRevenue = "revenue" // latest reported revenue in USD, highest first
+	SortFunding = "funding" // total disclosed funding in USD, highest first
+)
+
+// USD amounts compared by search, per company
+const (
+	latestRevenueUSD = querylang.LatestRevenueUSD
+	totalFundingUSD  = "(SELECT SUM(f.amount_usd) FROM funding_rounds f WHERE f.company_id = companies.id AND f.deleted_at IS NULL)"
+)
+
+// Number of technologies returned in the technologies facet
+const facetTechnologyLimit = 10
+
 type CompanyRepository interface {
 	Create(ctx context.Context, company *models.Company) error
 	Update(ctx context.Context, company *models.Company) error
 	FindByID(ctx context.Context, id uint) (*models.Company, error)
+	// FindByIDWithDetails also loads revenues (oldest year first), funding
+	// rounds with their investors (oldest first), technologies and locations.
+	FindByIDWithDetails(ctx context.Context, id uint) (*models.Company, error)
 	FindByName(ctx context.Context, name string) (*models.Company, error)
+	FindByDomain(ctx context.Context, domain string) (*models.Company, error)
 	Delete(ctx context.Context, id uint) error
 	List(ctx context.Context, limit, offset int) ([]models.Company, error)
 	// New search methods
 	Search(ctx context.Context, params CompanySearchParams) ([]models.Company, error)
 	SearchCount(ctx context.Context, params CompanySearchParams) (int64, error)
+	SearchFacets(ctx context.Context, params CompanySearchParams) (*SearchFacets, error)
 }
 
 type companyRepo struct {
@@ -43,11 +112,26 @@ func NewCompanyRepository(db *gorm.DB) CompanyRepository {
 }
 
 func (r *companyRepo) Create(ctx context.Context, company *models.Company) error {
-	return r.db.WithContext(ctx).Create(company).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(company).Error; err != nil {
+			return err
+		}
+		return recordCreate(tx, company.ID, models.EntityCompany, company.ID, company)
+	})
 }
 
+// Update saves the company and records every changed field in its history.
 func (r *companyRepo) Update(ctx context.Context, company *models.Company) error {
-	return r.db.WithContext(ctx).Save(company).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before models.Company
+		if err := tx.First(&before, company.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(company).Error; err != nil {
+			return err
+		}
+		return recordUpdate(tx, company.ID, models.EntityCompany, company.ID, &before, company)
+	})
 }
 
 func (r *companyRepo) FindByID(ctx context.Context, id uint) (*models.Company, error) {
@@ -58,6 +142,22 @@ func (r *companyRepo) FindByID(ctx context.Context, id uint) (*models.Company, e
 	return &c, nil
 }
 
+func (r *companyRepo) FindByIDWithDetails(ctx context.Context, id uint) (*models.Company, error) {
+	var c models.Company
+	if err := r.db.WithContext(ctx).
+		Preload("Revenues", func(db *gorm.DB) *gorm.DB { return db.Order("year ASC") }).
+		Preload("FundingRounds", func(db *gorm.DB) *gorm.DB {
+			return db.Order("date IS NULL, date ASC, id ASC")
+		}).
+		Preload("FundingRounds.RoundInvestors.Investor").
+		Preload("Technologies", func(db *gorm.DB) *gorm.DB { return db.Order("technology_name ASC") }).
+		Preload("Locations", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		First(&c, id).Error; err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
 func (r *companyRepo) FindByName(ctx context.Context, name string) (*models.Company, error) {
 	var c models.Company
 	if err := r.db.WithContext(ctx).Where("name = ?", name).First(&c).Error; err != nil {
@@ -66,8 +166,25 @@ func (r *companyRepo) FindByName(ctx context.Context, name string) (*models.Comp
 	return &c, nil
 }
 
+func (r *companyRepo) FindByDomain(ctx context.Context, domain string) (*models.Company, error) {
+	var c models.Company
+	if err := r.db.WithContext(ctx).Where("domain = ?", domain).First(&c).Error; err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
 func (r *companyRepo) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&models.Company{}, id).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var company models.Company
+		if err := tx.First(&company, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&company).Error; err != nil {
+			return err
+		}
+		return recordDelete(tx, id, models.EntityCompany, id, &company)
+	})
 }
 
 func (r *companyRepo) List(ctx context.Context, limit, offset int) ([]models.Company, error) {
@@ -81,6 +198,12 @@ func (r *companyRepo) List(ctx context.Context, limit, offset int) ([]models.Com
 func (r *companyRepo) Search(ctx context.Context, params CompanySearchParams) ([]models.Company, error) {
 	var companies []models.Company
 	query := r.buildSearchQuery(params)
+	switch params.Sort {
+	case SortRevenue:
+		query = query.Order(latestRevenueUSD + " IS NULL, " + latestRevenueUSD + " DESC, companies.id")
+	case SortFunding:
+		query = query.Order(totalFundingUSD + " IS NULL, " + totalFundingUSD + " DESC, companies.id")
+	}
 
 	if err := query.WithContext(ctx).Limit(params.Limit).Offset(params.Offset).Find(&companies).Error; err != nil {
 		return nil, err
@@ -98,47 +221,231 @@ func (r *companyRepo) SearchCount(ctx context.Context, params CompanySearchParam
 	return count, nil
 }
 
+// SearchFacets aggregates the current result set by industry, employee size,
+// status, funding stage, country and top technologies.
+func (r *companyRepo) SearchFacets(ctx context.Context, params CompanySearchParams) (*SearchFacets, error) {
+	facets := &SearchFacets{}
+	var err error
+
+	withoutIndustry := params
+	withoutIndustry.IndustryID = nil
+	if facets.Industries, err = r.facet(ctx, withoutIndustry, "CAST(companies.industry_id AS TEXT)", "", 0); err != nil {
+		return nil, err
+	}
+
+	withoutSize := params
+	withoutSize.EmployeeSizeID = nil
+	if facets.EmployeeSizes, err = r.facet(ctx, withoutSize, "CAST(companies.employee_size_id AS TEXT)", "", 0); err != nil {
+		return nil, err
+	}
+
+	withoutStatus := params
+	withoutStatus.Status = ""
+	if facets.Statuses, err = r.facet(ctx, withoutStatus, "companies.status", "", 0); err != nil {
+		return nil, err
+	}
+
+	withoutStage := params
+	withoutStage.FundingStage = ""
+	if facets.FundingStages, err = r.facet(ctx, withoutStage, "funding_rounds.round_type",
+		"JOIN funding_rounds ON funding_rounds.company_id = companies.id AND funding_rounds.deleted_at IS NULL", 0); err != nil {
+		return nil, err
+	}
+
+	withoutCountry := params
+	withoutCountry.CountryCode = ""
+	if facets.Countries, err = r.facet(ctx, withoutCountry, "locations.country_code",
+		"JOIN locations ON locations.company_id = companies.id AND locations.deleted_at IS NULL", 0); err != nil {
+		return nil, err
+	}
+
+	if facets.Technologies, err = r.facet(ctx, params, "technologies.technology_name",
+		"JOIN technologies ON technologies.company_id = companies.id AND technologies.deleted_at IS NULL", facetTechnologyLimit); err != nil {
+		return nil, err
+	}
+
+	withoutRevenue := params
+	withoutRevenue.RevenueMinUSD, withoutRevenue.RevenueMaxUSD = nil, nil
+	if facets.RevenueBands, err = r.facet(ctx, withoutRevenue, revenueBandCase(), "", 0); err != nil {
+		return nil, err
+	}
+
+	return facets, nil
+}
+
+// revenueBandCase is a SQL expression mapping a company's latest revenue to
+// its revenue band ID, as text.
+func revenueBandCase() string {
+	ids := make([]int, 0, len(constants.RevenueBandBounds))
+	for id := range constants.RevenueBandBounds {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	var sb strings.Builder
+	sb.WriteString("CASE")
+	for _, id := range ids {
+		bounds := constants.RevenueBandBounds[id]
+		fmt.Fprintf(&sb, " WHEN %s >= %.0f", latestRevenueUSD, bounds[0])
+		if bounds[1] > 0 {
+			fmt.Fprintf(&sb, " AND %s < %.0f", latestRevenueUSD, bounds[1])
+		}
+		fmt.Fprintf(&sb, " THEN '%d'", id)
+	}
+	sb.WriteString(" END")
+	return sb.String()
+}
+
+// facet groups the search result set by column, optionally joining a child table first.
+func (r *companyRepo) facet(ctx context.Context, params CompanySearchParams, column, join string, limit int) ([]FacetBucket, error) {
+	var buckets []FacetBucket
+	query := r.buildSearchQuery(params)
+	if join != "" {
+		query = query.Joins(join)
+	}
+	query = query.
+		Select(column + " AS value, COUNT(DISTINCT companies.id) AS count").
+		Where(column + " IS NOT NULL AND " + column + " <> ''").
+		Group(column).
+		Order("count DESC, value ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.WithContext(ctx).Scan(&buckets).Error; err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+// locationFilter returns a subquery of company IDs matching the structured
+// location filters, or nil when none are set.
+func (r *companyRepo) locationFilter(params CompanySearchParams) *gorm.DB {
+	if params.CountryCode == "" && params.RegionCode == "" && len(params.Cities) == 0 && params.Near == nil {
+		return nil
+	}
+
+	locations := r.db.Model(&models.Location{}).Select("company_id")
+	if params.CountryCode != "" {
+		locations = locations.Where("country_code = ?", params.CountryCode)
+	}
+	if params.RegionCode != "" {
+		locations = locations.Where("region_code = ?", params.RegionCode)
+	}
+	if len(params.Cities) > 0 {
+		locations = locations.Where("LOWER(city) IN ?", params.Cities)
+	}
+	if near := params.Near; near != nil {
+		// Bounding box first, then an equirectangular distance check, which is
+		// accurate to well under 1% at the radii used for territory search and
+		// only needs arithmetic SQLite supports natively.
+		minLat, maxLat, minLon, maxLon := geo.BoundingBox(near.Latitude, near.Longitude, near.RadiusKm)
+		lonScale := math.Cos(near.Latitude * math.Pi / 180)
+		radiusDeg := near.RadiusKm / 111.195
+		locations = locations.
+			Where("latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ?", minLat, maxLat, minLon, maxLon).
+			Where("(latitude - ?) * (latitude - ?) + (longitude - ?) * ? * (longitude - ?) * ? <= ?",
+				near.Latitude, near.Latitude, near.Longitude, lonScale, near.Longitude, lonScale, radiusDeg*radiusDeg)
+	}
+	return locations
+}
+
 func (r *companyRepo) buildSearchQuery(params CompanySearchParams) *gorm.DB {
 	query := r.db.Model(&models.Company{})
 
 	// Text search on name and website
 	if params.Query != "" {
-		query = query.Where("LOWER(name) LIKE ? OR LOWER(website) LIKE ?", "%"+strings.ToLower(params.Query)+"%", "%"+strings.ToLower(params.Query)+"%")
+		query = query.Where("(LOWER(companies.name) LIKE ? OR LOWER(companies.website) LIKE ?)", "%"+strings.ToLower(params.Query)+"%", "%"+strings.ToLower(params.Query)+"%")
 	}
 
 	// Industry filter
 	if params.IndustryID != nil {
-		query = query.Where("industry_id = ?", *params.IndustryID)
+		query = query.Where("companies.industry_id = ?", *params.IndustryID)
 	}
 
 	// Employee size filter
 	if params.EmployeeSizeID != nil {
-		query = query.Where("employee_size_id = ?", *params.EmployeeSizeID)
+		query = query.Where("companies.employee_size_id = ?", *params.EmployeeSizeID)
+	}
+
+	// Employee count range; companies without a count never match
+	if params.EmployeesMin != nil {
+		query = query.Where("companies.employee_count >= ?", *params.EmployeesMin)
+	}
+	if params.EmployeesMax != nil {
+		query = query.Where("companies.employee_count <= ?", *params.EmployeesMax)
 	}
 
 	// Location filter (search in HQ location)
 	if params.Location != "" {
-		query = query.Where("LOWER(hq_location) LIKE ?", "%"+strings.ToLower(params.Location)+"%")
+		query = query.Where("LOWER(companies.hq_location) LIKE ?", "%"+strings.ToLower(params.Location)+"%")
+	}
+
+	// Structured location filters, all applied to the same location row
+	if locations := r.locationFilter(params); locations != nil {
+		query = query.Where("companies.id IN (?)", locations)
 	}
 
 	// Founded year filters
 	if params.FoundedMin != nil {
-		query = query.Where("founded_year >= ?", *params.FoundedMin)
+		query = query.Where("companies.founded_year >= ?", *params.FoundedMin)
 	}
 	if params.FoundedMax != nil {
-		query = query.Where("founded_year <= ?", *params.FoundedMax)
+		query = query.Where("companies.founded_year <= ?", *params.FoundedMax)
+	}
+
+	// Revenue and funding filters on the USD-normalized amounts
+	if params.RevenueMinUSD != nil {
+		query = query.Where(latestRevenueUSD+" >= ?", *params.RevenueMinUSD)
+	}
+	if params.RevenueMaxUSD != nil {
+		query = query.Where(latestRevenueUSD+" <= ?", *params.RevenueMaxUSD)
+	}
+	if params.FundingMinUSD != nil {
+		query = query.Where(totalFundingUSD+" >= ?", *params.FundingMinUSD)
+	}
+
+	// Investor filter (subquery, like the funding stage filter below)
+	if params.InvestorID != nil || params.Investor != "" {
+		backed := r.db.Table("funding_round_investors fri").
+			Select("fr.company_id").
+			Joins("JOIN funding_rounds fr ON fr.id = fri.funding_round_id AND fr.deleted_at IS NULL")
+		if params.InvestorID != nil {
+			backed = backed.Where("fri.investor_id = ?", *params.InvestorID)
+		} else {
+			backed = backed.Where("fri.investor_id IN (?)", r.db.Model(&models.Investor{}).
+				Select("id").
+				Where("normalized_name = ? OR normalized_name LIKE ?", params.Investor, params.Investor+" %"))
+		}
+		if params.InvestorLead {
+			backed = backed.Where("fri.lead = ?", true)
+		}
+		query = query.Where("companies.id IN (?)", backed)
+	}
+
+	// Technology category filter
+	if params.TechCategory != "" {
+		query = query.Where("companies.id IN (?)", r.db.Model(&models.Technology{}).
+			Select("company_id").
+			Where("category = ?", params.TechCategory))
 	}
 
 	// Status filter
 	if params.Status != "" {
-		query = query.Where("status = ?", params.Status)
+		query = query.Where("companies.status = ?", params.Status)
 	}
 
-	// Funding stage filter (requires join with funding_rounds table)
+	// Funding stage filter (subquery keeps one row per company, so counts and facets stay exact)
 	if params.FundingStage != "" {
-		query = query.Joins("LEFT JOIN funding_rounds ON funding_rounds.company_id = companies.id").
-			Where("funding_rounds.round_type = ?", params.FundingStage).
-			Distinct()
+		query = query.Where("companies.id IN (?)", r.db.Model(&models.FundingRound{}).
+			Select("company_id").
+			Where("round_type = ?", params.FundingStage))
+	}
+
+	// Advanced query language expression
+	if params.Expression != nil {
+		condition, args := querylang.Compile(params.Expression)
+		query = query.Where(condition, args...)
 	}
 
 	return query
@@ -0,0 +1,390 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+
+	models "github.com/bhati00/Fynelo/backend/internal/company/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Child tables whose rows are re-pointed to the surviving company on merge.
+// Technologies are merged by mergeTechnologies so the survivor does not end
+// up listing the same technology twice.
+var mergeableChildTables = []struct {
+	table  string
+	entity models.EntityType
+}{
+	{"revenues", models.EntityRevenue},
+	{"funding_rounds", models.EntityFundingRound},
+	{"locations", models.EntityLocation},
+	{"contacts", models.EntityContact},
+}
+
+// Tables linking a company to a list, saved search or ICP at most once, by
+// the scope column. On merge their rows move to the survivor, except where
+// the survivor is already linked in the same scope.
+var mergeableLinkTables = []struct {
+	table string
+	scope string
+}{
+	{"prospect_list_members", "list_id"},
+	{"saved_search_matches", "saved_search_id"},
+	{"icp_matches", "icp_id"},
+}
+
+// Logs about a company that move to the survivor as they are.
+var mergeableLogTables = []string{"prospect_list_status_changes", "prospect_list_additions"}
+
+type DuplicateRepository interface {
+	// scanning
+	ListForScan(ctx context.Context) ([]models.Company, error)
+	SaveCandidates(ctx context.Context, candidates []models.DuplicateCandidate) error
+
+	// candidates
+	ListCandidates(ctx context.Context, status models.DuplicateStatus, limit, offset int) ([]models.DuplicateCandidate, error)
+	FindCandidate(ctx context.Context, id uint) (*models.DuplicateCandidate, error)
+	UpdateCandidateStatus(ctx context.Context, id uint, status models.DuplicateStatus) error
+
+	// merging
+	Merge(ctx context.Context, survivorID uint, mergedIDs []uint, mergedBy string) ([]models.CompanyMerge, error)
+	FindMergeByMergedID(ctx context.Context, mergedID uint) (*models.CompanyMerge, error)
+}
+
+type duplicateRepo struct {
+	db *gorm.DB
+}
+
+func NewDuplicateRepository(db *gorm.DB) DuplicateRepository {
+	return &duplicateRepo{db: db}
+}
+
+// ListForScan loads every company with its locations for duplicate comparison.
+func (r *duplicateRepo) ListForScan(ctx context.Context) ([]models.Company, error) {
+	var companies []models.Company
+	if err := r.db.WithContext(ctx).Preload("Locations").Order("id ASC").Find(&companies).Error; err != nil {
+		return nil, err
+	}
+	return companies, nil
+}
+
+// SaveCandidates upserts candidate pairs. Scores and clusters are refreshed,
+// but reviewer decisions (dismissed/merged) are kept.
+func (r *duplicateRepo) SaveCandidates(ctx context.Context, candidates []models.DuplicateCandidate) error {
+	if len(candidates) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "company_id"}, {Name: "duplicate_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"cluster_id", "score", "reasons", "updated_at"}),
+	}).CreateInBatches(&candidates, 200).Error
+}
+
+func (r *duplicateRepo) ListCandidates(ctx context.Context, status models.DuplicateStatus, limit, offset int) ([]models.DuplicateCandidate, error) {
+	var candidates []models.DuplicateCandidate
+	tx := r.db.WithContext(ctx).
+		Preload("Company").
+		Preload("Duplicate").
+		Order("score DESC, id ASC").
+		Limit(limit).
+		Offset(offset)
+	if status != "" {
+		tx = tx.Where("status = ?", status)
+	}
+	if err := tx.Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+func (r *duplicateRepo) FindCandidate(ctx context.Context, id uint) (*models.DuplicateCandidate, error) {
+	var candidate models.DuplicateCandidate
+	if err := r.db.WithContext(ctx).First(&candidate, id).Error; err != nil {
+		return nil, err
+	}
+	return &candidate, nil
+}
+
+func (r *duplicateRepo) UpdateCandidateStatus(ctx context.Context, id uint, status models.DuplicateStatus) error {
+	return r.db.WithContext(ctx).Model(&models.DuplicateCandidate{}).Where("id = ?", id).Update("status", status).Error
+}
+
+func (r *duplicateRepo) FindMergeByMergedID(ctx context.Context, mergedID uint) (*models.CompanyMerge, error) {
+	var merge models.CompanyMerge
+	if err := r.db.WithContext(ctx).Where("merged_id = ?", mergedID).First(&merge).Error; err != nil {
+		return nil, err
+	}
+	return &merge, nil
+}
+
+// Merge folds mergedIDs into survivorID in a single transaction: child rows
+// move to the survivor, missing survivor attributes are filled in, merged
+// companies are soft-deleted and an audit row is written for each of them.
+func (r *duplicateRepo) Merge(ctx context.Context, survivorID uint, mergedIDs []uint, mergedBy string) ([]models.CompanyMerge, error) {
+	var merges []models.CompanyMerge
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var survivor models.Company
+		if err := tx.First(&survivor, survivorID).Error; err != nil {
+			return err
+		}
+		before := survivor
+
+		for _, mergedID := range mergedIDs {
+			if mergedID == survivorID {
+				return errors.New("a company cannot be merged into itself")
+			}
+			var merged models.Company
+			if err := tx.First(&merged, mergedID).Error; err != nil {
+				return err
+			}
+
+			snapshot, err := json.Marshal(merged)
+			if err != nil {
+				return err
+			}
+
+			fillMissing(&survivor, &merged)
+
+			for _, child := range mergeableChildTables {
+				if err := moveChildRows(tx, child.table, child.entity, survivorID, mergedID); err != nil {
+					return err
+				}
+			}
+			if err := mergeTechnologies(tx, survivorID, mergedID); err != nil {
+				return err
+			}
+			for _, link := range mergeableLinkTables {
+				if err := moveLinkRows(tx, link.table, link.scope, survivorID, mergedID); err != nil {
+					return err
+				}
+			}
+			for _, table := range mergeableLogTables {
+				if !tx.Migrator().HasTable(table) {
+					continue
+				}
+				if err := tx.Table(table).Where("company_id = ?", mergedID).Update("company_id", survivorID).Error; err != nil {
+					return err
+				}
+			}
+
+			if err := tx.Delete(&merged).Error; err != nil {
+				return err
+			}
+			if err := recordDelete(tx, mergedID, models.EntityCompany, mergedID, &merged); err != nil {
+				return err
+			}
+
+			// companies previously merged into this one now redirect to the survivor
+			if err := tx.Model(&models.CompanyMerge{}).Where("survivor_id = ?", mergedID).
+				Update("survivor_id", survivorID).Error; err != nil {
+				return err
+			}
+
+			merge := models.CompanyMerge{
+				MergedID:   mergedID,
+				SurvivorID: survivorID,
+				MergedName: merged.Name,
+				Snapshot:   string(snapshot),
+				MergedBy:   mergedBy,
+			}
+			if err := tx.Create(&merge).Error; err != nil {
+				return err
+			}
+			merges = append(merges, merge)
+
+			if err := tx.Model(&models.DuplicateCandidate{}).
+				Where("company_id = ? OR duplicate_id = ?", mergedID, mergedID).
+				Update("status", models.DuplicateMerged).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Omit(clause.Associations).Save(&survivor).Error; err != nil {
+			return err
+		}
+		return recordUpdate(tx, survivorID, models.EntityCompany, survivorID, &before, &survivor)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return merges, nil
+}
+
+// moveChildRows re-points a merged company's rows in table to the survivor,
+// recording the move in the survivor's history.
+func moveChildRows(tx *gorm.DB, table string, entity models.EntityType, survivorID, mergedID uint) error {
+	var ids []uint
+	if err := tx.Table(table).Where("company_id = ? AND deleted_at IS NULL", mergedID).Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := tx.Table(table).Where("id IN ?", ids).Update("company_id", survivorID).Error; err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := recordUpdate(tx, survivorID, entity, id,
+			map[string]uint{"company_id": mergedID}, map[string]uint{"company_id": survivorID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveLinkRows re-points a merged company's rows in a link table to the
+// survivor. Where both are linked in the same scope the survivor's row is
+// kept; list members keep the notes and tags of both.
+func moveLinkRows(tx *gorm.DB, table, scope string, survivorID, mergedID uint) error {
+	if !tx.Migrator().HasTable(table) {
+		return nil // the feature owning the table is not migrated
+	}
+	var taken []uint
+	if err := tx.Table(table).Where("company_id = ?", survivorID).Pluck(scope, &taken).Error; err != nil {
+		return err
+	}
+	if len(taken) > 0 {
+		if table == "prospect_list_members" {
+			if err := foldListMembers(tx, survivorID, mergedID, taken); err != nil {
+				return err
+			}
+		}
+		if err := tx.Table(table).Where("company_id = ? AND "+scope+" IN ?", mergedID, taken).
+			Delete(nil).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Table(table).Where("company_id = ?", mergedID).Update("company_id", survivorID).Error
+}
+
+// listMemberNotes is the part of a list member folded into the survivor's.
+type listMemberNotes struct {
+	ListID uint
+	Notes  string
+	Tags   string // JSON array
+}
+
+// foldListMembers adds the notes and tags of the merged company's list
+// members to the survivor's members of the same lists.
+func foldListMembers(tx *gorm.DB, survivorID, mergedID uint, listIDs []uint) error {
+	var merged []listMemberNotes
+	if err := tx.Table("prospect_list_members").Select("list_id, notes, tags").
+		Where("company_id = ? AND list_id IN ?", mergedID, listIDs).Scan(&merged).Error; err != nil {
+		return err
+	}
+	for _, m := range merged {
+		var kept listMemberNotes
+		if err := tx.Table("prospect_list_members").Select("list_id, notes, tags").
+			Where("company_id = ? AND list_id = ?", survivorID, m.ListID).Take(&kept).Error; err != nil {
+			return err
+		}
+		notes := kept.Notes
+		if extra := strings.TrimSpace(m.Notes); extra != "" && !strings.Contains(notes, extra) {
+			if notes != "" {
+				notes += "\n\n"
+			}
+			notes += extra
+		}
+		tags, err := unionTags(kept.Tags, m.Tags)
+		if err != nil {
+			return err
+		}
+		if err := tx.Table("prospect_list_members").Where("company_id = ? AND list_id = ?", survivorID, m.ListID).
+			Updates(map[string]interface{}{"notes": notes, "tags": tags}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unionTags merges two JSON tag arrays, sorted as lists store them.
+func unionTags(a, b string) (string, error) {
+	seen := map[string]struct{}{}
+	for _, raw := range []string{a, b} {
+		if raw == "" || raw == "null" {
+			continue
+		}
+		var tags []string
+		if err := json.Unmarshal([]byte(raw), &tags); err != nil {
+			return "", err
+		}
+		for _, t := range tags {
+			seen[t] = struct{}{}
+		}
+	}
+	tags := make([]string, 0, len(seen))
+	for t := range seen {
+		tags = append(tags, t)
+	}
+	sort.Strings(tags)
+	out, err := json.Marshal(tags)
+	return string(out), err
+}
+
+// mergeTechnologies moves technologies to the survivor, dropping names the
+// survivor already has.
+func mergeTechnologies(tx *gorm.DB, survivorID, mergedID uint) error {
+	var existing []string
+	if err := tx.Model(&models.Technology{}).Where("company_id = ?", survivorID).
+		Pluck("technology_name", &existing).Error; err != nil {
+		return err
+	}
+	have := make(map[string]struct{}, len(existing))
+	for _, name := range existing {
+		have[strings.ToLower(name)] = struct{}{}
+	}
+
+	var techs []models.Technology
+	if err := tx.Where("company_id = ?", mergedID).Find(&techs).Error; err != nil {
+		return err
+	}
+	for _, t := range techs {
+		if _, dup := have[strings.ToLower(t.TechnologyName)]; dup {
+			if err := deleteTechnologies(tx, []models.Technology{t}); err != nil {
+				return err
+			}
+			continue
+		}
+		have[strings.ToLower(t.TechnologyName)] = struct{}{}
+		if err := tx.Model(&t).Update("company_id", survivorID).Error; err != nil {
+			return err
+		}
+		if err := recordUpdate(tx, survivorID, models.EntityTechnology, t.ID,
+			map[string]uint{"company_id": mergedID}, map[string]uint{"company_id": survivorID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fillMissing copies attributes the survivor lacks from the merged company.
+func fillMissing(survivor, merged *models.Company) {
+	if survivor.Website == nil {
+		survivor.Website = merged.Website
+	}
+	if survivor.HQLocation == nil {
+		survivor.HQLocation = merged.HQLocation
+	}
+	if survivor.IndustryID == nil {
+		survivor.IndustryID = merged.IndustryID
+	}
+	if survivor.EmployeeSizeID == nil {
+		survivor.EmployeeSizeID = merged.EmployeeSizeID
+	}
+	if survivor.EmployeeCount == nil {
+		survivor.EmployeeCount = merged.EmployeeCount
+		survivor.EmployeeCountEstimated = merged.EmployeeCountEstimated
+		survivor.EmployeeCountAt = merged.EmployeeCountAt
+	}
+	if survivor.FoundedYear == nil {
+		survivor.FoundedYear = merged.FoundedYear
+	}
+	if merged.LastEnrichedAt != nil && (survivor.LastEnrichedAt == nil || merged.LastEnrichedAt.After(*survivor.LastEnrichedAt)) {
+		survivor.LastEnrichedAt = merged.LastEnrichedAt
+	}
+}
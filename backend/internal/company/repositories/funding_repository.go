@@ -22,7 +22,15 @@ func NewFundingRepository(db *gorm.DB) FundingRepository {
 }
 
 func (r *fundingRepo) Create(ctx context.Context, FundingRound *models.FundingRound) error {
-	return r.db.WithContext(ctx).Create(FundingRound).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(FundingRound).Error; err != nil {
+			return err
+		}
+		if err := LinkRoundInvestors(tx, FundingRound); err != nil {
+			return err
+		}
+		return recordCreate(tx, FundingRound.CompanyID, models.EntityFundingRound, FundingRound.ID, FundingRound)
+	})
 }
 
 func (r *fundingRepo) FindByCompanyID(ctx context.Context, companyID uint) ([]models.FundingRound, error) {
@@ -0,0 +1,249 @@
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	models "github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/pkg/actor"
+
+	"gorm.io/gorm"
+)
+
+// Actor recorded for changes made without one on the context.
+const systemActor = "system"
+
+// Fields that change on every write or describe relations rather than data.
+var untrackedFields = map[string]struct{}{
+	"id": {}, "created_at": {}, "updated_at": {}, "company": {},
+	"revenues": {}, "funding_rounds": {}, "technologies": {}, "locations": {},
+}
+
+// HistoryFilter narrows a company's change history.
+type HistoryFilter struct {
+	EntityType models.EntityType
+	Field      string
+	Since      *time.Time
+	Until      *time.Time
+}
+
+// CompanyRows is a company and its child rows including soft-deleted ones,
+// which point-in-time snapshots may need to bring back.
+type CompanyRows struct {
+	Company        models.Company
+	CompanyDeleted bool
+	Revenues       []models.Revenue
+	FundingRounds  []models.FundingRound
+	Technologies   []models.Technology
+	Locations      []models.Location
+	Deleted        map[models.EntityType]map[uint]bool
+}
+
+type HistoryRepository interface {
+	List(ctx context.Context, companyID uint, filter HistoryFilter, limit, offset int) ([]models.CompanyChange, error)
+	// ListAfter returns changes made after t, newest first.
+	ListAfter(ctx context.Context, companyID uint, t time.Time) ([]models.CompanyChange, error)
+	LoadCompanyRows(ctx context.Context, companyID uint) (*CompanyRows, error)
+}
+
+type historyRepo struct {
+	db *gorm.DB
+}
+
+func NewHistoryRepository(db *gorm.DB) HistoryRepository {
+	return &historyRepo{db: db}
+}
+
+func (r *historyRepo) List(ctx context.Context, companyID uint, filter HistoryFilter, limit, offset int) ([]models.CompanyChange, error) {
+	var changes []models.CompanyChange
+	tx := r.db.WithContext(ctx).
+		Where("company_id = ?", companyID).
+		Order("changed_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset)
+	if filter.EntityType != "" {
+		tx = tx.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.Field != "" {
+		tx = tx.Where("field = ?", filter.Field)
+	}
+	if filter.Since != nil {
+		tx = tx.Where("changed_at >= ?", *filter.Since)
+	}
+	if filter.Until != nil {
+		tx = tx.Where("changed_at <= ?", *filter.Until)
+	}
+	if err := tx.Find(&changes).Error; err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (r *historyRepo) ListAfter(ctx context.Context, companyID uint, t time.Time) ([]models.CompanyChange, error) {
+	var changes []models.CompanyChange
+	if err := r.db.WithContext(ctx).
+		Where("company_id = ? AND changed_at > ?", companyID, t).
+		Order("changed_at DESC, id DESC").
+		Find(&changes).Error; err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (r *historyRepo) LoadCompanyRows(ctx context.Context, companyID uint) (*CompanyRows, error) {
+	// soft-deleted rows are included; each query starts from a fresh session
+	db := r.db.WithContext(ctx).Unscoped().Session(&gorm.Session{})
+	rows := &CompanyRows{Deleted: map[models.EntityType]map[uint]bool{
+		models.EntityRevenue:      {},
+		models.EntityFundingRound: {},
+		models.EntityTechnology:   {},
+		models.EntityLocation:     {},
+	}}
+
+	if err := db.First(&rows.Company, companyID).Error; err != nil {
+		return nil, err
+	}
+	rows.CompanyDeleted = rows.Company.DeletedAt.Valid
+
+	if err := db.Where("company_id = ?", companyID).Find(&rows.Revenues).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows.Revenues {
+		rows.Deleted[models.EntityRevenue][row.ID] = row.DeletedAt.Valid
+	}
+	if err := db.Where("company_id = ?", companyID).Find(&rows.FundingRounds).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows.FundingRounds {
+		rows.Deleted[models.EntityFundingRound][row.ID] = row.DeletedAt.Valid
+	}
+	if err := db.Where("company_id = ?", companyID).Find(&rows.Technologies).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows.Technologies {
+		rows.Deleted[models.EntityTechnology][row.ID] = row.DeletedAt.Valid
+	}
+	if err := db.Where("company_id = ?", companyID).Find(&rows.Locations).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows.Locations {
+		rows.Deleted[models.EntityLocation][row.ID] = row.DeletedAt.Valid
+	}
+	return rows, nil
+}
+
+// recordCreate stores the creation of a row.
+func recordCreate(tx *gorm.DB, companyID uint, entity models.EntityType, entityID uint, row interface{}) error {
+	value, err := encodeRow(row)
+	if err != nil {
+		return err
+	}
+	return saveChanges(tx, []models.CompanyChange{{
+		CompanyID:  companyID,
+		EntityType: entity,
+		EntityID:   entityID,
+		Operation:  models.ChangeCreate,
+		Field:      models.WholeRow,
+		NewValue:   &value,
+	}})
+}
+
+// recordDelete stores the deletion of a row together with its last values.
+func recordDelete(tx *gorm.DB, companyID uint, entity models.EntityType, entityID uint, row interface{}) error {
+	value, err := encodeRow(row)
+	if err != nil {
+		return err
+	}
+	return saveChanges(tx, []models.CompanyChange{{
+		CompanyID:  companyID,
+		EntityType: entity,
+		EntityID:   entityID,
+		Operation:  models.ChangeDelete,
+		Field:      models.WholeRow,
+		OldValue:   &value,
+	}})
+}
+
+// recordUpdate stores one change per field that differs between before and after.
+func recordUpdate(tx *gorm.DB, companyID uint, entity models.EntityType, entityID uint, before, after interface{}) error {
+	old, err := trackedFields(before)
+	if err != nil {
+		return err
+	}
+	updated, err := trackedFields(after)
+	if err != nil {
+		return err
+	}
+
+	fields := make([]string, 0, len(updated))
+	for field := range updated {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var changes []models.CompanyChange
+	for _, field := range fields {
+		if bytes.Equal(old[field], updated[field]) {
+			continue
+		}
+		change := models.CompanyChange{
+			CompanyID:  companyID,
+			EntityType: entity,
+			EntityID:   entityID,
+			Operation:  models.ChangeUpdate,
+			Field:      field,
+		}
+		if v, ok := old[field]; ok {
+			s := string(v)
+			change.OldValue = &s
+		}
+		s := string(updated[field])
+		change.NewValue = &s
+		changes = append(changes, change)
+	}
+	return saveChanges(tx, changes)
+}
+
+func saveChanges(tx *gorm.DB, changes []models.CompanyChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	name := actor.FromContext(tx.Statement.Context)
+	if name == "" {
+		name = systemActor
+	}
+	now := time.Now()
+	for i := range changes {
+		changes[i].Actor = name
+		changes[i].ChangedAt = now
+	}
+	return tx.Session(&gorm.Session{NewDB: true}).Create(&changes).Error
+}
+
+// trackedFields returns the JSON fields of a row that history records.
+func trackedFields(row interface{}) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(row)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for field := range untrackedFields {
+		delete(fields, field)
+	}
+	return fields, nil
+}
+
+func encodeRow(row interface{}) (string, error) {
+	fields, err := trackedFields(row)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(fields)
+	return string(data), err
+}
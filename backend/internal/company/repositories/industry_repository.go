@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+
+	models "github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/constants"
+
+	"gorm.io/gorm"
+)
+
+type IndustryRepository interface {
+	// ListUnmapped returns unmapped values, most reported first, and their
+	// total. An empty source matches every source.
+	ListUnmapped(ctx context.Context, source string, limit, offset int) ([]models.UnmappedIndustry, int64, error)
+	AllUnmapped(ctx context.Context) ([]models.UnmappedIndustry, error)
+	DeleteUnmapped(ctx context.Context, ids []uint) (int64, error)
+	// Unclassified returns companies without an industry, or filed under
+	// IndustryOther, that have a reported industry code or text.
+	Unclassified(ctx context.Context) ([]models.Company, error)
+}
+
+type industryRepo struct {
+	db *gorm.DB
+}
+
+func NewIndustryRepository(db *gorm.DB) IndustryRepository {
+	return &industryRepo{db: db}
+}
+
+func (r *industryRepo) ListUnmapped(ctx context.Context, source string, limit, offset int) ([]models.UnmappedIndustry, int64, error) {
+	tx := r.db.WithContext(ctx).Model(&models.UnmappedIndustry{})
+	if source != "" {
+		tx = tx.Where("source = ?", source)
+	}
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var list []models.UnmappedIndustry
+	if err := tx.Order("occurrences DESC, last_seen_at DESC, id ASC").Limit(limit).Offset(offset).Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+func (r *industryRepo) AllUnmapped(ctx context.Context) ([]models.UnmappedIndustry, error) {
+	var list []models.UnmappedIndustry
+	if err := r.db.WithContext(ctx).Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *industryRepo) DeleteUnmapped(ctx context.Context, ids []uint) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&models.UnmappedIndustry{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *industryRepo) Unclassified(ctx context.Context) ([]models.Company, error) {
+	var companies []models.Company
+	if err := r.db.WithContext(ctx).
+		Where("industry_id IS NULL OR industry_id = ?", constants.IndustryOther).
+		Where("naics_code IS NOT NULL OR sic_code IS NOT NULL OR industry_text IS NOT NULL").
+		Order("id ASC").
+		Find(&companies).Error; err != nil {
+		return nil, err
+	}
+	return companies, nil
+}
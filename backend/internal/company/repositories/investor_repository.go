@@ -0,0 +1,196 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	models "github.com/bhati00/Fynelo/backend/internal/company/model"
+
+	"gorm.io/gorm"
+)
+
+// InvestorSummary is an investor with the size of its portfolio.
+type InvestorSummary struct {
+	ID         uint    `json:"id"`
+	Name       string  `json:"name"`
+	Website    *string `json:"website"`
+	Companies  int64   `json:"companies"`   // portfolio companies
+	Rounds     int64   `json:"rounds"`      // rounds taken part in
+	LeadRounds int64   `json:"lead_rounds"` // rounds led
+}
+
+// PortfolioRound is a round an investor took part in.
+type PortfolioRound struct {
+	CompanyID      uint                    `json:"company_id"`
+	FundingRoundID uint                    `json:"funding_round_id"`
+	RoundType      models.FundingRoundType `json:"round_type" swaggertype:"string"`
+	Date           *time.Time              `json:"date"`
+	Amount         *float64                `json:"amount"`
+	Currency       string                  `json:"currency"`
+	AmountUSD      *float64                `json:"amount_usd"`
+	Lead           bool                    `json:"lead"`
+}
+
+// Most characters stored of an investor name
+const maxInvestorName = 200
+
+type InvestorRepository interface {
+	// Search returns investors whose name contains query, largest portfolio
+	// first, and their total. An empty query matches every investor.
+	Search(ctx context.Context, query string, limit, offset int) ([]InvestorSummary, int64, error)
+	// Autocomplete returns investors with a word of their name starting with
+	// prefix, those whose name starts with it first.
+	Autocomplete(ctx context.Context, prefix string, limit int) ([]models.Investor, error)
+	FindByID(ctx context.Context, id uint) (*models.Investor, error)
+	// Portfolio returns the rounds of existing companies an investor took
+	// part in, newest first, and those companies.
+	Portfolio(ctx context.Context, investorID uint) ([]PortfolioRound, []models.Company, error)
+}
+
+type investorRepo struct {
+	db *gorm.DB
+}
+
+func NewInvestorRepository(db *gorm.DB) InvestorRepository {
+	return &investorRepo{db: db}
+}
+
+// investorRounds joins investors to the live rounds of live companies.
+const investorRounds = "LEFT JOIN funding_round_investors fri ON fri.investor_id = investors.id " +
+	"LEFT JOIN funding_rounds fr ON fr.id = fri.funding_round_id AND fr.deleted_at IS NULL " +
+	"AND fr.company_id IN (SELECT id FROM companies WHERE deleted_at IS NULL)"
+
+func (r *investorRepo) Search(ctx context.Context, query string, limit, offset int) ([]InvestorSummary, int64, error) {
+	tx := r.db.WithContext(ctx).Model(&models.Investor{})
+	if name := models.NormalizeInvestorName(query); name != "" {
+		tx = tx.Where("investors.normalized_name LIKE ?", "%"+name+"%")
+	}
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var list []InvestorSummary
+	if err := tx.
+		Select("investors.id, investors.name, investors.website, COUNT(DISTINCT fr.company_id) AS companies, COUNT(DISTINCT fr.id) AS rounds, " +
+			"COUNT(DISTINCT CASE WHEN fri.lead THEN fr.id END) AS lead_rounds").
+		Joins(investorRounds).
+		Group("investors.id").
+		Order("companies DESC, investors.name ASC, investors.id ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&list).Error; err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+func (r *investorRepo) Autocomplete(ctx context.Context, prefix string, limit int) ([]models.Investor, error) {
+	list := []models.Investor{}
+	name := models.NormalizeInvestorName(prefix)
+	if name == "" {
+		return list, nil
+	}
+	// normalized names hold no LIKE wildcards
+	if err := r.db.WithContext(ctx).
+		Where("normalized_name LIKE ? OR normalized_name LIKE ?", name+"%", "% "+name+"%").
+		Order(gorm.Expr("CASE WHEN normalized_name LIKE ? THEN 0 ELSE 1 END, name ASC", name+"%")).
+		Limit(limit).
+		Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *investorRepo) FindByID(ctx context.Context, id uint) (*models.Investor, error) {
+	var investor models.Investor
+	if err := r.db.WithContext(ctx).First(&investor, id).Error; err != nil {
+		return nil, err
+	}
+	return &investor, nil
+}
+
+func (r *investorRepo) Portfolio(ctx context.Context, investorID uint) ([]PortfolioRound, []models.Company, error) {
+	var rounds []PortfolioRound
+	if err := r.db.WithContext(ctx).
+		Table("funding_round_investors fri").
+		Select("fr.company_id, fr.id AS funding_round_id, fr.round_type, fr.date, fr.amount, fr.currency, fr.amount_usd, fri.lead").
+		Joins("JOIN funding_rounds fr ON fr.id = fri.funding_round_id AND fr.deleted_at IS NULL").
+		Joins("JOIN companies c ON c.id = fr.company_id AND c.deleted_at IS NULL").
+		Where("fri.investor_id = ?", investorID).
+		Order("fr.date IS NULL, fr.date DESC, fr.id DESC").
+		Scan(&rounds).Error; err != nil {
+		return nil, nil, err
+	}
+
+	companies := []models.Company{}
+	if len(rounds) == 0 {
+		return rounds, companies, nil
+	}
+	ids := make([]uint, 0, len(rounds))
+	for _, round := range rounds {
+		ids = append(ids, round.CompanyID)
+	}
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&companies).Error; err != nil {
+		return nil, nil, err
+	}
+	return rounds, companies, nil
+}
+
+// LinkRoundInvestors brings the investors linked to a saved round in line
+// with those named in its investors text, creating investors not seen
+// before. Links that still apply keep their creation time.
+func LinkRoundInvestors(tx *gorm.DB, round *models.FundingRound) error {
+	var existing []models.FundingRoundInvestor
+	if err := tx.Where("funding_round_id = ?", round.ID).Find(&existing).Error; err != nil {
+		return err
+	}
+	stale := make(map[uint]models.FundingRoundInvestor, len(existing))
+	for _, link := range existing {
+		stale[link.InvestorID] = link
+	}
+
+	for _, mention := range models.ParseInvestors(round.Investors) {
+		investor, err := findOrCreateInvestor(tx, mention.Name)
+		if err != nil {
+			return err
+		}
+		link, linked := stale[investor.ID]
+		delete(stale, investor.ID)
+		switch {
+		case !linked:
+			link = models.FundingRoundInvestor{FundingRoundID: round.ID, InvestorID: investor.ID, Lead: mention.Lead}
+			if err := tx.Create(&link).Error; err != nil {
+				return err
+			}
+		case link.Lead != mention.Lead:
+			if err := tx.Model(&models.FundingRoundInvestor{}).
+				Where("funding_round_id = ? AND investor_id = ?", round.ID, investor.ID).
+				Update("lead", mention.Lead).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	for investorID := range stale {
+		if err := tx.Where("funding_round_id = ? AND investor_id = ?", round.ID, investorID).
+			Delete(&models.FundingRoundInvestor{}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func findOrCreateInvestor(tx *gorm.DB, name string) (*models.Investor, error) {
+	if runes := []rune(name); len(runes) > maxInvestorName {
+		name = string(runes[:maxInvestorName])
+	}
+	normalized := models.NormalizeInvestorName(name)
+
+	investor := models.Investor{Name: name, NormalizedName: normalized}
+	if err := tx.Where("normalized_name = ?", normalized).FirstOrCreate(&investor).Error; err != nil {
+		return nil, err
+	}
+	return &investor, nil
+}
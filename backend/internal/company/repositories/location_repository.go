@@ -21,7 +21,12 @@ func NewLocationRepository(db *gorm.DB) LocationRepository {
 }
 
 func (r *locationRepo) Create(ctx context.Context, location *models.Location) error {
-	return r.db.WithContext(ctx).Create(location).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(location).Error; err != nil {
+			return err
+		}
+		return recordCreate(tx, location.CompanyID, models.EntityLocation, location.ID, location)
+	})
 }
 
 func (r *locationRepo) FindByCompanyID(ctx context.Context, companyID uint) ([]models.Location, error) {
@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"context"
+
+	models "github.com/bhati00/Fynelo/backend/internal/company/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EnrichmentWrite is everything an enrichment run changes, persisted atomically.
+type EnrichmentWrite struct {
+	Company       *models.Company
+	Revenues      []models.Revenue      // new rows, or existing rows with an ID to update
+	FundingRounds []models.FundingRound // new rows, or existing rows with an ID to update
+	Technologies  []models.Technology   // new rows only
+	Locations     []models.Location     // new rows, or existing rows with an ID to update
+	Observations  []models.FieldProvenance
+	Superseded    []uint                    // IDs of previously accepted observations that lost to a new one
+	Unmapped      []models.UnmappedIndustry // industry codes and text the classifier could not map
+}
+
+type ProvenanceRepository interface {
+	ListByCompany(ctx context.Context, companyID uint, entityType models.EntityType, field string) ([]models.FieldProvenance, error)
+	ListAccepted(ctx context.Context, companyID uint) ([]models.FieldProvenance, error)
+	SaveEnrichment(ctx context.Context, w EnrichmentWrite) error
+}
+
+type provenanceRepo struct {
+	db *gorm.DB
+}
+
+func NewProvenanceRepository(db *gorm.DB) ProvenanceRepository {
+	return &provenanceRepo{db: db}
+}
+
+// ListByCompany returns observations for a company, accepted ones first and
+// then newest first. Empty entityType or field match everything.
+func (r *provenanceRepo) ListByCompany(ctx context.Context, companyID uint, entityType models.EntityType, field string) ([]models.FieldProvenance, error) {
+	var list []models.FieldProvenance
+	tx := r.db.WithContext(ctx).
+		Where("company_id = ?", companyID).
+		Order("entity_type ASC, entity_key ASC, field ASC, accepted DESC, observed_at DESC")
+	if entityType != "" {
+		tx = tx.Where("entity_type = ?", entityType)
+	}
+	if field != "" {
+		tx = tx.Where("field = ?", field)
+	}
+	if err := tx.Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *provenanceRepo) ListAccepted(ctx context.Context, companyID uint) ([]models.FieldProvenance, error) {
+	var list []models.FieldProvenance
+	if err := r.db.WithContext(ctx).
+		Where("company_id = ? AND accepted = ?", companyID, true).
+		Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *provenanceRepo) SaveEnrichment(ctx context.Context, w EnrichmentWrite) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := saveTracked(tx, w.Company.ID, models.EntityCompany, w.Company, &models.Company{}, func() uint { return w.Company.ID }); err != nil {
+			return err
+		}
+		for i := range w.Revenues {
+			row := &w.Revenues[i]
+			row.CompanyID = w.Company.ID
+			if err := saveTracked(tx, w.Company.ID, models.EntityRevenue, row, &models.Revenue{}, func() uint { return row.ID }); err != nil {
+				return err
+			}
+		}
+		for i := range w.FundingRounds {
+			row := &w.FundingRounds[i]
+			row.CompanyID = w.Company.ID
+			if err := saveTracked(tx, w.Company.ID, models.EntityFundingRound, row, &models.FundingRound{}, func() uint { return row.ID }); err != nil {
+				return err
+			}
+			if err := LinkRoundInvestors(tx, row); err != nil {
+				return err
+			}
+		}
+		for i := range w.Technologies {
+			row := &w.Technologies[i]
+			row.CompanyID = w.Company.ID
+			if err := saveTracked(tx, w.Company.ID, models.EntityTechnology, row, &models.Technology{}, func() uint { return row.ID }); err != nil {
+				return err
+			}
+		}
+		for i := range w.Locations {
+			row := &w.Locations[i]
+			row.CompanyID = w.Company.ID
+			if err := saveTracked(tx, w.Company.ID, models.EntityLocation, row, &models.Location{}, func() uint { return row.ID }); err != nil {
+				return err
+			}
+		}
+		if len(w.Superseded) > 0 {
+			if err := tx.Model(&models.FieldProvenance{}).Where("id IN ?", w.Superseded).
+				Update("accepted", false).Error; err != nil {
+				return err
+			}
+		}
+		for i := range w.Observations {
+			w.Observations[i].CompanyID = w.Company.ID
+		}
+		if len(w.Observations) > 0 {
+			if err := tx.CreateInBatches(&w.Observations, 200).Error; err != nil {
+				return err
+			}
+		}
+		for i := range w.Unmapped {
+			row := &w.Unmapped[i]
+			row.CompanyID = w.Company.ID
+			if err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "source"}, {Name: "value"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"occurrences":  gorm.Expr("unmapped_industries.occurrences + 1"),
+					"company_id":   row.CompanyID,
+					"last_seen_at": row.LastSeenAt,
+				}),
+			}).Create(row).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// saveTracked creates or updates row and records the change in the company
+// history. before receives the stored row when row already exists; id reads
+// the row ID after a create.
+func saveTracked(tx *gorm.DB, companyID uint, entity models.EntityType, row, before interface{}, id func() uint) error {
+	if existingID := id(); existingID != 0 {
+		if err := tx.First(before, existingID).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Save(row).Error; err != nil {
+			return err
+		}
+		return recordUpdate(tx, companyID, entity, existingID, before, row)
+	}
+	if err := tx.Omit(clause.Associations).Create(row).Error; err != nil {
+		return err
+	}
+	if companyID == 0 {
+		companyID = id() // the company itself was just created
+	}
+	return recordCreate(tx, companyID, entity, id(), row)
+}
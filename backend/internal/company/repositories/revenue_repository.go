@@ -22,7 +22,12 @@ func NewRevenueRepository(db *gorm.DB) RevenueRepository {
 }
 
 func (r *revenueRepo) Create(ctx context.Context, revenue *model.Revenue) error {
-	return r.db.WithContext(ctx).Create(revenue).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(revenue).Error; err != nil {
+			return err
+		}
+		return recordCreate(tx, revenue.CompanyID, model.EntityRevenue, revenue.ID, revenue)
+	})
 }
 
 func (r *revenueRepo) FindByCompanyID(ctx context.Context, companyID uint) ([]model.Revenue, error) {
@@ -3,8 +3,10 @@ package repositories
 
 import (
 	"context"
+	"strings"
 
 	. "github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/techcatalog"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -39,7 +41,12 @@ func NewTechnologyRepository(db *gorm.DB) TechnologyRepository {
 }
 
 func (r *technologyRepo) Create(ctx context.Context, t *Technology) error {
-	return r.db.WithContext(ctx).Create(t).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(t).Error; err != nil {
+			return err
+		}
+		return recordCreate(tx, t.CompanyID, EntityTechnology, t.ID, t)
+	})
 }
 
 func (r *technologyRepo) CreateBulk(ctx context.Context, techs []Technology, batchSize int) error {
@@ -49,16 +56,122 @@ func (r *technologyRepo) CreateBulk(ctx context.Context, techs []Technology, bat
 	if batchSize <= 0 {
 		batchSize = 200
 	}
-	return r.db.WithContext(ctx).CreateInBatches(&techs, batchSize).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.CreateInBatches(&techs, batchSize).Error; err != nil {
+			return err
+		}
+		return recordTechnologyCreates(tx, techs)
+	})
 }
 
-// Upsert on (company_id, technology_name). Requires a composite unique index
-// or we specify the columns explicitly in the clause.
+// Upsert adds a technology to a company unless it already uses it. Names are
+// matched in their catalog form, ignoring case, so "k8s" finds Kubernetes; a
+// deleted row is restored rather than duplicated, and a kept row takes the
+// catalog name, category and vendor.
 func (r *technologyRepo) Upsert(ctx context.Context, t *Technology) error {
-	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "company_id"}, {Name: "technology_name"}},
-		DoUpdates: clause.AssignmentColumns([]string{"updated_at"}),
-	}).Create(t).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return upsertTechnology(tx, t)
+	})
+}
+
+func upsertTechnology(tx *gorm.DB, t *Technology) error {
+	t.Normalize()
+	var existing Technology
+	if err := tx.Unscoped().
+		Where("company_id = ? AND LOWER(technology_name) = ?", t.CompanyID, strings.ToLower(t.TechnologyName)).
+		Order("deleted_at IS NOT NULL, id ASC").
+		Limit(1).
+		Find(&existing).Error; err != nil {
+		return err
+	}
+	if existing.ID == 0 {
+		t.ID = 0
+		if err := tx.Create(t).Error; err != nil {
+			return err
+		}
+		return recordCreate(tx, t.CompanyID, EntityTechnology, t.ID, t)
+	}
+
+	restored := existing.DeletedAt.Valid
+	t.ID, t.CreatedAt, t.DeletedAt = existing.ID, existing.CreatedAt, gorm.DeletedAt{}
+	if _, ok := techcatalog.Default().Lookup(t.TechnologyName); !ok {
+		t.TechnologyName = existing.TechnologyName // names outside the catalog keep their first spelling
+	}
+	if t.Category == nil {
+		t.Category = existing.Category
+	}
+	if t.Vendor == nil {
+		t.Vendor = existing.Vendor
+	}
+	if !restored && sameTechnology(&existing, t) {
+		*t = existing // nothing to store; updated_at is left alone
+		return nil
+	}
+	if err := tx.Unscoped().Omit(clause.Associations).Save(t).Error; err != nil {
+		return err
+	}
+	if restored {
+		return recordCreate(tx, t.CompanyID, EntityTechnology, t.ID, t)
+	}
+	return recordUpdate(tx, t.CompanyID, EntityTechnology, t.ID, &existing, t)
+}
+
+// sameTechnology reports whether two rows of a technology have the same
+// name, category and vendor.
+func sameTechnology(a, b *Technology) bool {
+	return a.TechnologyName == b.TechnologyName && sameValue(a.Category, b.Category) && sameValue(a.Vendor, b.Vendor)
+}
+
+func sameValue(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// NormalizeTechnologies puts stored technologies in their catalog form and
+// deletes the duplicates that leaves, such as "k8s" next to "Kubernetes",
+// keeping the oldest row. Every rename and deletion is recorded in the
+// company's history.
+func NormalizeTechnologies(db *gorm.DB, batchSize int) error {
+	seen := map[uint]map[string]bool{}
+	var techs []Technology
+	return db.Order("id ASC").FindInBatches(&techs, batchSize, func(_ *gorm.DB, _ int) error {
+		for i := range techs {
+			t := &techs[i]
+			before := *t
+			t.Normalize()
+			key := strings.ToLower(t.TechnologyName)
+			if seen[t.CompanyID] == nil {
+				seen[t.CompanyID] = map[string]bool{}
+			}
+			duplicate := seen[t.CompanyID][key]
+			seen[t.CompanyID][key] = true
+			if !duplicate && sameTechnology(&before, t) {
+				continue
+			}
+			err := db.Session(&gorm.Session{NewDB: true}).Transaction(func(tx *gorm.DB) error {
+				if duplicate {
+					if err := tx.Delete(&before).Error; err != nil {
+						return err
+					}
+					return recordDelete(tx, t.CompanyID, EntityTechnology, t.ID, &before)
+				}
+				if err := tx.Model(t).UpdateColumns(map[string]interface{}{
+					"technology_name": t.TechnologyName,
+					"category":        t.Category,
+					"vendor":          t.Vendor,
+				}).Error; err != nil {
+					return err
+				}
+				return recordUpdate(tx, t.CompanyID, EntityTechnology, t.ID, &before, t)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
 }
 
 func (r *technologyRepo) FindByID(ctx context.Context, id uint) (*Technology, error) {
@@ -80,10 +193,11 @@ func (r *technologyRepo) FindByCompanyID(ctx context.Context, companyID uint) ([
 	return list, nil
 }
 
+// FindByCompanyAndName matches the name in its catalog form, ignoring case.
 func (r *technologyRepo) FindByCompanyAndName(ctx context.Context, companyID uint, name string) (*Technology, error) {
 	var t Technology
 	if err := r.db.WithContext(ctx).
-		Where("company_id = ? AND technology_name = ?", companyID, name).
+		Where("company_id = ? AND LOWER(technology_name) = ?", companyID, canonicalKey(name)).
 		First(&t).Error; err != nil {
 		return nil, err
 	}
@@ -107,13 +221,14 @@ func (r *technologyRepo) ListDistinctNames(ctx context.Context, limit, offset in
 	return names, nil
 }
 
-// SearchNames does a partial match on technology_name.
-// NOTE: `ILIKE` is Postgres-specific; change to `LIKE` for MySQL/SQLite.
+// SearchNames returns the distinct technology names containing query,
+// ignoring case.
 func (r *technologyRepo) SearchNames(ctx context.Context, query string, limit int) ([]string, error) {
 	var names []string
 	tx := r.db.WithContext(ctx).
 		Model(&Technology{}).
-		Where("technology_name ILIKE ?", "%"+query+"%").
+		Distinct().
+		Where(`LOWER(technology_name) LIKE ? ESCAPE '\'`, "%"+strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(strings.ToLower(query))+"%").
 		Order("technology_name ASC")
 	if limit > 0 {
 		tx = tx.Limit(limit)
@@ -124,9 +239,9 @@ func (r *technologyRepo) SearchNames(ctx context.Context, query string, limit in
 	return names, nil
 }
 
-// ReplaceForCompany performs a "sync":
+// ReplaceForCompany performs a "sync" on names in their catalog form:
 // - deletes technologies not in the new set
-// - upserts any new ones
+// - upserts the others, restoring deleted rows
 func (r *technologyRepo) ReplaceForCompany(ctx context.Context, companyID uint, names []string) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// existing
@@ -135,41 +250,32 @@ func (r *technologyRepo) ReplaceForCompany(ctx context.Context, companyID uint,
 			return err
 		}
 
-		// build sets
+		// build the set, keeping the first spelling of each technology
 		want := map[string]struct{}{}
+		var rows []Technology
 		for _, n := range names {
-			if n == "" {
+			key := canonicalKey(n)
+			if key == "" {
+				continue
+			}
+			if _, dup := want[key]; dup {
 				continue
 			}
-			want[n] = struct{}{}
+			want[key] = struct{}{}
+			rows = append(rows, Technology{CompanyID: companyID, TechnologyName: n})
 		}
 		// compute deletions
-		var toDelete []string
+		var toDelete []Technology
 		for _, t := range existing {
-			if _, keep := want[t.TechnologyName]; !keep {
-				toDelete = append(toDelete, t.TechnologyName)
+			if _, keep := want[strings.ToLower(t.TechnologyName)]; !keep {
+				toDelete = append(toDelete, t)
 			}
 		}
-		if len(toDelete) > 0 {
-			if err := tx.
-				Where("company_id = ? AND technology_name IN ?", companyID, toDelete).
-				Delete(&Technology{}).Error; err != nil {
-				return err
-			}
+		if err := deleteTechnologies(tx, toDelete); err != nil {
+			return err
 		}
-		// upsert additions
-		if len(want) > 0 {
-			rows := make([]Technology, 0, len(want))
-			for n := range want {
-				rows = append(rows, Technology{
-					CompanyID:      companyID,
-					TechnologyName: n,
-				})
-			}
-			if err := tx.Clauses(clause.OnConflict{
-				Columns:   []clause.Column{{Name: "company_id"}, {Name: "technology_name"}},
-				DoNothing: true,
-			}).Create(&rows).Error; err != nil {
+		for i := range rows {
+			if err := upsertTechnology(tx, &rows[i]); err != nil {
 				return err
 			}
 		}
@@ -178,17 +284,52 @@ func (r *technologyRepo) ReplaceForCompany(ctx context.Context, companyID uint,
 }
 
 func (r *technologyRepo) DeleteByID(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&Technology{}, id).Error
+	return r.deleteWhere(ctx, "id = ?", id)
 }
 
 func (r *technologyRepo) DeleteByCompanyID(ctx context.Context, companyID uint) error {
-	return r.db.WithContext(ctx).Where("company_id = ?", companyID).Delete(&Technology{}).Error
+	return r.deleteWhere(ctx, "company_id = ?", companyID)
 }
 
 func (r *technologyRepo) DeleteByCompanyAndName(ctx context.Context, companyID uint, name string) error {
-	return r.db.WithContext(ctx).
-		Where("company_id = ? AND technology_name = ?", companyID, name).
-		Delete(&Technology{}).Error
+	return r.deleteWhere(ctx, "company_id = ? AND LOWER(technology_name) = ?", companyID, canonicalKey(name))
+}
+
+func (r *technologyRepo) deleteWhere(ctx context.Context, query string, args ...interface{}) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var rows []Technology
+		if err := tx.Where(query, args...).Find(&rows).Error; err != nil {
+			return err
+		}
+		return deleteTechnologies(tx, rows)
+	})
+}
+
+// deleteTechnologies soft-deletes rows and records each deletion in history.
+func deleteTechnologies(tx *gorm.DB, rows []Technology) error {
+	for i := range rows {
+		if err := tx.Delete(&rows[i]).Error; err != nil {
+			return err
+		}
+		if err := recordDelete(tx, rows[i].CompanyID, EntityTechnology, rows[i].ID, &rows[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func recordTechnologyCreates(tx *gorm.DB, rows []Technology) error {
+	for i := range rows {
+		if err := recordCreate(tx, rows[i].CompanyID, EntityTechnology, rows[i].ID, &rows[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// canonicalKey is the catalog form of a name as stored, lowercased.
+func canonicalKey(name string) string {
+	return strings.ToLower(techcatalog.Default().Canonical(name))
 }
 
 func (r *technologyRepo) CountByCompanyID(ctx context.Context, companyID uint) (int64, error) {
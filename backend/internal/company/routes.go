@@ -6,7 +6,33 @@ func RegisterCompanyRoutes(rg *gin.RouterGroup, h *Handler) {
 	companies := rg.Group("/companies")
 	{
 		companies.GET("/search", h.SearchCompaniesHandler)
+		companies.GET("/duplicates", h.ListDuplicatesHandler)
+		companies.POST("/duplicates/scan", h.ScanDuplicatesHandler)
+		companies.POST("/duplicates/:id/dismiss", h.DismissDuplicateHandler)
+		companies.POST("/merge", h.MergeCompaniesHandler)
+		companies.POST("/import", h.ImportCompaniesHandler)
+		companies.POST("/enrichment", h.ApplyEnrichmentHandler)
+		companies.POST("/industries/classify", h.ClassifyIndustryHandler)
+		companies.GET("/industries/unmapped", h.ListUnmappedIndustriesHandler)
+		companies.DELETE("/industries/unmapped/:id", h.DismissUnmappedIndustryHandler)
+		companies.POST("/industries/reclassify", h.ReclassifyIndustriesHandler)
+		companies.GET("/currencies", h.ListCurrenciesHandler)
+		companies.GET("/technologies/categories", h.ListTechnologyCategoriesHandler)
+		companies.GET("/technologies/catalog", h.ListTechnologyCatalogHandler)
+		companies.GET("/by-domain/:domain", h.GetCompanyByDomainHandler)
 		companies.GET("/:id", h.GetCompanyHandler)
+		companies.GET("/:id/details", h.GetCompanyDetailsHandler)
+		companies.GET("/:id/provenance", h.GetProvenanceHandler)
+		companies.GET("/:id/history", h.GetHistoryHandler)
+		companies.GET("/:id/snapshot", h.GetSnapshotHandler)
+		companies.GET("/:id/financials", h.GetFinancialsHandler)
 		companies.GET("", h.ListCompaniesHandler)
 	}
+
+	investors := rg.Group("/investors")
+	{
+		investors.GET("", h.SearchInvestorsHandler)
+		investors.GET("/autocomplete", h.AutocompleteInvestorsHandler)
+		investors.GET("/:id", h.GetInvestorHandler)
+	}
 }
\ No newline at end of file
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+)
+
+// Category reported for technologies without one
+const uncategorizedTechnology = "other"
+
+// LatestRound is the most recent funding round of a company.
+type LatestRound struct {
+	ID        uint                   `json:"id"`
+	RoundType model.FundingRoundType `json:"round_type"`
+	Date      *time.Time             `json:"date"`
+	Amount    *float64               `json:"amount"`
+	Currency  string                 `json:"currency"`
+	AmountUSD *float64               `json:"amount_usd"`
+}
+
+// TechnologyCategory groups the technologies a company uses by category.
+type TechnologyCategory struct {
+	Category     string   `json:"category"`
+	Technologies []string `json:"technologies"`
+}
+
+// CompanyMetrics are firmographics computed from a company's revenues,
+// funding rounds, technologies and locations.
+type CompanyMetrics struct {
+	TotalFundingUSD      float64              `json:"total_funding_usd"` // of the rounds with a disclosed amount and known FX rate
+	FundingRounds        int                  `json:"funding_rounds"`
+	LatestRound          *LatestRound         `json:"latest_round"`            // latest dated round, or the last recorded if none is dated
+	LastRaisedAt         *time.Time           `json:"last_raised_at"`          // date of the latest dated round
+	MonthsSinceLastRaise *int                 `json:"months_since_last_raise"` // whole months since LastRaisedAt
+	LatestRevenueYear    *int                 `json:"latest_revenue_year"`
+	RevenueGrowthYoY     *float64             `json:"revenue_growth_yoy"` // percent change of the latest year over the one before; nil without both
+	TechnologyCategories []TechnologyCategory `json:"technology_categories"`
+	HeadquartersCount    int                  `json:"headquarters_count"` // locations flagged is_headquarters
+	OfficeCount          int                  `json:"office_count"`       // the other locations
+}
+
+// CompanyDetails is a company with all its related records and the metrics
+// computed from them.
+type CompanyDetails struct {
+	model.Company
+	Metrics CompanyMetrics `json:"metrics"`
+}
+
+// companyMetrics computes the metrics of a company loaded with its details,
+// as of now.
+func companyMetrics(c *model.Company, now time.Time) CompanyMetrics {
+	m := CompanyMetrics{FundingRounds: len(c.FundingRounds), TechnologyCategories: []TechnologyCategory{}}
+
+	var latest *model.FundingRound
+	for i := range c.FundingRounds {
+		round := &c.FundingRounds[i]
+		if round.AmountUSD != nil {
+			m.TotalFundingUSD += *round.AmountUSD
+		}
+		switch {
+		case latest == nil:
+			latest = round
+		case round.Date != nil && (latest.Date == nil || !round.Date.Before(*latest.Date)):
+			latest = round
+		case round.Date == nil && latest.Date == nil && round.ID > latest.ID:
+			latest = round
+		}
+	}
+	m.TotalFundingUSD = math.Round(m.TotalFundingUSD*100) / 100
+	if latest != nil {
+		m.LatestRound = &LatestRound{
+			ID:        latest.ID,
+			RoundType: latest.RoundType,
+			Date:      latest.Date,
+			Amount:    latest.Amount,
+			Currency:  latest.Currency,
+			AmountUSD: latest.AmountUSD,
+		}
+		if latest.Date != nil {
+			months := monthsBetween(*latest.Date, now)
+			m.LastRaisedAt, m.MonthsSinceLastRaise = latest.Date, &months
+		}
+	}
+
+	m.LatestRevenueYear, m.RevenueGrowthYoY = revenueGrowth(c.Revenues)
+	m.TechnologyCategories = technologyCategories(c.Technologies)
+
+	for _, loc := range c.Locations {
+		if loc.IsHeadquarters {
+			m.HeadquartersCount++
+		}
+	}
+	m.OfficeCount = len(c.Locations) - m.HeadquartersCount
+	return m
+}
+
+// monthsBetween counts the whole months from one time to a later one; it is
+// 0 for times in the future.
+func monthsBetween(from, to time.Time) int {
+	months := (to.Year()-from.Year())*12 + int(to.Month()-from.Month())
+	if to.Day() < from.Day() {
+		months--
+	}
+	if months < 0 {
+		return 0
+	}
+	return months
+}
+
+// revenueGrowth returns the latest reported year and its growth over the year
+// before, in percent. Years in the same currency compare as reported, so the
+// growth is not skewed by exchange rates; others compare in USD.
+func revenueGrowth(revenues []model.Revenue) (*int, *float64) {
+	if len(revenues) == 0 {
+		return nil, nil
+	}
+	byYear := make(map[int]model.Revenue, len(revenues))
+	latestYear := revenues[0].Year
+	for _, r := range revenues {
+		byYear[r.Year] = r
+		if r.Year > latestYear {
+			latestYear = r.Year
+		}
+	}
+
+	latest := byYear[latestYear]
+	previous, ok := byYear[latestYear-1]
+	if !ok {
+		return &latestYear, nil
+	}
+	var from, to float64
+	switch {
+	case strings.EqualFold(previous.Currency, latest.Currency):
+		from, to = previous.Amount, latest.Amount
+	case previous.AmountUSD != nil && latest.AmountUSD != nil:
+		from, to = *previous.AmountUSD, *latest.AmountUSD
+	default:
+		return &latestYear, nil
+	}
+	if from <= 0 {
+		return &latestYear, nil
+	}
+	growth := math.Round((to-from)/from*10000) / 100
+	return &latestYear, &growth
+}
+
+// technologyCategories groups technologies by category, the largest
+// category first.
+func technologyCategories(techs []model.Technology) []TechnologyCategory {
+	index := map[string]int{}
+	out := []TechnologyCategory{}
+	for _, t := range techs {
+		category := uncategorizedTechnology
+		if t.Category != nil && strings.TrimSpace(*t.Category) != "" {
+			category = strings.ToLower(strings.TrimSpace(*t.Category))
+		}
+		i, ok := index[category]
+		if !ok {
+			i = len(out)
+			index[category] = i
+			out = append(out, TechnologyCategory{Category: category})
+		}
+		out[i].Technologies = append(out[i].Technologies, t.TechnologyName)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if (out[i].Category == uncategorizedTechnology) != (out[j].Category == uncategorizedTechnology) {
+			return out[j].Category == uncategorizedTechnology
+		}
+		if len(out[i].Technologies) != len(out[j].Technologies) {
+			return len(out[i].Technologies) > len(out[j].Technologies)
+		}
+		return out[i].Category < out[j].Category
+	})
+	return out
+}
@@ -3,36 +3,162 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/company/querylang"
 	"github.com/bhati00/Fynelo/backend/internal/company/repositories"
 	"github.com/bhati00/Fynelo/backend/internal/constants"
+	"github.com/bhati00/Fynelo/backend/internal/geo"
 	"github.com/bhati00/Fynelo/backend/internal/queue"
+	"github.com/bhati00/Fynelo/backend/internal/taxonomy"
+	"github.com/bhati00/Fynelo/backend/internal/techcatalog"
+	"github.com/bhati00/Fynelo/backend/pkg/domain"
+)
+
+// Search query modes
+const (
+	QueryModeSimple   = "simple"   // q is a substring match on name and website
+	QueryModeAdvanced = "advanced" // q is parsed with the querylang package
+)
+
+var ErrInvalidQueryMode = errors.New("q_mode must be 'simple' or 'advanced'")
+
+// ErrUnknownLocation is returned when a location filter cannot be resolved.
+var ErrUnknownLocation = errors.New("unknown location")
+
+// ErrUnknownEmployeeSize is returned when an employee size filter names no
+// company size.
+var ErrUnknownEmployeeSize = errors.New("unknown employee size")
+
+// ErrInvalidEmployeeRange is returned when employees_min or employees_max is
+// negative or the range is empty.
+var ErrInvalidEmployeeRange = errors.New("employees_min and employees_max must not be negative, with employees_min no greater than employees_max")
+
+// ErrInvalidSort is returned for an unknown search order.
+var ErrInvalidSort = errors.New("sort must be one of: revenue, funding")
+
+// ErrInvalidAmountRange is returned when a revenue or funding filter is
+// negative or revenue_min exceeds revenue_max.
+var ErrInvalidAmountRange = errors.New("revenue_min, revenue_max and funding_min must not be negative, with revenue_min no greater than revenue_max")
+
+// ErrInvalidInvestor is returned for an investor filter that names no one.
+var ErrInvalidInvestor = errors.New("investor must be an investor ID or name")
+
+// ErrUnknownTechCategory is returned for a technology category the catalog
+// does not have.
+var ErrUnknownTechCategory = errors.New("unknown technology category")
+
+// searchValidationErrors are returned by SearchCompanies for requests it
+// rejects, as opposed to failures running them.
+var searchValidationErrors = []error{
+	ErrInvalidQueryMode,
+	ErrUnknownLocation,
+	ErrUnknownEmployeeSize,
+	ErrInvalidEmployeeRange,
+	ErrInvalidSort,
+	ErrInvalidAmountRange,
+	ErrInvalidInvestor,
+	ErrUnknownTechCategory,
+}
+
+// IsSearchValidationError reports whether err rejects a company search
+// request, which handlers answer with 400. Query syntax errors are
+// querylang.SyntaxError values, reported with their position.
+func IsSearchValidationError(err error) bool {
+	for _, target := range searchValidationErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrDuplicateDomain is returned when creating a company whose domain is
+// already used by another company.
+var ErrDuplicateDomain = errors.New("a company with this domain already exists")
+
+// DomainConflictError is returned when a company would take the domain of
+// another company. It matches ErrDuplicateDomain.
+type DomainConflictError struct {
+	Domain    string
+	CompanyID uint // the company that has the domain
+}
+
+func (e *DomainConflictError) Error() string {
+	return fmt.Sprintf("%v: %s belongs to company %d", ErrDuplicateDomain, e.Domain, e.CompanyID)
+}
+
+func (e *DomainConflictError) Unwrap() error {
+	return ErrDuplicateDomain
+}
+
+// Radius search defaults
+const (
+	defaultRadiusKm = 50
+	maxRadiusKm     = 1000
 )
 
 type CompanySearchRequest struct {
-	Query        string `form:"q"`
-	Industry     string `form:"industry"`
-	EmployeeSize string `form:"employee_size"`
-	Location     string `form:"location"`
-	FundingStage string `form:"funding_stage"`
-	FoundedMin   *int   `form:"founded_min"`
-	FoundedMax   *int   `form:"founded_max"`
-	Status       string `form:"status"`
-	Limit        int    `form:"limit"`
-	Offset       int    `form:"offset"`
+	Query        string   `form:"q" json:"q,omitempty"`
+	QueryMode    string   `form:"q_mode" json:"q_mode,omitempty"`
+	Industry     string   `form:"industry" json:"industry,omitempty"`
+	EmployeeSize string   `form:"employee_size" json:"employee_size,omitempty"`
+	EmployeesMin *int     `form:"employees_min" json:"employees_min,omitempty"` // employee count range
+	EmployeesMax *int     `form:"employees_max" json:"employees_max,omitempty"`
+	Location     string   `form:"location" json:"location,omitempty"`
+	Country      string   `form:"country" json:"country,omitempty"`     // country name or ISO code
+	State        string   `form:"state" json:"state,omitempty"`         // state/region name or ISO-3166-2 code
+	City         string   `form:"city" json:"city,omitempty"`           // city name
+	Near         string   `form:"near" json:"near,omitempty"`           // place name ("Berlin") or "lat,lon"
+	RadiusKm     float64  `form:"radius_km" json:"radius_km,omitempty"` // radius for near (default 50 km)
+	FundingStage string   `form:"funding_stage" json:"funding_stage,omitempty"`
+	FoundedMin   *int     `form:"founded_min" json:"founded_min,omitempty"`
+	FoundedMax   *int     `form:"founded_max" json:"founded_max,omitempty"`
+	RevenueMin   *float64 `form:"revenue_min" json:"revenue_min,omitempty"` // latest reported revenue, USD
+	RevenueMax   *float64 `form:"revenue_max" json:"revenue_max,omitempty"`
+	FundingMin   *float64 `form:"funding_min" json:"funding_min,omitempty"`     // total disclosed funding, USD
+	Investor     string   `form:"investor" json:"investor,omitempty"`           // investor ID or name
+	InvestorLead bool     `form:"investor_lead" json:"investor_lead,omitempty"` // only rounds the investor led
+	TechCategory string   `form:"tech_category" json:"tech_category,omitempty"` // technology category code or name, e.g. crm
+	Sort         string   `form:"sort" json:"sort,omitempty"`                   // revenue or funding, highest first
+	Status       string   `form:"status" json:"status,omitempty"`
+	Limit        int      `form:"limit" json:"-"`
+	Offset       int      `form:"offset" json:"-"`
+	Facets       bool     `form:"facets" json:"-"` // include facet counts in the response
+
+	// ICPID is set when an ICP profile drives the search; enrichment is then
+	// always queued and attributed to the profile.
+	ICPID uint `form:"-" json:"-"`
 }
 
 type CompanySearchResponse struct {
-	Companies  []model.Company `json:"companies"`
-	Total      int64           `json:"total"`
-	HasMore    bool            `json:"has_more"`
-	Limit      int             `json:"limit"`
-	Offset     int             `json:"offset"`
-	QueuedJobs []QueuedJob     `json:"queued_jobs,omitempty"`
-	SearchTime string          `json:"search_time"`
+	Companies  []model.Company            `json:"companies"`
+	Total      int64                      `json:"total"`
+	HasMore    bool                       `json:"has_more"`
+	Limit      int                        `json:"limit"`
+	Offset     int                        `json:"offset"`
+	QueuedJobs []QueuedJob                `json:"queued_jobs,omitempty"`
+	Facets     *repositories.SearchFacets `json:"facets,omitempty"`
+	SearchTime string                     `json:"search_time"`
+}
+
+// ImportResult summarizes an upsert-by-domain import.
+type ImportResult struct {
+	Created   int             `json:"created"`
+	Updated   int             `json:"updated"`
+	Failed    []ImportFailure `json:"failed,omitempty"`
+	Companies []model.Company `json:"companies"`
+}
+
+type ImportFailure struct {
+	Index             int    `json:"index"`
+	Name              string `json:"name"`
+	Error             string `json:"error"`
+	ExistingCompanyID uint   `json:"existing_company_id,omitempty"` // company already using the domain, on a domain conflict
 }
 
 type QueuedJob struct {
@@ -44,21 +170,32 @@ type QueuedJob struct {
 type CompanyService interface {
 	CreateCompany(ctx context.Context, c *model.Company) error
 	GetCompanyByID(ctx context.Context, id uint) (*model.Company, error)
-	GetCompanyWithDetails(ctx context.Context, id uint) (*model.Company, error) // preloads related entities
+	GetCompanyWithDetails(ctx context.Context, id uint) (*CompanyDetails, error) // preloads related entities and computes metrics
+	GetCompanyByDomain(ctx context.Context, website string) (*model.Company, error)
+	// UpsertCompanyByDomain applies the company as an enrichment observation
+	// from its Source; it never inserts a second company for an existing domain.
+	UpsertCompanyByDomain(ctx context.Context, c *model.Company) (*model.Company, bool, error)
+	ImportCompanies(ctx context.Context, companies []model.Company) (*ImportResult, error)
 	ListCompanies(ctx context.Context, limit, offset int) ([]model.Company, error)
 	UpdateCompany(ctx context.Context, c *model.Company) error
 	DeleteCompany(ctx context.Context, id uint) error
 	// New search method
 	SearchCompanies(ctx context.Context, req CompanySearchRequest) (*CompanySearchResponse, error)
+	// SearchCompanyIDs returns the IDs of up to max companies matching req,
+	// ignoring its pagination and without queueing enrichment.
+	SearchCompanyIDs(ctx context.Context, req CompanySearchRequest, max int) ([]uint, error)
+	// ValidateSearch reports whether req would be accepted by SearchCompanies.
+	ValidateSearch(req CompanySearchRequest) error
 }
 
 type companyService struct {
 	repo         repositories.CompanyRepository
+	enrichment   EnrichmentService
 	queueService queue.QueueService
 }
 
-func NewCompanyService(repo repositories.CompanyRepository, queueService queue.QueueService) CompanyService {
-	return &companyService{repo: repo, queueService: queueService}
+func NewCompanyService(repo repositories.CompanyRepository, enrichment EnrichmentService, queueService queue.QueueService) CompanyService {
+	return &companyService{repo: repo, enrichment: enrichment, queueService: queueService}
 }
 
 func (s *companyService) CreateCompany(ctx context.Context, c *model.Company) error {
@@ -68,6 +205,13 @@ func (s *companyService) CreateCompany(ctx context.Context, c *model.Company) er
 	if c.Name == "" {
 		return errors.New("company name is required")
 	}
+	if c.Website != nil {
+		if host, err := domain.Canonicalize(*c.Website); err == nil {
+			if existing, err := s.repo.FindByDomain(ctx, host); err == nil {
+				return &DomainConflictError{Domain: host, CompanyID: existing.ID}
+			}
+		}
+	}
 	return s.repo.Create(ctx, c)
 }
 
@@ -75,9 +219,110 @@ func (s *companyService) GetCompanyByID(ctx context.Context, id uint) (*model.Co
 	return s.repo.FindByID(ctx, id)
 }
 
-func (s *companyService) GetCompanyWithDetails(ctx context.Context, id uint) (*model.Company, error) {
-	// assume repo.FindByID preloads necessary relations; if not, add a dedicated repo method
-	return s.repo.FindByID(ctx, id)
+func (s *companyService) GetCompanyWithDetails(ctx context.Context, id uint) (*CompanyDetails, error) {
+	c, err := s.repo.FindByIDWithDetails(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &CompanyDetails{Company: *c, Metrics: companyMetrics(c, time.Now())}, nil
+}
+
+// GetCompanyByDomain accepts a bare domain or a full website URL.
+func (s *companyService) GetCompanyByDomain(ctx context.Context, website string) (*model.Company, error) {
+	host, err := domain.Canonicalize(website)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FindByDomain(ctx, host)
+}
+
+func (s *companyService) UpsertCompanyByDomain(ctx context.Context, c *model.Company) (*model.Company, bool, error) {
+	if c == nil {
+		return nil, false, errors.New("company is nil")
+	}
+	if c.Name == "" {
+		return nil, false, errors.New("company name is required")
+	}
+	if c.Website == nil && c.Domain != nil {
+		c.Website = c.Domain
+	}
+
+	// the company's own Source decides how it ranks against enriched values
+	source := c.Source
+	if source == "" {
+		source = model.SourceManual
+	}
+	outcome, err := s.enrichment.ApplyEnrichment(ctx, EnrichmentResult{
+		Source:                 source,
+		Name:                   &c.Name,
+		Website:                c.Website,
+		HQLocation:             c.HQLocation,
+		IndustryID:             c.IndustryID,
+		NAICSCode:              c.NAICSCode,
+		SICCode:                c.SICCode,
+		Industry:               c.IndustryText,
+		EmployeeSizeID:         c.EmployeeSizeID,
+		EmployeeCount:          c.EmployeeCount,
+		Employees:              optionalString(c.Employees),
+		EmployeeCountEstimated: c.EmployeeCountEstimated,
+		FoundedYear:            c.FoundedYear,
+		Status:                 optionalStatus(c.Status),
+		Revenues:               c.Revenues,
+		FundingRounds:          c.FundingRounds,
+		Technologies:           technologyNames(c.Technologies),
+		Locations:              c.Locations,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return outcome.Company, outcome.Created, nil
+}
+
+func optionalString(s string) *string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	return &s
+}
+
+func optionalStatus(status model.CompanyStatus) *model.CompanyStatus {
+	if status == "" {
+		return nil
+	}
+	return &status
+}
+
+func technologyNames(techs []model.Technology) []string {
+	names := make([]string, 0, len(techs))
+	for _, t := range techs {
+		names = append(names, t.TechnologyName)
+	}
+	return names
+}
+
+// ImportCompanies upserts each company by domain. A failing row is reported
+// and does not stop the rest of the import.
+func (s *companyService) ImportCompanies(ctx context.Context, companies []model.Company) (*ImportResult, error) {
+	result := &ImportResult{Companies: []model.Company{}}
+	for i := range companies {
+		stored, created, err := s.UpsertCompanyByDomain(ctx, &companies[i])
+		if err != nil {
+			failure := ImportFailure{Index: i, Name: companies[i].Name, Error: err.Error()}
+			var conflict *DomainConflictError
+			if errors.As(err, &conflict) {
+				failure.ExistingCompanyID = conflict.CompanyID
+			}
+			result.Failed = append(result.Failed, failure)
+			continue
+		}
+		if created {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+		result.Companies = append(result.Companies, *stored)
+	}
+	return result, nil
 }
 
 func (s *companyService) ListCompanies(ctx context.Context, limit, offset int) ([]model.Company, error) {
@@ -105,30 +350,9 @@ func (s *companyService) SearchCompanies(ctx context.Context, req CompanySearchR
 		req.Limit = 100 // Max limit
 	}
 
-	// Convert search request to repository params
-	params := repositories.CompanySearchParams{
-		Query:        req.Query,
-		Location:     req.Location,
-		FundingStage: req.FundingStage,
-		FoundedMin:   req.FoundedMin,
-		FoundedMax:   req.FoundedMax,
-		Status:       req.Status,
-		Limit:        req.Limit,
-		Offset:       req.Offset,
-	}
-
-	// Convert industry name to ID
-	if req.Industry != "" {
-		industryID := constants.GetIndustryID(strings.ToLower(req.Industry))
-		if industryID != constants.IndustryOther || strings.ToLower(req.Industry) == "other" {
-			params.IndustryID = &industryID
-		}
-	}
-
-	// Convert employee size to ID
-	if req.EmployeeSize != "" {
-		sizeID := constants.GetCompanySizeID(req.EmployeeSize)
-		params.EmployeeSizeID = &sizeID
+	params, err := searchParams(req)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get companies and total count
@@ -142,6 +366,16 @@ func (s *companyService) SearchCompanies(ctx context.Context, req CompanySearchR
 		return nil, err
 	}
 
+	// Facets are computed alongside the count so sidebars reflect the same filters
+	var facets *repositories.SearchFacets
+	if req.Facets {
+		facets, err = s.repo.SearchFacets(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		labelFacets(facets)
+	}
+
 	// Check if there are more results
 	hasMore := int64(req.Offset+req.Limit) < total
 
@@ -153,11 +387,12 @@ func (s *companyService) SearchCompanies(ctx context.Context, req CompanySearchR
 		HasMore:    hasMore,
 		Limit:      req.Limit,
 		Offset:     req.Offset,
+		Facets:     facets,
 		SearchTime: searchTime,
 	}
 
 	// Queue for enrichment if results are limited and we have search criteria
-	if s.shouldEnqueueForEnrichment(req, total) {
+	if req.ICPID != 0 || s.shouldEnqueueForEnrichment(req, total) {
 		queuedJob := s.enqueueSearchJob(req)
 		if queuedJob != nil {
 			response.QueuedJobs = []QueuedJob{*queuedJob}
@@ -167,10 +402,233 @@ func (s *companyService) SearchCompanies(ctx context.Context, req CompanySearchR
 	return response, nil
 }
 
+// searchParams converts a search request to repository params.
+func searchParams(req CompanySearchRequest) (repositories.CompanySearchParams, error) {
+	// Convert search request to repository params
+	params := repositories.CompanySearchParams{
+		Location:      req.Location,
+		FundingStage:  req.FundingStage,
+		FoundedMin:    req.FoundedMin,
+		FoundedMax:    req.FoundedMax,
+		EmployeesMin:  req.EmployeesMin,
+		EmployeesMax:  req.EmployeesMax,
+		RevenueMinUSD: req.RevenueMin,
+		RevenueMaxUSD: req.RevenueMax,
+		FundingMinUSD: req.FundingMin,
+		Status:        req.Status,
+		Limit:         req.Limit,
+		Offset:        req.Offset,
+	}
+
+	switch req.QueryMode {
+	case "", QueryModeSimple:
+		params.Query = req.Query
+	case QueryModeAdvanced:
+		if strings.TrimSpace(req.Query) != "" {
+			expr, err := querylang.Parse(req.Query)
+			if err != nil {
+				return params, err
+			}
+			params.Expression = expr
+		}
+	default:
+		return params, ErrInvalidQueryMode
+	}
+
+	if err := resolveLocationFilters(req, &params); err != nil {
+		return params, err
+	}
+
+	// Convert industry name to ID
+	if req.Industry != "" {
+		if industryID, ok := taxonomy.Default().Lookup(taxonomy.Industries, req.Industry); ok {
+			params.IndustryID = &industryID
+		}
+	}
+
+	// Convert employee size to ID
+	if req.EmployeeSize != "" {
+		sizeID, ok := taxonomy.Default().Lookup(taxonomy.CompanySizes, req.EmployeeSize)
+		if !ok {
+			return params, fmt.Errorf("%w: %q", ErrUnknownEmployeeSize, req.EmployeeSize)
+		}
+		params.EmployeeSizeID = &sizeID
+	}
+
+	if (req.EmployeesMin != nil && *req.EmployeesMin < 0) || (req.EmployeesMax != nil && *req.EmployeesMax < 0) ||
+		(req.EmployeesMin != nil && req.EmployeesMax != nil && *req.EmployeesMin > *req.EmployeesMax) {
+		return params, ErrInvalidEmployeeRange
+	}
+
+	if (req.RevenueMin != nil && *req.RevenueMin < 0) || (req.RevenueMax != nil && *req.RevenueMax < 0) ||
+		(req.FundingMin != nil && *req.FundingMin < 0) ||
+		(req.RevenueMin != nil && req.RevenueMax != nil && *req.RevenueMin > *req.RevenueMax) {
+		return params, ErrInvalidAmountRange
+	}
+
+	if investor := strings.TrimSpace(req.Investor); investor != "" {
+		if id, err := strconv.ParseUint(investor, 10, 32); err == nil {
+			investorID := uint(id)
+			params.InvestorID = &investorID
+		} else if name := model.NormalizeInvestorName(investor); name != "" {
+			params.Investor = name
+		} else {
+			return params, fmt.Errorf("%w: %q", ErrInvalidInvestor, req.Investor)
+		}
+		params.InvestorLead = req.InvestorLead
+	}
+
+	if req.TechCategory != "" {
+		category, ok := techcatalog.Default().Category(req.TechCategory)
+		if !ok {
+			return params, fmt.Errorf("%w: %q", ErrUnknownTechCategory, req.TechCategory)
+		}
+		params.TechCategory = category.Code
+	}
+
+	switch req.Sort {
+	case "", repositories.SortRevenue, repositories.SortFunding:
+		params.Sort = req.Sort
+	default:
+		return params, ErrInvalidSort
+	}
+
+	return params, nil
+}
+
+func (s *companyService) ValidateSearch(req CompanySearchRequest) error {
+	_, err := searchParams(req)
+	return err
+}
+
+func (s *companyService) SearchCompanyIDs(ctx context.Context, req CompanySearchRequest, max int) ([]uint, error) {
+	params, err := searchParams(req)
+	if err != nil {
+		return nil, err
+	}
+
+	const pageSize = 100
+	var ids []uint
+	for offset := 0; len(ids) < max; offset += pageSize {
+		params.Limit, params.Offset = pageSize, offset
+		if remaining := max - len(ids); remaining < pageSize {
+			params.Limit = remaining
+		}
+		page, err := s.repo.Search(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range page {
+			ids = append(ids, c.ID)
+		}
+		if len(page) < params.Limit {
+			break
+		}
+	}
+	return ids, nil
+}
+
+// resolveLocationFilters maps the country, state, city and near filters to
+// normalized codes and coordinates using the gazetteer.
+func resolveLocationFilters(req CompanySearchRequest, params *repositories.CompanySearchParams) error {
+	gaz := geo.Default()
+
+	if req.Country != "" {
+		country, ok := gaz.Country(req.Country)
+		if !ok {
+			return fmt.Errorf("%w: country %q", ErrUnknownLocation, req.Country)
+		}
+		params.CountryCode = country.Code
+	}
+
+	if req.State != "" {
+		region, ok := gaz.Region(req.State, params.CountryCode)
+		if !ok {
+			return fmt.Errorf("%w: state %q", ErrUnknownLocation, req.State)
+		}
+		params.RegionCode = region.Code
+	}
+
+	if req.City != "" {
+		cities := []string{strings.ToLower(strings.TrimSpace(req.City))}
+		// also match the gazetteer spelling, e.g. "Bengaluru" finds "Bangalore"
+		if city, ok := gaz.City(req.City, params.CountryCode, params.RegionCode); ok {
+			if canonical := strings.ToLower(city.Name); canonical != cities[0] {
+				cities = append(cities, canonical)
+			}
+		}
+		params.Cities = cities
+	}
+
+	if req.Near != "" {
+		lat, lon, ok := parseCoordinates(req.Near)
+		if !ok {
+			place := gaz.ParsePlace(req.Near)
+			if place.City == nil {
+				return fmt.Errorf("%w: near %q", ErrUnknownLocation, req.Near)
+			}
+			lat, lon = place.City.Latitude, place.City.Longitude
+		}
+		radius := req.RadiusKm
+		if radius <= 0 {
+			radius = defaultRadiusKm
+		}
+		if radius > maxRadiusKm {
+			radius = maxRadiusKm
+		}
+		params.Near = &repositories.GeoRadius{Latitude: lat, Longitude: lon, RadiusKm: radius}
+	}
+
+	return nil
+}
+
+// parseCoordinates parses "lat,lon".
+func parseCoordinates(s string) (float64, float64, bool) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return 0, 0, false
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil || lon < -180 || lon > 180 {
+		return 0, 0, false
+	}
+	return lat, lon, true
+}
+
+// labelFacets fills display labels for facets whose values are taxonomy IDs or country codes.
+func labelFacets(facets *repositories.SearchFacets) {
+	for i, b := range facets.Industries {
+		if id, err := strconv.Atoi(b.Value); err == nil {
+			facets.Industries[i].Label = taxonomy.Default().Name(taxonomy.Industries, id)
+		}
+	}
+	for i, b := range facets.EmployeeSizes {
+		if id, err := strconv.Atoi(b.Value); err == nil {
+			facets.EmployeeSizes[i].Label = taxonomy.Default().Name(taxonomy.CompanySizes, id)
+		}
+	}
+	for i, b := range facets.RevenueBands {
+		if id, err := strconv.Atoi(b.Value); err == nil {
+			facets.RevenueBands[i].Label = constants.GetRevenueBandName(id)
+		}
+	}
+	for i, b := range facets.Countries {
+		if country, ok := geo.Default().CountryByCode(b.Value); ok {
+			facets.Countries[i].Label = country.Name
+		}
+	}
+}
+
 func (s *companyService) shouldEnqueueForEnrichment(req CompanySearchRequest, currentTotal int64) bool {
 	// Only queue if we have specific search criteria and limited results
 	hasSearchCriteria := req.Query != "" || req.Industry != "" || req.EmployeeSize != "" ||
-		req.Location != "" || req.FundingStage != ""
+		req.EmployeesMin != nil || req.EmployeesMax != nil ||
+		req.Location != "" || req.Country != "" || req.State != "" || req.City != "" ||
+		req.FundingStage != ""
 
 	// Queue if we have search criteria and less than 50 results
 	return hasSearchCriteria && currentTotal < 50
@@ -183,16 +641,23 @@ func (s *companyService) enqueueSearchJob(req CompanySearchRequest) *QueuedJob {
 
 	// Create search job
 	searchJob := &queue.SearchJob{
-		Query: req.Query,
+		Query:     req.Query,
+		QueryMode: req.QueryMode,
 		Filters: queue.SearchFilters{
 			Industry:     req.Industry,
 			EmployeeSize: req.EmployeeSize,
+			EmployeesMin: req.EmployeesMin,
+			EmployeesMax: req.EmployeesMax,
 			Location:     req.Location,
+			Country:      req.Country,
+			State:        req.State,
+			City:         req.City,
 			FundingStage: req.FundingStage,
 			FoundedMin:   req.FoundedMin,
 			FoundedMax:   req.FoundedMax,
 			Status:       req.Status,
 		},
+		ICPID:    req.ICPID,
 		Priority: queue.PriorityNormal,
 	}
 
@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/company/repositories"
+	"github.com/bhati00/Fynelo/backend/pkg/domain"
+)
+
+// Duplicate scoring weights. A pair becomes a candidate at DuplicateThreshold.
+const (
+	DuplicateThreshold   = 0.5
+	domainMatchWeight    = 0.6
+	nameSimilarityWeight = 0.5
+	locationMatchWeight  = 0.1
+	domainConflictWeight = 0.3 // subtracted when both companies have different domains
+	minNameSimilarity    = 0.85
+)
+
+type DuplicateScanResult struct {
+	CompaniesScanned int `json:"companies_scanned"`
+	PairsCompared    int `json:"pairs_compared"`
+	Candidates       int `json:"candidates"`
+	Clusters         int `json:"clusters"`
+}
+
+type MergeRequest struct {
+	SurvivorID uint   `json:"survivor_id" binding:"required"`
+	MergedIDs  []uint `json:"merged_ids" binding:"required,min=1"`
+	MergedBy   string `json:"merged_by"`
+}
+
+type DedupeService interface {
+	ScanDuplicates(ctx context.Context) (*DuplicateScanResult, error)
+	ListCandidates(ctx context.Context, status model.DuplicateStatus, limit, offset int) ([]model.DuplicateCandidate, error)
+	DismissCandidate(ctx context.Context, id uint) error
+	MergeCompanies(ctx context.Context, req MergeRequest) ([]model.CompanyMerge, error)
+	// ResolveMergedID returns the surviving company ID for a merged company.
+	ResolveMergedID(ctx context.Context, id uint) (uint, bool)
+}
+
+type dedupeService struct {
+	repo repositories.DuplicateRepository
+}
+
+func NewDedupeService(repo repositories.DuplicateRepository) DedupeService {
+	return &dedupeService{repo: repo}
+}
+
+// dedupeRecord is a company reduced to the keys used for comparison.
+type dedupeRecord struct {
+	id        uint
+	name      string
+	domain    string
+	locations map[string]struct{}
+}
+
+// ScanDuplicates compares companies that share a blocking key (domain or
+// name prefix), scores each pair and stores pairs above the threshold,
+// grouped into clusters of connected companies.
+func (s *dedupeService) ScanDuplicates(ctx context.Context) (*DuplicateScanResult, error) {
+	companies, err := s.repo.ListForScan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]dedupeRecord, len(companies))
+	blocks := map[string][]int{}
+	for i, c := range companies {
+		records[i] = toDedupeRecord(c)
+		for _, key := range blockingKeys(records[i]) {
+			blocks[key] = append(blocks[key], i)
+		}
+	}
+
+	result := &DuplicateScanResult{CompaniesScanned: len(companies)}
+	seen := map[[2]int]struct{}{}
+	var candidates []model.DuplicateCandidate
+	for _, members := range blocks {
+		for a := 0; a < len(members); a++ {
+			for b := a + 1; b < len(members); b++ {
+				pair := [2]int{members[a], members[b]}
+				if _, done := seen[pair]; done {
+					continue
+				}
+				seen[pair] = struct{}{}
+				result.PairsCompared++
+
+				score, reasons := scorePair(records[pair[0]], records[pair[1]])
+				if score < DuplicateThreshold {
+					continue
+				}
+				candidates = append(candidates, model.DuplicateCandidate{
+					CompanyID:   records[pair[0]].id,
+					DuplicateID: records[pair[1]].id,
+					Score:       score,
+					Reasons:     strings.Join(reasons, ","),
+					Status:      model.DuplicatePending,
+				})
+			}
+		}
+	}
+
+	result.Clusters = assignClusters(candidates)
+	result.Candidates = len(candidates)
+	if err := s.repo.SaveCandidates(ctx, candidates); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *dedupeService) ListCandidates(ctx context.Context, status model.DuplicateStatus, limit, offset int) ([]model.DuplicateCandidate, error) {
+	return s.repo.ListCandidates(ctx, status, limit, offset)
+}
+
+func (s *dedupeService) DismissCandidate(ctx context.Context, id uint) error {
+	if _, err := s.repo.FindCandidate(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.UpdateCandidateStatus(ctx, id, model.DuplicateDismissed)
+}
+
+func (s *dedupeService) MergeCompanies(ctx context.Context, req MergeRequest) ([]model.CompanyMerge, error) {
+	if req.SurvivorID == 0 || len(req.MergedIDs) == 0 {
+		return nil, errors.New("survivor_id and merged_ids are required")
+	}
+	if req.MergedBy == "" {
+		req.MergedBy = "manual"
+	}
+	return s.repo.Merge(ctx, req.SurvivorID, req.MergedIDs, req.MergedBy)
+}
+
+func (s *dedupeService) ResolveMergedID(ctx context.Context, id uint) (uint, bool) {
+	merge, err := s.repo.FindMergeByMergedID(ctx, id)
+	if err != nil {
+		return 0, false
+	}
+	return merge.SurvivorID, true
+}
+
+func toDedupeRecord(c model.Company) dedupeRecord {
+	rec := dedupeRecord{id: c.ID, name: normalizeCompanyName(c.Name), locations: map[string]struct{}{}}
+	if c.Domain != nil {
+		rec.domain = *c.Domain
+	} else if c.Website != nil {
+		// companies left without a domain because it was already taken
+		rec.domain, _ = domain.Canonicalize(*c.Website)
+	}
+	// a company named after its domain ("acme.com") compares by domain too
+	if rec.domain == "" && strings.Contains(c.Name, ".") && !strings.Contains(c.Name, " ") {
+		rec.domain, _ = domain.Canonicalize(c.Name)
+	}
+	for _, l := range c.Locations {
+		if l.CountryCode != nil {
+			rec.locations[*l.CountryCode] = struct{}{}
+		}
+	}
+	if c.HQLocation != nil && *c.HQLocation != "" {
+		rec.locations["hq:"+strings.ToLower(strings.TrimSpace(*c.HQLocation))] = struct{}{}
+	}
+	return rec
+}
+
+// blockingKeys returns the distinct keys a record is grouped by; only records
+// sharing a key are compared.
+func blockingKeys(r dedupeRecord) []string {
+	keys := map[string]struct{}{}
+	if r.domain != "" {
+		keys["domain:"+r.domain] = struct{}{}
+		keys["name:"+prefix(domain.Label(r.domain), 4)] = struct{}{}
+	}
+	if r.name != "" {
+		keys["name:"+prefix(r.name, 4)] = struct{}{}
+	}
+	out := make([]string, 0, len(keys))
+	for k := range keys {
+		out = append(out, k)
+	}
+	return out
+}
+
+func scorePair(a, b dedupeRecord) (float64, []string) {
+	var score float64
+	var reasons []string
+
+	if a.domain != "" && b.domain != "" {
+		if a.domain == b.domain {
+			score += domainMatchWeight
+			reasons = append(reasons, "domain")
+		} else {
+			score -= domainConflictWeight
+		}
+	}
+
+	nameA, nameB := a.name, b.name
+	if nameA == "" && a.domain != "" {
+		nameA = domain.Label(a.domain)
+	}
+	if nameB == "" && b.domain != "" {
+		nameB = domain.Label(b.domain)
+	}
+	if sim := jaroWinkler(nameA, nameB); sim >= minNameSimilarity {
+		score += nameSimilarityWeight * sim
+		reasons = append(reasons, "name")
+	}
+
+	for key := range a.locations {
+		if _, ok := b.locations[key]; ok {
+			score += locationMatchWeight
+			reasons = append(reasons, "location")
+			break
+		}
+	}
+
+	if score > 1 {
+		score = 1
+	}
+	return score, reasons
+}
+
+// assignClusters groups candidate pairs into connected components and sets
+// ClusterID to the lowest company ID of each component.
+func assignClusters(candidates []model.DuplicateCandidate) int {
+	parent := map[uint]uint{}
+	var find func(uint) uint
+	find = func(x uint) uint {
+		if p, ok := parent[x]; ok && p != x {
+			parent[x] = find(p)
+			return parent[x]
+		}
+		parent[x] = x
+		return x
+	}
+	union := func(a, b uint) {
+		ra, rb := find(a), find(b)
+		if ra == rb {
+			return
+		}
+		if ra < rb {
+			parent[rb] = ra
+		} else {
+			parent[ra] = rb
+		}
+	}
+
+	for i := range candidates {
+		if candidates[i].CompanyID > candidates[i].DuplicateID {
+			candidates[i].CompanyID, candidates[i].DuplicateID = candidates[i].DuplicateID, candidates[i].CompanyID
+		}
+		union(candidates[i].CompanyID, candidates[i].DuplicateID)
+	}
+
+	clusters := map[uint]struct{}{}
+	for i := range candidates {
+		candidates[i].ClusterID = find(candidates[i].CompanyID)
+		clusters[candidates[i].ClusterID] = struct{}{}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].CompanyID < candidates[j].CompanyID })
+	return len(clusters)
+}
+
+// Legal suffixes ignored when comparing company names.
+var legalSuffixes = map[string]struct{}{
+	"inc": {}, "incorporated": {}, "llc": {}, "llp": {}, "ltd": {}, "limited": {},
+	"corp": {}, "corporation": {}, "co": {}, "company": {}, "plc": {}, "gmbh": {},
+	"ag": {}, "sa": {}, "sas": {}, "srl": {}, "bv": {}, "nv": {}, "ab": {}, "oy": {},
+	"pty": {}, "pvt": {}, "private": {}, "holdings": {}, "group": {},
+}
+
+// normalizeCompanyName lowercases a name, strips punctuation, a trailing
+// domain TLD and legal suffixes: "Acme, Inc." and "acme.com" both become "acme".
+func normalizeCompanyName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !strings.Contains(name, " ") && strings.Contains(name, ".") {
+		if host, err := domain.Canonicalize(name); err == nil {
+			name = domain.Label(host)
+		}
+	}
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	out := words[:0]
+	for _, w := range words {
+		if _, legal := legalSuffixes[w]; !legal {
+			out = append(out, w)
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+func prefix(s string, n int) string {
+	r := []rune(s)
+	if len(r) > n {
+		r = r[:n]
+	}
+	return string(r)
+}
+
+// jaroWinkler returns the Jaro-Winkler similarity of two strings (0..1).
+func jaroWinkler(a, b string) float64 {
+	if a == b {
+		if a == "" {
+			return 0
+		}
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	window := max(len(ra), len(rb))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+	matchedA := make([]bool, len(ra))
+	matchedB := make([]bool, len(rb))
+	matches := 0
+	for i := range ra {
+		lo, hi := max(0, i-window), min(len(rb), i+window+1)
+		for j := lo; j < hi; j++ {
+			if !matchedB[j] && ra[i] == rb[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	j := 0
+	for i := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if ra[i] != rb[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+
+	common := 0
+	for common < min(4, len(ra), len(rb)) && ra[common] == rb[common] {
+		common++
+	}
+	return jaro + float64(common)*0.1*(1-jaro)
+}
@@ -0,0 +1,899 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/company/repositories"
+	"github.com/bhati00/Fynelo/backend/internal/fx"
+	"github.com/bhati00/Fynelo/backend/internal/geo"
+	"github.com/bhati00/Fynelo/backend/internal/headcount"
+	"github.com/bhati00/Fynelo/backend/internal/industry"
+	"github.com/bhati00/Fynelo/backend/internal/taxonomy"
+	"github.com/bhati00/Fynelo/backend/internal/techcatalog"
+	"github.com/bhati00/Fynelo/backend/pkg/actor"
+	"github.com/bhati00/Fynelo/backend/pkg/domain"
+	"gorm.io/gorm"
+)
+
+// sourcePriority ranks sources when observations conflict; unknown sources rank lowest.
+var sourcePriority = map[string]int{
+	model.SourceManual:  3,
+	model.SourceAPI:     2,
+	model.SourceScraped: 1,
+}
+
+// Confidence assumed for values stored before provenance was recorded, and
+// for observations that do not state one.
+const (
+	legacyConfidence  = 0.5
+	defaultConfidence = 0.8
+)
+
+// Share of a result's confidence given to an estimated employee count, so an
+// exact count from an equally ranked source replaces it
+const estimatedCountConfidence = 0.8
+
+var ErrInvalidSource = errors.New("source must be one of: manual, api, scraped")
+
+// EnrichmentResult is what an enrichment provider (or a manual edit or
+// import) reports about a company. Nil fields were not observed.
+type EnrichmentResult struct {
+	CompanyID  uint       `json:"company_id"` // optional; otherwise matched by website domain, then by name
+	Source     string     `json:"source" binding:"required"`
+	Provider   string     `json:"provider"`
+	Confidence float64    `json:"confidence"`  // 0..1 (default 0.8, manual 1)
+	ObservedAt *time.Time `json:"observed_at"` // default now
+
+	Name                   *string              `json:"name"`
+	Website                *string              `json:"website"`
+	HQLocation             *string              `json:"hq_location"`
+	IndustryID             *int                 `json:"industry_id"`      // otherwise classified from the fields below
+	NAICSCode              *string              `json:"naics_code"`       // kept as reported
+	SICCode                *string              `json:"sic_code"`         // kept as reported
+	Industry               *string              `json:"industry"`         // free text, kept as industry_text
+	EmployeeSizeID         *int                 `json:"employee_size_id"` // otherwise derived from the employee count
+	EmployeeCount          *int                 `json:"employee_count"`
+	Employees              *string              `json:"employees"` // headcount as reported: "250", "~250", "1k-5k", "500+"; sets employee_count
+	EmployeeCountEstimated bool                 `json:"employee_count_estimated"`
+	FoundedYear            *int                 `json:"founded_year"`
+	Status                 *model.CompanyStatus `json:"status"`
+
+	Revenues      []model.Revenue      `json:"revenues"`       // matched by year
+	FundingRounds []model.FundingRound `json:"funding_rounds"` // matched by round type and date
+	Technologies  []string             `json:"technologies"`
+	Locations     []model.Location     `json:"locations"` // matched by city, state and country; is_headquarters follows hq_location
+
+	industryConfidence float64 // of the classification IndustryID came from, if any
+}
+
+// EnrichmentOutcome reports which observed fields replaced the stored value.
+type EnrichmentOutcome struct {
+	Company  *model.Company   `json:"company"`
+	Created  bool             `json:"created"`
+	Accepted []string         `json:"accepted"`
+	Rejected []string         `json:"rejected"`
+	Industry *industry.Result `json:"industry,omitempty"` // classification of the reported codes and industry text
+}
+
+// FieldExplanation answers "why does Fynelo think this" for one field: the
+// observation behind the stored value and every other observation seen.
+type FieldExplanation struct {
+	EntityType   model.EntityType        `json:"entity_type"`
+	EntityKey    string                  `json:"entity_key,omitempty"`
+	Field        string                  `json:"field"`
+	Current      *model.FieldProvenance  `json:"current"`
+	Observations []model.FieldProvenance `json:"observations"`
+}
+
+type EnrichmentService interface {
+	// ApplyEnrichment records every observed field and stores the values that
+	// win under the conflict-resolution policy (see outranks).
+	ApplyEnrichment(ctx context.Context, result EnrichmentResult) (*EnrichmentOutcome, error)
+	ExplainCompany(ctx context.Context, companyID uint, entityType model.EntityType, field string) ([]FieldExplanation, error)
+}
+
+type enrichmentService struct {
+	companyRepo    repositories.CompanyRepository
+	revenueRepo    repositories.RevenueRepository
+	fundingRepo    repositories.FundingRepository
+	technologyRepo repositories.TechnologyRepository
+	locationRepo   repositories.LocationRepository
+	provenanceRepo repositories.ProvenanceRepository
+}
+
+func NewEnrichmentService(
+	companyRepo repositories.CompanyRepository,
+	revenueRepo repositories.RevenueRepository,
+	fundingRepo repositories.FundingRepository,
+	technologyRepo repositories.TechnologyRepository,
+	locationRepo repositories.LocationRepository,
+	provenanceRepo repositories.ProvenanceRepository,
+) EnrichmentService {
+	return &enrichmentService{
+		companyRepo:    companyRepo,
+		revenueRepo:    revenueRepo,
+		fundingRepo:    fundingRepo,
+		technologyRepo: technologyRepo,
+		locationRepo:   locationRepo,
+		provenanceRepo: provenanceRepo,
+	}
+}
+
+// outranks reports whether observation a should replace the accepted
+// observation b: the higher-priority source wins, then the higher
+// confidence, then the more recent observation.
+func outranks(a, b model.FieldProvenance) bool {
+	if pa, pb := sourcePriority[a.Source], sourcePriority[b.Source]; pa != pb {
+		return pa > pb
+	}
+	if a.Confidence != b.Confidence {
+		return a.Confidence > b.Confidence
+	}
+	return !a.ObservedAt.Before(b.ObservedAt)
+}
+
+// companyField describes how a company attribute is observed and applied.
+// confidence, when set, overrides the confidence of the result.
+type companyField struct {
+	name       string
+	value      func(r *EnrichmentResult) (string, bool)
+	stored     func(c *model.Company) bool
+	apply      func(c *model.Company, r *EnrichmentResult)
+	confidence func(r *EnrichmentResult) float64
+}
+
+var companyFields = []companyField{
+	{
+		name:   "name",
+		value:  func(r *EnrichmentResult) (string, bool) { return formatString(r.Name) },
+		stored: func(c *model.Company) bool { return c.Name != "" },
+		apply:  func(c *model.Company, r *EnrichmentResult) { c.Name = *r.Name },
+	},
+	{
+		name:   "website",
+		value:  func(r *EnrichmentResult) (string, bool) { return formatString(r.Website) },
+		stored: func(c *model.Company) bool { return c.Website != nil },
+		apply:  func(c *model.Company, r *EnrichmentResult) { c.Website = r.Website },
+	},
+	{
+		name:   "hq_location",
+		value:  func(r *EnrichmentResult) (string, bool) { return formatString(r.HQLocation) },
+		stored: func(c *model.Company) bool { return c.HQLocation != nil },
+		apply:  func(c *model.Company, r *EnrichmentResult) { c.HQLocation = r.HQLocation },
+	},
+	{
+		name:   "industry_id",
+		value:  func(r *EnrichmentResult) (string, bool) { return formatInt(r.IndustryID) },
+		stored: func(c *model.Company) bool { return c.IndustryID != nil },
+		apply:  func(c *model.Company, r *EnrichmentResult) { c.IndustryID = r.IndustryID },
+		confidence: func(r *EnrichmentResult) float64 {
+			if r.industryConfidence > 0 {
+				return r.Confidence * r.industryConfidence
+			}
+			return r.Confidence
+		},
+	},
+	{
+		name:   "naics_code",
+		value:  func(r *EnrichmentResult) (string, bool) { return formatString(r.NAICSCode) },
+		stored: func(c *model.Company) bool { return c.NAICSCode != nil },
+		apply:  func(c *model.Company, r *EnrichmentResult) { c.NAICSCode = r.NAICSCode },
+	},
+	{
+		name:   "sic_code",
+		value:  func(r *EnrichmentResult) (string, bool) { return formatString(r.SICCode) },
+		stored: func(c *model.Company) bool { return c.SICCode != nil },
+		apply:  func(c *model.Company, r *EnrichmentResult) { c.SICCode = r.SICCode },
+	},
+	{
+		name:   "industry_text",
+		value:  func(r *EnrichmentResult) (string, bool) { return formatString(r.Industry) },
+		stored: func(c *model.Company) bool { return c.IndustryText != nil },
+		apply:  func(c *model.Company, r *EnrichmentResult) { c.IndustryText = r.Industry },
+	},
+	{
+		name:   "employee_size_id",
+		value:  func(r *EnrichmentResult) (string, bool) { return formatInt(r.EmployeeSizeID) },
+		stored: func(c *model.Company) bool { return c.EmployeeSizeID != nil },
+		apply:  func(c *model.Company, r *EnrichmentResult) { c.EmployeeSizeID = r.EmployeeSizeID },
+	},
+	{
+		name: "employee_count",
+		value: func(r *EnrichmentResult) (string, bool) {
+			value, ok := formatInt(r.EmployeeCount)
+			if ok && r.EmployeeCountEstimated {
+				value = "~" + value
+			}
+			return value, ok
+		},
+		stored: func(c *model.Company) bool { return c.EmployeeCount != nil },
+		apply: func(c *model.Company, r *EnrichmentResult) {
+			c.EmployeeCount, c.EmployeeCountEstimated, c.EmployeeCountAt = r.EmployeeCount, r.EmployeeCountEstimated, r.ObservedAt
+		},
+		confidence: func(r *EnrichmentResult) float64 {
+			if r.EmployeeCountEstimated {
+				return r.Confidence * estimatedCountConfidence
+			}
+			return r.Confidence
+		},
+	},
+	{
+		name:   "founded_year",
+		value:  func(r *EnrichmentResult) (string, bool) { return formatInt(r.FoundedYear) },
+		stored: func(c *model.Company) bool { return c.FoundedYear != nil },
+		apply:  func(c *model.Company, r *EnrichmentResult) { c.FoundedYear = r.FoundedYear },
+	},
+	{
+		name: "status",
+		value: func(r *EnrichmentResult) (string, bool) {
+			if r.Status == nil {
+				return "", false
+			}
+			return string(*r.Status), true
+		},
+		stored: func(c *model.Company) bool { return c.Status != "" },
+		apply:  func(c *model.Company, r *EnrichmentResult) { c.Status = *r.Status },
+	},
+}
+
+// provenanceKey identifies the field an observation is about.
+type provenanceKey struct {
+	entityType model.EntityType
+	entityKey  string
+	field      string
+}
+
+func keyOf(p model.FieldProvenance) provenanceKey {
+	return provenanceKey{entityType: p.EntityType, entityKey: p.EntityKey, field: p.Field}
+}
+
+// resolver decides observations against the currently accepted ones and
+// collects what needs to be written.
+type resolver struct {
+	result   *EnrichmentResult
+	accepted map[provenanceKey]model.FieldProvenance
+	write    repositories.EnrichmentWrite
+	outcome  *EnrichmentOutcome
+}
+
+// observe records an observation and reports whether it wins. baseline is
+// used when the field has a stored value but no recorded provenance.
+func (rv *resolver) observe(key provenanceKey, value string, baseline *model.FieldProvenance) bool {
+	return rv.observeWith(key, value, baseline, rv.result.Confidence)
+}
+
+// observeWith is observe with a confidence other than the result's.
+func (rv *resolver) observeWith(key provenanceKey, value string, baseline *model.FieldProvenance, confidence float64) bool {
+	obs := model.FieldProvenance{
+		EntityType: key.entityType,
+		EntityKey:  key.entityKey,
+		Field:      key.field,
+		Value:      value,
+		Source:     rv.result.Source,
+		Provider:   rv.result.Provider,
+		Confidence: confidence,
+		ObservedAt: *rv.result.ObservedAt,
+	}
+
+	label := key.field
+	if key.entityType != model.EntityCompany {
+		label = fmt.Sprintf("%s[%s].%s", key.entityType, key.entityKey, key.field)
+	}
+
+	current, ok := rv.accepted[key]
+	if !ok && baseline != nil {
+		current, ok = *baseline, true
+	}
+	if ok && !outranks(obs, current) {
+		rv.write.Observations = append(rv.write.Observations, obs)
+		rv.outcome.Rejected = append(rv.outcome.Rejected, label)
+		return false
+	}
+
+	if ok && current.ID != 0 {
+		rv.write.Superseded = append(rv.write.Superseded, current.ID)
+	}
+	obs.Accepted = true
+	rv.accepted[key] = obs
+	rv.write.Observations = append(rv.write.Observations, obs)
+	rv.outcome.Accepted = append(rv.outcome.Accepted, label)
+	return true
+}
+
+func (s *enrichmentService) ApplyEnrichment(ctx context.Context, result EnrichmentResult) (*EnrichmentOutcome, error) {
+	if _, known := sourcePriority[result.Source]; !known {
+		return nil, ErrInvalidSource
+	}
+	if result.Confidence < 0 || result.Confidence > 1 {
+		return nil, errors.New("confidence must be between 0 and 1")
+	}
+	if result.Confidence == 0 {
+		result.Confidence = defaultConfidence
+		if result.Source == model.SourceManual {
+			result.Confidence = 1
+		}
+	}
+	if result.ObservedAt == nil {
+		now := time.Now()
+		result.ObservedAt = &now
+	}
+
+	if err := parseEmployees(&result); err != nil {
+		return nil, err
+	}
+	classification := classifyIndustry(&result)
+
+	// attribute history entries to the source unless a user is known
+	if actor.FromContext(ctx) == "" {
+		name := "enrichment:" + result.Source
+		if result.Provider != "" {
+			name = "enrichment:" + result.Provider
+		}
+		ctx = actor.WithActor(ctx, name)
+	}
+
+	company, err := s.findCompany(ctx, &result)
+	if err != nil {
+		return nil, err
+	}
+	outcome := &EnrichmentOutcome{Created: company == nil, Accepted: []string{}, Rejected: []string{}, Industry: classification}
+	if company == nil {
+		if result.Name == nil || *result.Name == "" {
+			return nil, errors.New("company name is required to create a company")
+		}
+		company = &model.Company{Source: result.Source}
+	}
+
+	rv := &resolver{
+		result:   &result,
+		accepted: map[provenanceKey]model.FieldProvenance{},
+		write:    repositories.EnrichmentWrite{Company: company},
+		outcome:  outcome,
+	}
+	if company.ID != 0 {
+		accepted, err := s.provenanceRepo.ListAccepted(ctx, company.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range accepted {
+			rv.accepted[keyOf(p)] = p
+		}
+	}
+	legacy := &model.FieldProvenance{Source: company.Source, Confidence: legacyConfidence, ObservedAt: company.UpdatedAt}
+
+	for _, f := range companyFields {
+		value, observed := f.value(&result)
+		if !observed {
+			continue
+		}
+		var baseline *model.FieldProvenance
+		if f.stored(company) {
+			baseline = legacy
+		}
+		confidence := result.Confidence
+		if f.confidence != nil {
+			confidence = f.confidence(&result)
+		}
+		if rv.observeWith(provenanceKey{model.EntityCompany, "", f.name}, value, baseline, confidence) {
+			f.apply(company, &result)
+		}
+	}
+	if classification != nil {
+		for _, v := range classification.Unmapped {
+			rv.write.Unmapped = append(rv.write.Unmapped, model.UnmappedIndustry{
+				Source: string(v.Source), Value: unmappedValue(v.Value), FirstSeenAt: *result.ObservedAt, LastSeenAt: *result.ObservedAt,
+			})
+		}
+	}
+
+	if err := s.checkDomain(ctx, company); err != nil {
+		return nil, err
+	}
+	if err := s.resolveChildren(ctx, rv, company, legacy); err != nil {
+		return nil, err
+	}
+	if result.Source != model.SourceManual && len(outcome.Accepted) > 0 {
+		company.LastEnrichedAt = result.ObservedAt
+	}
+
+	if err := s.provenanceRepo.SaveEnrichment(ctx, rv.write); err != nil {
+		return nil, err
+	}
+	outcome.Company = company
+	return outcome, nil
+}
+
+// classifyIndustry classifies the reported industry codes and text, and
+// uses the classification for IndustryID when the result does not state one.
+// It returns nil when nothing was reported.
+func classifyIndustry(result *EnrichmentResult) *industry.Result {
+	in := industry.Input{}
+	if result.NAICSCode != nil {
+		in.NAICS = *result.NAICSCode
+	}
+	if result.SICCode != nil {
+		in.SIC = *result.SICCode
+	}
+	if result.Industry != nil {
+		in.Text = *result.Industry
+	}
+	if in == (industry.Input{}) {
+		return nil
+	}
+	classified := industry.Classify(in)
+	if result.IndustryID == nil && classified.Classification != nil {
+		id := classified.Classification.IndustryID
+		result.IndustryID = &id
+		result.industryConfidence = classified.Classification.Confidence
+	}
+	return &classified
+}
+
+// parseEmployees reads the reported headcount into EmployeeCount and derives
+// EmployeeSizeID from the count when the result does not state one. A
+// headcount that names a company size ("51-200") only sets the size.
+func parseEmployees(result *EnrichmentResult) error {
+	var r headcount.Range
+	switch {
+	case result.Employees != nil:
+		if sizeID, ok := taxonomy.Default().Lookup(taxonomy.CompanySizes, *result.Employees); ok {
+			if result.EmployeeSizeID == nil {
+				result.EmployeeSizeID = &sizeID
+			}
+			return nil
+		}
+		parsed, err := headcount.Parse(*result.Employees)
+		if err != nil {
+			return err
+		}
+		r = parsed
+		if result.EmployeeCount == nil {
+			count := r.Count()
+			result.EmployeeCount, result.EmployeeCountEstimated = &count, r.Estimated
+		}
+	case result.EmployeeCount != nil:
+		if *result.EmployeeCount < 1 || *result.EmployeeCount > headcount.MaxCount {
+			return headcount.ErrInvalid
+		}
+		r = headcount.Exact(*result.EmployeeCount)
+		r.Estimated = result.EmployeeCountEstimated
+	default:
+		return nil
+	}
+	if result.EmployeeSizeID == nil {
+		if sizeID, ok := headcount.Bucket(r); ok {
+			result.EmployeeSizeID = &sizeID
+		}
+	}
+	return nil
+}
+
+// unmappedValue lowercases an unmapped value and cuts it to the 200
+// characters stored.
+func unmappedValue(value string) string {
+	value = strings.ToLower(value)
+	if runes := []rune(value); len(runes) > 200 {
+		value = string(runes[:200])
+	}
+	return value
+}
+
+// findCompany locates the company an enrichment result is about, or returns
+// nil when it describes a company we do not have yet.
+func (s *enrichmentService) findCompany(ctx context.Context, result *EnrichmentResult) (*model.Company, error) {
+	var (
+		company *model.Company
+		err     error
+	)
+	switch {
+	case result.CompanyID != 0:
+		return s.companyRepo.FindByID(ctx, result.CompanyID)
+	case result.Website != nil:
+		host, herr := domain.Canonicalize(*result.Website)
+		if herr != nil {
+			return nil, herr
+		}
+		company, err = s.companyRepo.FindByDomain(ctx, host)
+	case result.Name != nil:
+		company, err = s.companyRepo.FindByName(ctx, *result.Name)
+	default:
+		return nil, errors.New("company_id, website or name is required")
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return company, err
+}
+
+// checkDomain fails with a DomainConflictError when the website a company is
+// about to be saved with changed and belongs to another company.
+func (s *enrichmentService) checkDomain(ctx context.Context, company *model.Company) error {
+	if company.Website == nil || !company.WebsiteChanged() {
+		return nil
+	}
+	host, err := domain.Canonicalize(*company.Website)
+	if err != nil {
+		return nil // saved without a domain
+	}
+	existing, err := s.companyRepo.FindByDomain(ctx, host)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return nil
+	case err != nil:
+		return err
+	case existing.ID != company.ID:
+		return &DomainConflictError{Domain: host, CompanyID: existing.ID}
+	}
+	return nil
+}
+
+// resolveChildren applies observed revenues (per year), funding rounds (per
+// round type and date), technologies (per name) and locations (per place).
+func (s *enrichmentService) resolveChildren(ctx context.Context, rv *resolver, company *model.Company, legacy *model.FieldProvenance) error {
+	var (
+		revenues  []model.Revenue
+		rounds    []model.FundingRound
+		techs     []model.Technology
+		locations []model.Location
+		err       error
+	)
+	if company.ID != 0 {
+		if revenues, err = s.revenueRepo.FindByCompanyID(ctx, company.ID); err != nil {
+			return err
+		}
+		if rounds, err = s.fundingRepo.FindByCompanyID(ctx, company.ID); err != nil {
+			return err
+		}
+		if techs, err = s.technologyRepo.FindByCompanyID(ctx, company.ID); err != nil {
+			return err
+		}
+		if locations, err = s.locationRepo.FindByCompanyID(ctx, company.ID); err != nil {
+			return err
+		}
+	}
+
+	for _, observed := range rv.result.Revenues {
+		if observed.Year == 0 || observed.Currency == "" {
+			return errors.New("revenues need a year and currency")
+		}
+		observed.Currency = fx.Normalize(observed.Currency)
+		if !fx.Default().Known(observed.Currency) {
+			return fmt.Errorf("%w: %q", fx.ErrUnknownCurrency, observed.Currency)
+		}
+		row := model.Revenue{Year: observed.Year}
+		var baseline *model.FieldProvenance
+		for _, existing := range revenues {
+			if existing.Year == observed.Year {
+				row, baseline = existing, legacy
+				break
+			}
+		}
+		key := provenanceKey{model.EntityRevenue, strconv.Itoa(observed.Year), "amount"}
+		if rv.observe(key, fmt.Sprintf("%.2f %s", observed.Amount, observed.Currency), baseline) {
+			row.Amount, row.Currency = observed.Amount, observed.Currency
+			rv.write.Revenues = append(rv.write.Revenues, row)
+		}
+	}
+
+	for _, observed := range rv.result.FundingRounds {
+		if observed.RoundType == "" {
+			return errors.New("funding rounds need a round_type")
+		}
+		if observed.Currency != "" {
+			observed.Currency = fx.Normalize(observed.Currency)
+			if !fx.Default().Known(observed.Currency) {
+				return fmt.Errorf("%w: %q", fx.ErrUnknownCurrency, observed.Currency)
+			}
+		}
+		row := model.FundingRound{RoundType: observed.RoundType}
+		var baseline *model.FieldProvenance
+		if existing := matchRound(rounds, observed); existing != nil {
+			row, baseline = *existing, legacy
+		}
+		if observed.Date != nil {
+			row.Date = observed.Date
+		}
+		key := provenanceKey{model.EntityFundingRound, roundKey(row), "amount"}
+		if rv.observe(key, formatFundingRound(observed), baseline) {
+			row.Amount = observed.Amount
+			if observed.Currency != "" {
+				row.Currency = observed.Currency
+			}
+			if observed.Investors != "" {
+				row.Investors = observed.Investors
+			}
+			rv.write.FundingRounds = append(rv.write.FundingRounds, row)
+		}
+	}
+
+	have := make(map[string]struct{}, len(techs))
+	for _, t := range techs {
+		have[strings.ToLower(t.TechnologyName)] = struct{}{}
+	}
+	for _, name := range rv.result.Technologies {
+		name = techcatalog.Default().Canonical(name)
+		if name == "" {
+			continue
+		}
+		lower := strings.ToLower(name)
+		_, exists := have[lower]
+		var baseline *model.FieldProvenance
+		if exists {
+			baseline = legacy
+		}
+		if rv.observe(provenanceKey{model.EntityTechnology, lower, "technology_name"}, name, baseline) && !exists {
+			rv.write.Technologies = append(rv.write.Technologies, model.Technology{TechnologyName: name})
+			have[lower] = struct{}{}
+		}
+	}
+
+	// an accepted hq_location is observed as a location too, and that
+	// location alone is flagged as the headquarters
+	observedLocations := rv.result.Locations
+	hqKey := ""
+	if rv.result.HQLocation != nil && company.HQLocation != nil && *company.HQLocation == *rv.result.HQLocation {
+		if hq, ok := parseHeadquarters(*company.HQLocation); ok {
+			hqKey = locationKey(hq)
+			observedLocations = append(observedLocations[:len(observedLocations):len(observedLocations)], hq)
+		}
+	}
+
+	seen := map[string]struct{}{}
+	for _, observed := range observedLocations {
+		key := locationKey(observed)
+		if key == "" {
+			return errors.New("locations need a city, state, country or address")
+		}
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		row := model.Location{}
+		var baseline *model.FieldProvenance
+		for _, existing := range locations {
+			if locationKey(existing) == key {
+				row, baseline = existing, legacy
+				break
+			}
+		}
+		changed := false
+		if rv.observe(provenanceKey{model.EntityLocation, key, "address"}, formatLocation(observed), baseline) {
+			applyLocation(&row, &observed)
+			changed = true
+		}
+		if hqKey != "" && row.IsHeadquarters != (key == hqKey) {
+			row.IsHeadquarters = key == hqKey
+			changed = true
+		}
+		if changed {
+			rv.write.Locations = append(rv.write.Locations, row)
+		}
+	}
+	if hqKey != "" {
+		for _, existing := range locations {
+			if _, done := seen[locationKey(existing)]; !done && existing.IsHeadquarters {
+				existing.IsHeadquarters = false
+				rv.write.Locations = append(rv.write.Locations, existing)
+			}
+		}
+	}
+	return nil
+}
+
+// matchRound finds the stored round an observed one is about: the round of
+// the same type on the same day or, when either date is unknown, the only
+// round of that type that could be it.
+func matchRound(rounds []model.FundingRound, observed model.FundingRound) *model.FundingRound {
+	var candidates []*model.FundingRound
+	for i := range rounds {
+		existing := &rounds[i]
+		if existing.RoundType != observed.RoundType {
+			continue
+		}
+		switch {
+		case existing.Date != nil && observed.Date != nil:
+			if sameDay(*existing.Date, *observed.Date) {
+				return existing
+			}
+		case existing.Date == nil && observed.Date == nil:
+			return existing
+		default:
+			candidates = append(candidates, existing)
+		}
+	}
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+	return nil
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.UTC().Format("2006-01-02") == b.UTC().Format("2006-01-02")
+}
+
+// roundKey is the provenance key of a funding round: its type, and its date
+// when known, so rounds of one type on different dates are kept apart.
+func roundKey(f model.FundingRound) string {
+	if f.Date == nil {
+		return string(f.RoundType)
+	}
+	return string(f.RoundType) + "@" + f.Date.UTC().Format("2006-01-02")
+}
+
+// locationKey identifies the place a location is in: its city with the
+// region and country codes the gazetteer resolves, falling back to the text
+// as reported. Locations without a city, state or country are keyed by
+// address.
+func locationKey(l model.Location) string {
+	city, state, country := tidy(l.City), tidy(l.State), tidy(l.Country)
+	if city == "" && state == "" && country == "" {
+		return tidy(l.Address)
+	}
+	place := geo.Default().Normalize(city, state, country)
+	if place.RegionCode != "" {
+		state = strings.ToLower(place.RegionCode)
+	}
+	if place.CountryCode != "" {
+		country = strings.ToLower(place.CountryCode)
+	}
+	return city + "|" + state + "|" + country
+}
+
+// tidy lowercases s and collapses its whitespace.
+func tidy(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return strings.ToLower(strings.Join(strings.Fields(*s), " "))
+}
+
+// parseHeadquarters reads an hq_location such as "Berlin", "Berlin, Germany",
+// "Austin, TX" or "Austin, Texas, USA" as a location.
+func parseHeadquarters(text string) (model.Location, bool) {
+	var parts []string
+	for _, p := range strings.Split(text, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	gazetteer := geo.Default()
+	var loc model.Location
+	switch n := len(parts); {
+	case n == 0:
+		return loc, false
+	case n == 1:
+		place := gazetteer.ParsePlace(parts[0])
+		switch {
+		case place.City == nil && place.RegionCode != "":
+			loc.State = &parts[0]
+		case place.City == nil && place.CountryCode != "":
+			loc.Country = &parts[0]
+		default:
+			loc.City = &parts[0]
+		}
+	case n == 2:
+		loc.City = &parts[0]
+		if _, ok := gazetteer.Country(parts[1]); ok {
+			loc.Country = &parts[1]
+		} else {
+			loc.State = &parts[1]
+		}
+	default:
+		loc.City, loc.State, loc.Country = &parts[0], &parts[n-2], &parts[n-1]
+	}
+	return loc, true
+}
+
+// HeadquartersIndex returns the index of the location hqLocation names, or
+// -1 when none of them is it.
+func HeadquartersIndex(locations []model.Location, hqLocation string) int {
+	hq, ok := parseHeadquarters(hqLocation)
+	if !ok {
+		return -1
+	}
+	key := locationKey(hq)
+	for i, l := range locations {
+		if locationKey(l) == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// applyLocation copies the observed parts of a location onto row.
+func applyLocation(row, observed *model.Location) {
+	for _, f := range []struct{ dst, src **string }{
+		{&row.Address, &observed.Address},
+		{&row.City, &observed.City},
+		{&row.State, &observed.State},
+		{&row.Country, &observed.Country},
+		{&row.PostalCode, &observed.PostalCode},
+		{&row.CountryCode, &observed.CountryCode},
+		{&row.RegionCode, &observed.RegionCode},
+	} {
+		if *f.src != nil {
+			*f.dst = *f.src
+		}
+	}
+	if observed.Latitude != nil && observed.Longitude != nil {
+		row.Latitude, row.Longitude = observed.Latitude, observed.Longitude
+	}
+}
+
+func (s *enrichmentService) ExplainCompany(ctx context.Context, companyID uint, entityType model.EntityType, field string) ([]FieldExplanation, error) {
+	if _, err := s.companyRepo.FindByID(ctx, companyID); err != nil {
+		return nil, err
+	}
+	observations, err := s.provenanceRepo.ListByCompany(ctx, companyID, entityType, field)
+	if err != nil {
+		return nil, err
+	}
+
+	// observations arrive grouped by field, accepted first
+	explanations := []FieldExplanation{}
+	index := map[provenanceKey]int{}
+	for _, p := range observations {
+		key := keyOf(p)
+		i, ok := index[key]
+		if !ok {
+			i = len(explanations)
+			index[key] = i
+			explanations = append(explanations, FieldExplanation{
+				EntityType: p.EntityType,
+				EntityKey:  p.EntityKey,
+				Field:      p.Field,
+			})
+		}
+		explanations[i].Observations = append(explanations[i].Observations, p)
+		if p.Accepted && explanations[i].Current == nil {
+			current := p
+			explanations[i].Current = &current
+		}
+	}
+	return explanations, nil
+}
+
+func formatString(s *string) (string, bool) {
+	if s == nil {
+		return "", false
+	}
+	return *s, true
+}
+
+func formatInt(i *int) (string, bool) {
+	if i == nil {
+		return "", false
+	}
+	return strconv.Itoa(*i), true
+}
+
+func formatLocation(l model.Location) string {
+	parts := []string{}
+	for _, p := range []*string{l.Address, l.City, l.State, l.PostalCode, l.Country} {
+		if p != nil && strings.TrimSpace(*p) != "" {
+			parts = append(parts, strings.TrimSpace(*p))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatFundingRound(f model.FundingRound) string {
+	amount := "undisclosed"
+	if f.Amount != nil {
+		currency := f.Currency
+		if currency == "" {
+			currency = "USD"
+		}
+		amount = fmt.Sprintf("%.2f %s", *f.Amount, currency)
+	}
+	if f.Date != nil {
+		amount += " on " + f.Date.Format("2006-01-02")
+	}
+	return amount
+}
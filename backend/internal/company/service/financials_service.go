@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/company/repositories"
+	"github.com/bhati00/Fynelo/backend/internal/fx"
+)
+
+// ConvertedAmount is a reported amount and its value in the requested
+// currency. Converted is nil when the amount is undisclosed or its currency
+// has no FX rate.
+type ConvertedAmount struct {
+	Amount    *float64   `json:"amount"`
+	Currency  string     `json:"currency"`
+	Converted *float64   `json:"converted"`
+	Rate      *float64   `json:"rate,omitempty"`      // units of the requested currency per unit of Currency
+	RateDate  *time.Time `json:"rate_date,omitempty"` // date of the FX table entry used
+}
+
+type ConvertedRevenue struct {
+	Year int `json:"year"`
+	ConvertedAmount
+}
+
+type ConvertedFundingRound struct {
+	ID        uint                   `json:"id"`
+	RoundType model.FundingRoundType `json:"round_type"`
+	Date      *time.Time             `json:"date"`
+	Investors string                 `json:"investors"`
+	ConvertedAmount
+}
+
+// FinancialsResponse is a company's revenue (oldest year first) and funding
+// history (oldest round first) in one currency.
+type FinancialsResponse struct {
+	CompanyID     uint                    `json:"company_id"`
+	Currency      string                  `json:"currency"`
+	Revenues      []ConvertedRevenue      `json:"revenues"`
+	FundingRounds []ConvertedFundingRound `json:"funding_rounds"`
+	TotalFunding  float64                 `json:"total_funding"` // of the converted rounds
+}
+
+type FinancialsService interface {
+	// Financials converts a company's revenues at the rate of the middle of
+	// each year and its funding rounds at the rate of their dates.
+	Financials(ctx context.Context, companyID uint, currency string) (*FinancialsResponse, error)
+	Currencies() []string
+}
+
+type financialsService struct {
+	companyRepo repositories.CompanyRepository
+	revenueRepo repositories.RevenueRepository
+	fundingRepo repositories.FundingRepository
+}
+
+func NewFinancialsService(companyRepo repositories.CompanyRepository, revenueRepo repositories.RevenueRepository, fundingRepo repositories.FundingRepository) FinancialsService {
+	return &financialsService{companyRepo: companyRepo, revenueRepo: revenueRepo, fundingRepo: fundingRepo}
+}
+
+func (s *financialsService) Currencies() []string {
+	return fx.Default().Currencies()
+}
+
+func (s *financialsService) Financials(ctx context.Context, companyID uint, currency string) (*FinancialsResponse, error) {
+	rates := fx.Default()
+	currency = fx.Normalize(currency)
+	if currency == "" {
+		currency = fx.USD
+	}
+	if !rates.Known(currency) {
+		return nil, fx.ErrUnknownCurrency
+	}
+	if _, err := s.companyRepo.FindByID(ctx, companyID); err != nil {
+		return nil, err
+	}
+	revenues, err := s.revenueRepo.FindByCompanyID(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	rounds, err := s.fundingRepo.FindByCompanyID(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &FinancialsResponse{
+		CompanyID:     companyID,
+		Currency:      currency,
+		Revenues:      make([]ConvertedRevenue, 0, len(revenues)),
+		FundingRounds: make([]ConvertedFundingRound, 0, len(rounds)),
+	}
+
+	sort.Slice(revenues, func(i, j int) bool { return revenues[i].Year < revenues[j].Year })
+	for _, r := range revenues {
+		amount := r.Amount
+		resp.Revenues = append(resp.Revenues, ConvertedRevenue{
+			Year:            r.Year,
+			ConvertedAmount: convert(rates, &amount, r.Currency, currency, r.RateDate()),
+		})
+	}
+
+	sort.SliceStable(rounds, func(i, j int) bool { return rounds[i].RateDate().Before(rounds[j].RateDate()) })
+	for _, f := range rounds {
+		from := f.Currency
+		if from == "" {
+			from = fx.USD
+		}
+		converted := convert(rates, f.Amount, from, currency, f.RateDate())
+		if converted.Converted != nil {
+			resp.TotalFunding += *converted.Converted
+		}
+		resp.FundingRounds = append(resp.FundingRounds, ConvertedFundingRound{
+			ID:              f.ID,
+			RoundType:       f.RoundType,
+			Date:            f.Date,
+			Investors:       f.Investors,
+			ConvertedAmount: converted,
+		})
+	}
+	return resp, nil
+}
+
+func convert(rates *fx.Table, amount *float64, from, to string, on time.Time) ConvertedAmount {
+	out := ConvertedAmount{Amount: amount, Currency: from}
+	if amount == nil {
+		return out
+	}
+	value, rate, err := rates.Convert(*amount, from, to, on)
+	if err != nil {
+		return out
+	}
+	out.Converted, out.Rate = &value, &rate.Value
+	if !rate.Date.IsZero() {
+		out.RateDate = &rate.Date
+	}
+	return out
+}
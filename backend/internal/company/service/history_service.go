@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/company/repositories"
+)
+
+// ErrNotExisted is returned for snapshots taken before a company was created
+// or after it was deleted.
+var ErrNotExisted = errors.New("company did not exist at that time")
+
+// CompanySnapshot is a company and its child rows as they were at AsOf.
+type CompanySnapshot struct {
+	AsOf    time.Time     `json:"as_of"`
+	Company model.Company `json:"company"`
+}
+
+type HistoryService interface {
+	ListHistory(ctx context.Context, companyID uint, filter repositories.HistoryFilter, limit, offset int) ([]model.CompanyChange, error)
+	// SnapshotAt rebuilds a company as of t by undoing every later change.
+	SnapshotAt(ctx context.Context, companyID uint, t time.Time) (*CompanySnapshot, error)
+}
+
+type historyService struct {
+	repo repositories.HistoryRepository
+}
+
+func NewHistoryService(repo repositories.HistoryRepository) HistoryService {
+	return &historyService{repo: repo}
+}
+
+func (s *historyService) ListHistory(ctx context.Context, companyID uint, filter repositories.HistoryFilter, limit, offset int) ([]model.CompanyChange, error) {
+	return s.repo.List(ctx, companyID, filter, limit, offset)
+}
+
+// rowState is an entity's JSON fields while changes are being undone.
+type rowState struct {
+	fields  map[string]json.RawMessage
+	live    bool // not deleted at the point in time
+	created time.Time
+}
+
+type rowRef struct {
+	entity model.EntityType
+	id     uint
+}
+
+func (s *historyService) SnapshotAt(ctx context.Context, companyID uint, t time.Time) (*CompanySnapshot, error) {
+	rows, err := s.repo.LoadCompanyRows(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := s.repo.ListAfter(ctx, companyID, t)
+	if err != nil {
+		return nil, err
+	}
+
+	company, err := newRowState(&rows.Company, !rows.CompanyDeleted, rows.Company.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	children := map[rowRef]*rowState{}
+	add := func(entity model.EntityType, id uint, row interface{}, created time.Time) error {
+		state, err := newRowState(row, !rows.Deleted[entity][id], created)
+		children[rowRef{entity, id}] = state
+		return err
+	}
+	for i := range rows.Revenues {
+		if err := add(model.EntityRevenue, rows.Revenues[i].ID, &rows.Revenues[i], rows.Revenues[i].CreatedAt); err != nil {
+			return nil, err
+		}
+	}
+	for i := range rows.FundingRounds {
+		if err := add(model.EntityFundingRound, rows.FundingRounds[i].ID, &rows.FundingRounds[i], rows.FundingRounds[i].CreatedAt); err != nil {
+			return nil, err
+		}
+	}
+	for i := range rows.Technologies {
+		if err := add(model.EntityTechnology, rows.Technologies[i].ID, &rows.Technologies[i], rows.Technologies[i].CreatedAt); err != nil {
+			return nil, err
+		}
+	}
+	for i := range rows.Locations {
+		if err := add(model.EntityLocation, rows.Locations[i].ID, &rows.Locations[i], rows.Locations[i].CreatedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	// changes arrive newest first; undo them one by one
+	for _, change := range changes {
+		state := company
+		if change.EntityType != model.EntityCompany {
+			ref := rowRef{change.EntityType, change.EntityID}
+			state = children[ref]
+			if state == nil {
+				if change.Operation != model.ChangeDelete || change.OldValue == nil {
+					continue
+				}
+				// a deleted row we could not load, e.g. one removed outright
+				state = &rowState{fields: map[string]json.RawMessage{}}
+				children[ref] = state
+			}
+		}
+		undo(state, change)
+	}
+
+	if !company.live || company.created.After(t) {
+		return nil, ErrNotExisted
+	}
+	snapshot := &CompanySnapshot{AsOf: t}
+	if err := company.decode(&snapshot.Company); err != nil {
+		return nil, err
+	}
+
+	refs := make([]rowRef, 0, len(children))
+	for ref := range children {
+		refs = append(refs, ref)
+	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i].id < refs[j].id })
+
+	c := &snapshot.Company
+	for _, ref := range refs {
+		state := children[ref]
+		if !state.live || state.created.After(t) || !state.belongsTo(companyID) {
+			continue
+		}
+		var err error
+		switch ref.entity {
+		case model.EntityRevenue:
+			var row model.Revenue
+			err = state.decode(&row)
+			c.Revenues = append(c.Revenues, row)
+		case model.EntityFundingRound:
+			var row model.FundingRound
+			err = state.decode(&row)
+			c.FundingRounds = append(c.FundingRounds, row)
+		case model.EntityTechnology:
+			var row model.Technology
+			err = state.decode(&row)
+			c.Technologies = append(c.Technologies, row)
+		case model.EntityLocation:
+			var row model.Location
+			err = state.decode(&row)
+			c.Locations = append(c.Locations, row)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return snapshot, nil
+}
+
+func newRowState(row interface{}, live bool, created time.Time) (*rowState, error) {
+	data, err := json.Marshal(row)
+	if err != nil {
+		return nil, err
+	}
+	state := &rowState{fields: map[string]json.RawMessage{}, live: live, created: created}
+	if err := json.Unmarshal(data, &state.fields); err != nil {
+		return nil, err
+	}
+	delete(state.fields, "company")
+	return state, nil
+}
+
+// undo reverts a single change on state.
+func undo(state *rowState, change model.CompanyChange) {
+	switch change.Operation {
+	case model.ChangeCreate:
+		state.live = false
+	case model.ChangeDelete:
+		state.live = true
+		if change.OldValue != nil {
+			var fields map[string]json.RawMessage
+			if json.Unmarshal([]byte(*change.OldValue), &fields) == nil {
+				for field, value := range fields {
+					state.fields[field] = value
+				}
+			}
+		}
+		if _, ok := state.fields["id"]; !ok {
+			state.fields["id"], _ = json.Marshal(change.EntityID)
+		}
+	case model.ChangeUpdate:
+		if change.OldValue == nil {
+			delete(state.fields, change.Field)
+			return
+		}
+		state.fields[change.Field] = json.RawMessage(*change.OldValue)
+	}
+}
+
+func (r *rowState) belongsTo(companyID uint) bool {
+	var owner uint
+	if err := json.Unmarshal(r.fields["company_id"], &owner); err != nil {
+		return true
+	}
+	return owner == companyID
+}
+
+func (r *rowState) decode(v interface{}) error {
+	data, err := json.Marshal(r.fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/company/repositories"
+	"github.com/bhati00/Fynelo/backend/internal/industry"
+)
+
+// Provider recorded for industries set by reclassification
+const classifierProvider = "industry-classifier"
+
+var (
+	ErrInvalidIndustrySource = errors.New("source must be one of: naics, sic, text")
+	ErrUnmappedNotFound      = errors.New("unmapped industry value not found")
+)
+
+// ReclassifyResult reports a reclassification against the current taxonomy.
+type ReclassifyResult struct {
+	Resolved  int `json:"resolved"`  // unmapped values that now map, removed from the list
+	Companies int `json:"companies"` // companies that were given an industry or moved out of Other
+}
+
+type IndustryService interface {
+	Classify(in industry.Input) industry.Result
+	ListUnmapped(ctx context.Context, source string, limit, offset int) ([]model.UnmappedIndustry, int64, error)
+	DismissUnmapped(ctx context.Context, id uint) error
+	// Reclassify retries the unmapped values and the companies without an
+	// industry or filed under Other, typically after taxonomy aliases were
+	// added for them.
+	Reclassify(ctx context.Context) (*ReclassifyResult, error)
+}
+
+type industryService struct {
+	repo       repositories.IndustryRepository
+	enrichment EnrichmentService
+}
+
+func NewIndustryService(repo repositories.IndustryRepository, enrichment EnrichmentService) IndustryService {
+	return &industryService{repo: repo, enrichment: enrichment}
+}
+
+func (s *industryService) Classify(in industry.Input) industry.Result {
+	return industry.Classify(in)
+}
+
+func (s *industryService) ListUnmapped(ctx context.Context, source string, limit, offset int) ([]model.UnmappedIndustry, int64, error) {
+	switch industry.Source(source) {
+	case "", industry.SourceNAICS, industry.SourceSIC, industry.SourceText:
+	default:
+		return nil, 0, ErrInvalidIndustrySource
+	}
+	return s.repo.ListUnmapped(ctx, source, limit, offset)
+}
+
+func (s *industryService) DismissUnmapped(ctx context.Context, id uint) error {
+	deleted, err := s.repo.DeleteUnmapped(ctx, []uint{id})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrUnmappedNotFound
+	}
+	return nil
+}
+
+func (s *industryService) Reclassify(ctx context.Context) (*ReclassifyResult, error) {
+	result := &ReclassifyResult{}
+
+	unmapped, err := s.repo.AllUnmapped(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var resolved []uint
+	for _, u := range unmapped {
+		var in industry.Input
+		switch industry.Source(u.Source) {
+		case industry.SourceNAICS:
+			in.NAICS = u.Value
+		case industry.SourceSIC:
+			in.SIC = u.Value
+		default:
+			in.Text = u.Value
+		}
+		if industry.Classify(in).Classification != nil {
+			resolved = append(resolved, u.ID)
+		}
+	}
+	deleted, err := s.repo.DeleteUnmapped(ctx, resolved)
+	if err != nil {
+		return nil, err
+	}
+	result.Resolved = int(deleted)
+
+	companies, err := s.repo.Unclassified(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range companies {
+		classified := industry.Classify(industry.Input{NAICS: deref(c.NAICSCode), SIC: deref(c.SICCode), Text: deref(c.IndustryText)})
+		if classified.Classification == nil || (c.IndustryID != nil && *c.IndustryID == classified.Classification.IndustryID) {
+			continue
+		}
+		// the classification ranks like the data it was made from
+		source := c.Source
+		if _, known := sourcePriority[source]; !known {
+			source = model.SourceScraped
+		}
+		id := classified.Classification.IndustryID
+		if _, err := s.enrichment.ApplyEnrichment(ctx, EnrichmentResult{
+			CompanyID:  c.ID,
+			Source:     source,
+			Provider:   classifierProvider,
+			Confidence: classified.Classification.Confidence,
+			IndustryID: &id,
+		}); err != nil {
+			return nil, err
+		}
+		result.Companies++
+	}
+	return result, nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
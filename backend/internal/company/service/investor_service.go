@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/company/repositories"
+)
+
+// Most suggestions returned by autocomplete
+const maxInvestorSuggestions = 20
+
+// PortfolioCompany is a company an investor backed and the rounds it took
+// part in, newest first.
+type PortfolioCompany struct {
+	Company   model.Company                 `json:"company"`
+	Lead      bool                          `json:"lead"` // led at least one of the rounds
+	Rounds    []repositories.PortfolioRound `json:"rounds"`
+	LastRound *repositories.PortfolioRound  `json:"last_round"`
+}
+
+// InvestorProfile is an investor and its portfolio, companies most recently
+// backed first.
+type InvestorProfile struct {
+	Investor   model.Investor     `json:"investor"`
+	Companies  int                `json:"companies"`
+	Rounds     int                `json:"rounds"`
+	LeadRounds int                `json:"lead_rounds"`
+	TotalUSD   float64            `json:"total_usd"` // disclosed size of the rounds taken part in
+	Portfolio  []PortfolioCompany `json:"portfolio"`
+}
+
+type InvestorService interface {
+	Search(ctx context.Context, query string, limit, offset int) ([]repositories.InvestorSummary, int64, error)
+	Autocomplete(ctx context.Context, prefix string, limit int) ([]model.Investor, error)
+	Profile(ctx context.Context, id uint) (*InvestorProfile, error)
+}
+
+type investorService struct {
+	repo repositories.InvestorRepository
+}
+
+func NewInvestorService(repo repositories.InvestorRepository) InvestorService {
+	return &investorService{repo: repo}
+}
+
+func (s *investorService) Search(ctx context.Context, query string, limit, offset int) ([]repositories.InvestorSummary, int64, error) {
+	return s.repo.Search(ctx, query, limit, offset)
+}
+
+func (s *investorService) Autocomplete(ctx context.Context, prefix string, limit int) ([]model.Investor, error) {
+	if limit <= 0 || limit > maxInvestorSuggestions {
+		limit = maxInvestorSuggestions
+	}
+	return s.repo.Autocomplete(ctx, prefix, limit)
+}
+
+func (s *investorService) Profile(ctx context.Context, id uint) (*InvestorProfile, error) {
+	investor, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	rounds, companies, err := s.repo.Portfolio(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint]model.Company, len(companies))
+	for _, c := range companies {
+		byID[c.ID] = c
+	}
+
+	profile := &InvestorProfile{Investor: *investor, Rounds: len(rounds), Portfolio: []PortfolioCompany{}}
+	index := map[uint]int{}
+	// rounds come newest first, so companies are ordered by their last round
+	for _, round := range rounds {
+		if round.Lead {
+			profile.LeadRounds++
+		}
+		if round.AmountUSD != nil {
+			profile.TotalUSD += *round.AmountUSD
+		}
+		i, ok := index[round.CompanyID]
+		if !ok {
+			i = len(profile.Portfolio)
+			index[round.CompanyID] = i
+			profile.Portfolio = append(profile.Portfolio, PortfolioCompany{Company: byID[round.CompanyID]})
+		}
+		entry := &profile.Portfolio[i]
+		entry.Rounds = append(entry.Rounds, round)
+		entry.Lead = entry.Lead || round.Lead
+	}
+	for i := range profile.Portfolio {
+		profile.Portfolio[i].LastRound = &profile.Portfolio[i].Rounds[0]
+	}
+	profile.Companies = len(profile.Portfolio)
+	return profile, nil
+}
@@ -3,9 +3,11 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/bhati00/Fynelo/backend/internal/company/model"
 	"github.com/bhati00/Fynelo/backend/internal/company/repositories"
+	"github.com/bhati00/Fynelo/backend/internal/techcatalog"
 )
 
 type TechnologyService interface {
@@ -13,6 +15,10 @@ type TechnologyService interface {
 	GetTechnologiesByCompany(ctx context.Context, companyID uint) ([]model.Technology, error)
 	DeleteTechnology(ctx context.Context, id uint) error
 	ReplaceTechnologiesForCompany(ctx context.Context, companyID uint, names []string) error
+	Categories() []techcatalog.Category
+	// Catalog lists the catalog technologies of a category code or name, or
+	// all of them when category is empty.
+	Catalog(category string) ([]techcatalog.Technology, error)
 }
 
 type technologyService struct {
@@ -51,3 +57,18 @@ func (s *technologyService) ReplaceTechnologiesForCompany(ctx context.Context, c
 	}
 	return s.repo.ReplaceForCompany(ctx, companyID, names)
 }
+
+func (s *technologyService) Categories() []techcatalog.Category {
+	return techcatalog.Default().Categories()
+}
+
+func (s *technologyService) Catalog(category string) ([]techcatalog.Technology, error) {
+	if category == "" {
+		return techcatalog.Default().Technologies(""), nil
+	}
+	c, ok := techcatalog.Default().Category(category)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTechCategory, category)
+	}
+	return techcatalog.Default().Technologies(c.Code), nil
+}
@@ -0,0 +1,43 @@
+package company
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/service"
+	"github.com/gin-gonic/gin"
+)
+
+// ListTechnologyCategoriesHandler godoc
+// @Summary List technology categories
+// @Description Lists the categories of the technology catalog, accepted by the tech_category search filter and query field
+// @Tags Companies
+// @Produce json
+// @Success 200 {object} map[string][]techcatalog.Category
+// @Router /companies/technologies/categories [get]
+func (h *Handler) ListTechnologyCategoriesHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"categories": h.technologyService.Categories()})
+}
+
+// ListTechnologyCatalogHandler godoc
+// @Summary List catalog technologies
+// @Description Lists the technologies of the catalog with their aliases, category and vendor. Technology names reported by imports and enrichment are stored in their catalog form, so "k8s" is stored as Kubernetes
+// @Tags Companies
+// @Produce json
+// @Param category query string false "Category code or name, e.g. crm"
+// @Success 200 {object} map[string][]techcatalog.Technology
+// @Failure 400 {object} map[string]string
+// @Router /companies/technologies/catalog [get]
+func (h *Handler) ListTechnologyCatalogHandler(c *gin.Context) {
+	techs, err := h.technologyService.Catalog(c.Query("category"))
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownTechCategory) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "categories": h.technologyService.Categories()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list technologies"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"technologies": techs})
+}
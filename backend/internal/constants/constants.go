@@ -70,119 +70,50 @@ const (
 	BuyerRoleOther             = 10
 )
 
-// Maps for string conversions
-var IndustryNames = map[int]string{
-	IndustryTechnology:     "Technology",
-	IndustryFintech:        "Fintech",
-	IndustryHealthcare:     "Healthcare",
-	IndustryEducation:      "Education",
-	IndustryECommerce:      "E-commerce",
-	IndustryManufacturing:  "Manufacturing",
-	IndustryRealEstate:     "Real Estate",
-	IndustryConsulting:     "Consulting",
-	IndustryMarketing:      "Marketing",
-	IndustryRetail:         "Retail",
-	IndustryLogistics:      "Logistics",
-	IndustryEnergy:         "Energy",
-	IndustryMedia:          "Media",
-	IndustryGaming:         "Gaming",
-	IndustryAutomotive:     "Automotive",
-	IndustryAgriculture:    "Agriculture",
-	IndustryAerospace:      "Aerospace",
-	IndustryTelecom:        "Telecom",
-	IndustryBiotechnology:  "Biotechnology",
-	IndustryCybersecurity:  "Cybersecurity",
-	IndustryAI:             "Artificial Intelligence",
-	IndustryBlockchain:     "Blockchain",
-	IndustryCloudComputing: "Cloud Computing",
-	IndustryInsurance:      "Insurance",
-	IndustryBanking:        "Banking",
-	IndustryFoodBeverage:   "Food & Beverage",
-	IndustryTravel:         "Travel",
-	IndustryFashion:        "Fashion",
-	IndustryOther:          "Other",
-}
+// Revenue bands (annual revenue in USD)
+const (
+	RevenueBandUnder1M   = 1 // "<1M"
+	RevenueBand1To10M    = 2 // "1M-10M"
+	RevenueBand10To50M   = 3 // "10M-50M"
+	RevenueBand50To100M  = 4 // "50M-100M"
+	RevenueBand100To500M = 5 // "100M-500M"
+	RevenueBand500MPlus  = 6 // "500M+"
+)
 
-var IndustryNamesToID = map[string]int{
-	"technology":         IndustryTechnology,
-	"fintech":           IndustryFintech,
-	"healthcare":        IndustryHealthcare,
-	"education":         IndustryEducation,
-	"e-commerce":        IndustryECommerce,
-	"ecommerce":         IndustryECommerce,
-	"manufacturing":     IndustryManufacturing,
-	"real estate":       IndustryRealEstate,
-	"realestate":        IndustryRealEstate,
-	"consulting":        IndustryConsulting,
-	"marketing":         IndustryMarketing,
-	"retail":            IndustryRetail,
-	"logistics":         IndustryLogistics,
-	"energy":            IndustryEnergy,
-	"media":             IndustryMedia,
-	"gaming":            IndustryGaming,
-	"automotive":        IndustryAutomotive,
-	"agriculture":       IndustryAgriculture,
-	"aerospace":         IndustryAerospace,
-	"telecom":           IndustryTelecom,
-	"biotechnology":     IndustryBiotechnology,
-	"cybersecurity":     IndustryCybersecurity,
-	"ai":                IndustryAI,
-	"artificial intelligence": IndustryAI,
-	"blockchain":        IndustryBlockchain,
-	"cloud computing":   IndustryCloudComputing,
-	"cloud":             IndustryCloudComputing,
-	"insurance":         IndustryInsurance,
-	"banking":           IndustryBanking,
-	"food & beverage":   IndustryFoodBeverage,
-	"food":              IndustryFoodBeverage,
-	"travel":            IndustryTravel,
-	"fashion":           IndustryFashion,
-	"other":             IndustryOther,
+// Maps for string conversions. Business types, industries, buyer roles and
+// company sizes are served by the taxonomy package from the database.
+var RevenueBandNames = map[int]string{
+	RevenueBandUnder1M:   "<1M",
+	RevenueBand1To10M:    "1M-10M",
+	RevenueBand10To50M:   "10M-50M",
+	RevenueBand50To100M:  "50M-100M",
+	RevenueBand100To500M: "100M-500M",
+	RevenueBand500MPlus:  "500M+",
 }
 
-var CompanySizeRanges = map[int]string{
-	CompanySize1To10:     "1-10",
-	CompanySize11To50:    "11-50",
-	CompanySize51To200:   "51-200",
-	CompanySize201To500:  "201-500",
-	CompanySize501To1000: "501-1000",
-	CompanySize1000Plus:  "1000+",
+var RevenueBandNamesToID = map[string]int{
+	"<1m":       RevenueBandUnder1M,
+	"1m-10m":    RevenueBand1To10M,
+	"10m-50m":   RevenueBand10To50M,
+	"50m-100m":  RevenueBand50To100M,
+	"100m-500m": RevenueBand100To500M,
+	"500m+":     RevenueBand500MPlus,
 }
 
-var CompanySizeRangesToID = map[string]int{
-	"1-10":     CompanySize1To10,
-	"11-50":    CompanySize11To50,
-	"51-200":   CompanySize51To200,
-	"201-500":  CompanySize201To500,
-	"501-1000": CompanySize501To1000,
-	"1000+":    CompanySize1000Plus,
+// RevenueBandBounds holds each band's [min, max) in USD; 0 means unbounded above.
+var RevenueBandBounds = map[int][2]float64{
+	RevenueBandUnder1M:   {0, 1e6},
+	RevenueBand1To10M:    {1e6, 10e6},
+	RevenueBand10To50M:   {10e6, 50e6},
+	RevenueBand50To100M:  {50e6, 100e6},
+	RevenueBand100To500M: {100e6, 500e6},
+	RevenueBand500MPlus:  {500e6, 0},
 }
 
 // Helper functions
-func GetIndustryName(id int) string {
-	if name, exists := IndustryNames[id]; exists {
+func GetRevenueBandName(id int) string {
+	if name, exists := RevenueBandNames[id]; exists {
 		return name
 	}
 	return "Unknown"
 }
-
-func GetIndustryID(name string) int {
-	if id, exists := IndustryNamesToID[name]; exists {
-		return id
-	}
-	return IndustryOther
-}
-
-func GetCompanySizeRange(id int) string {
-	if size, exists := CompanySizeRanges[id]; exists {
-		return size
-	}
-	return "Unknown"
-}
-
-func GetCompanySizeID(sizeRange string) int {
-	if id, exists := CompanySizeRangesToID[sizeRange]; exists {
-		return id
-	}
-	return CompanySize1To10 // Default to smallest range
-}
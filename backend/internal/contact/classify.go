@@ -0,0 +1,143 @@
+package contact
+
+import (
+	"strings"
+
+	"github.com/bhati00/Fynelo/backend/internal/constants"
+)
+
+// Rank of each seniority, higher is more senior
+var seniorityRank = map[Seniority]int{
+	SeniorityCSuite:     5,
+	SeniorityVP:         4,
+	SeniorityDirector:   3,
+	SeniorityManager:    2,
+	SeniorityIndividual: 1,
+}
+
+// Title rewrites applied before matching, so that e.g. "Vice President" is
+// not mistaken for a president.
+var titleAliases = strings.NewReplacer(
+	"vice president", "vp",
+	"senior vp", "vp",
+	"co founder", "founder",
+	"cofounder", "founder",
+	"head of", "head",
+	"product owner", "product manager",
+	"human resources", "hr",
+	"information technology", "it",
+	"business development", "sales",
+	"account executive", "sales",
+)
+
+// Seniority keywords, checked in order
+var seniorityRules = []struct {
+	seniority Seniority
+	words     []string
+}{
+	{SeniorityVP, []string{"vp", "svp", "evp"}},
+	{SeniorityCSuite, []string{"chief", "ceo", "cto", "cfo", "coo", "cmo", "cio", "ciso", "cpo", "cro", "chro", "founder", "owner", "president", "managing director"}},
+	{SeniorityDirector, []string{"director", "head"}},
+	{SeniorityManager, []string{"manager", "lead", "supervisor"}},
+}
+
+// Department keywords, checked in order
+var departmentRules = []struct {
+	department Department
+	words      []string
+}{
+	{DepartmentExecutive, []string{"ceo", "founder", "owner", "president", "executive", "managing director", "general manager"}},
+	{DepartmentIT, []string{"it", "cio", "ciso", "infrastructure", "sysadmin", "helpdesk", "network", "security"}},
+	{DepartmentEngineering, []string{"cto", "engineering", "engineer", "developer", "software", "devops", "architect", "technology", "technical", "r&d"}},
+	{DepartmentProduct, []string{"cpo", "product"}},
+	{DepartmentMarketing, []string{"cmo", "marketing", "growth", "brand", "content", "seo", "communications", "demand"}},
+	{DepartmentSales, []string{"cro", "sales", "revenue", "bdr", "sdr", "partnerships"}},
+	{DepartmentFinance, []string{"cfo", "finance", "financial", "accounting", "accountant", "controller", "treasury"}},
+	{DepartmentHR, []string{"chro", "hr", "people", "talent", "recruiting", "recruiter"}},
+	{DepartmentOperations, []string{"coo", "operations", "ops", "supply chain", "logistics", "procurement"}},
+}
+
+// ClassifyTitle derives seniority, department and buyer role from a job title.
+// Titles that match nothing are individual contributors in DepartmentOther
+// with BuyerRoleOther.
+func ClassifyTitle(title string) (Seniority, Department, int) {
+	normalized := normalizeTitle(title)
+	if normalized == "" {
+		return SeniorityIndividual, DepartmentOther, constants.BuyerRoleOther
+	}
+
+	seniority := SeniorityIndividual
+	for _, rule := range seniorityRules {
+		if containsAny(normalized, rule.words) {
+			seniority = rule.seniority
+			break
+		}
+	}
+	department := DepartmentOther
+	for _, rule := range departmentRules {
+		if containsAny(normalized, rule.words) {
+			department = rule.department
+			break
+		}
+	}
+	return seniority, department, buyerRole(seniority, department)
+}
+
+// buyerRole maps a seniority and department onto the ICP buyer role taxonomy.
+// Director-level roles need at least a director; manager-level roles at least
+// a manager.
+func buyerRole(seniority Seniority, department Department) int {
+	rank := seniorityRank[seniority]
+	director := rank >= seniorityRank[SeniorityDirector]
+	manager := rank >= seniorityRank[SeniorityManager]
+
+	switch {
+	case department == DepartmentExecutive && seniority == SeniorityCSuite:
+		return constants.BuyerRoleCEOFounder
+	case department == DepartmentEngineering && director:
+		return constants.BuyerRoleCTOVPEngineering
+	case department == DepartmentMarketing && director:
+		return constants.BuyerRoleMarketingDirector
+	case department == DepartmentSales && director:
+		return constants.BuyerRoleSalesDirector
+	case department == DepartmentOperations && manager:
+		return constants.BuyerRoleOperationsManager
+	case department == DepartmentHR && director:
+		return constants.BuyerRoleHRDirector
+	case department == DepartmentFinance && director:
+		return constants.BuyerRoleFinanceDirector
+	case department == DepartmentProduct && manager:
+		return constants.BuyerRoleProductManager
+	case department == DepartmentIT && manager:
+		return constants.BuyerRoleITManager
+	}
+	return constants.BuyerRoleOther
+}
+
+// normalizeTitle lowercases a title, turns punctuation into spaces and pads it
+// with spaces so that keywords can be matched as whole words.
+func normalizeTitle(title string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '&':
+			b.WriteRune(r)
+		default:
+			b.WriteRune(' ')
+		}
+	}
+	words := strings.Fields(b.String())
+	if len(words) == 0 {
+		return ""
+	}
+	return " " + titleAliases.Replace(strings.Join(words, " ")) + " "
+}
+
+func containsAny(normalized string, words []string) bool {
+	for _, w := range words {
+		if strings.Contains(normalized, " "+w+" ") {
+			return true
+		}
+	}
+	return false
+}
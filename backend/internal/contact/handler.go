@@ -0,0 +1,222 @@
+package contact
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// CreateContactHandler godoc
+// @Summary Create a contact
+// @Description Adds a person at a company. Seniority, department and buyer role are derived from the title unless given.
+// @Tags Contacts
+// @Accept json
+// @Produce json
+// @Param contact body ContactRequest true "Contact"
+// @Success 201 {object} Contact
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /contacts [post]
+func (h *Handler) CreateContactHandler(c *gin.Context) {
+	var req ContactRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	contact, err := h.service.CreateContact(c.Request.Context(), req)
+	if err != nil {
+		h.writeError(c, err, "Failed to create contact")
+		return
+	}
+
+	c.JSON(http.StatusCreated, contact)
+}
+
+// SearchContactsHandler godoc
+// @Summary Search contacts
+// @Description Searches contacts by name, title or email and filters by company, buyer role, seniority and department
+// @Tags Contacts
+// @Accept json
+// @Produce json
+// @Param q query string false "Matched against name, title and email"
+// @Param company_id query int false "Company ID"
+// @Param buyer_role query int false "Buyer role ID"
+// @Param seniority query string false "c_suite, vp, director, manager or individual"
+// @Param department query string false "executive, engineering, it, product, marketing, sales, finance, hr, operations or other"
+// @Param limit query int false "Results limit (default: 20, max: 100)"
+// @Param offset query int false "Results offset (default: 0)"
+// @Success 200 {object} SearchResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /contacts [get]
+func (h *Handler) SearchContactsHandler(c *gin.Context) {
+	var req SearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	result, err := h.service.SearchContacts(c.Request.Context(), req)
+	if err != nil {
+		h.writeError(c, err, "Failed to search contacts")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetContactHandler godoc
+// @Summary Get a contact
+// @Description Fetches a contact and its company
+// @Tags Contacts
+// @Accept json
+// @Produce json
+// @Param id path int true "Contact ID"
+// @Success 200 {object} Contact
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /contacts/{id} [get]
+func (h *Handler) GetContactHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	contact, err := h.service.GetContact(c.Request.Context(), uint(id))
+	if err != nil {
+		h.writeError(c, err, "Failed to fetch contact")
+		return
+	}
+
+	c.JSON(http.StatusOK, contact)
+}
+
+// UpdateContactHandler godoc
+// @Summary Update a contact
+// @Description Replaces a contact. Seniority, department and buyer role are re-derived from the title unless given.
+// @Tags Contacts
+// @Accept json
+// @Produce json
+// @Param id path int true "Contact ID"
+// @Param contact body ContactRequest true "Contact"
+// @Success 200 {object} Contact
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /contacts/{id} [put]
+func (h *Handler) UpdateContactHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	var req ContactRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	contact, err := h.service.UpdateContact(c.Request.Context(), uint(id), req)
+	if err != nil {
+		h.writeError(c, err, "Failed to update contact")
+		return
+	}
+
+	c.JSON(http.StatusOK, contact)
+}
+
+// DeleteContactHandler godoc
+// @Summary Delete a contact
+// @Description Deletes a contact
+// @Tags Contacts
+// @Accept json
+// @Produce json
+// @Param id path int true "Contact ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /contacts/{id} [delete]
+func (h *Handler) DeleteContactHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	if err := h.service.DeleteContact(c.Request.Context(), uint(id)); err != nil {
+		h.writeError(c, err, "Failed to delete contact")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DecisionMakersHandler godoc
+// @Summary Decision-makers at a company
+// @Description Lists a company's contacts holding the buyer roles targeted by an ICP, most senior first. Without icp_id every buyer role except Other counts.
+// @Tags Contacts
+// @Accept json
+// @Produce json
+// @Param id path int true "Company ID"
+// @Param icp_id query int false "ICP profile whose buyer roles to match"
+// @Success 200 {object} DecisionMakersResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /companies/{id}/decision-makers [get]
+func (h *Handler) DecisionMakersHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company ID"})
+		return
+	}
+	var icpID uint64
+	if v := c.Query("icp_id"); v != "" {
+		if icpID, err = strconv.ParseUint(v, 10, 32); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid icp_id"})
+			return
+		}
+	}
+
+	result, err := h.service.DecisionMakers(c.Request.Context(), uint(id), uint(icpID))
+	if err != nil {
+		h.writeError(c, err, "Failed to fetch decision-makers")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *Handler) writeError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Contact not found"})
+	case errors.Is(err, ErrCompanyNotFound), errors.Is(err, ErrICPNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrDuplicateEmail):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrInvalidSeniority), errors.Is(err, ErrInvalidDepartment), errors.Is(err, ErrInvalidBuyerRole):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -0,0 +1,9 @@
+package contact
+
+import (
+	"github.com/bhati00/Fynelo/backend/pkg/database"
+)
+
+func Migrate() {
+	database.DB.AutoMigrate(&Contact{})
+}
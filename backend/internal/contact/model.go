@@ -0,0 +1,80 @@
+package contact
+
+import (
+	"time"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"gorm.io/gorm"
+)
+
+type Seniority string
+
+const (
+	SeniorityCSuite     Seniority = "c_suite" // chiefs, founders, owners, presidents
+	SeniorityVP         Seniority = "vp"
+	SeniorityDirector   Seniority = "director" // including "head of"
+	SeniorityManager    Seniority = "manager"  // including leads
+	SeniorityIndividual Seniority = "individual"
+)
+
+type Department string
+
+const (
+	DepartmentExecutive   Department = "executive"
+	DepartmentEngineering Department = "engineering"
+	DepartmentIT          Department = "it"
+	DepartmentProduct     Department = "product"
+	DepartmentMarketing   Department = "marketing"
+	DepartmentSales       Department = "sales"
+	DepartmentFinance     Department = "finance"
+	DepartmentHR          Department = "hr"
+	DepartmentOperations  Department = "operations"
+	DepartmentOther       Department = "other"
+)
+
+type EmailStatus string
+
+const (
+	EmailUnverified EmailStatus = "unverified" // not checked yet
+	EmailValid      EmailStatus = "valid"      // well-formed and the domain accepts mail
+	EmailInvalid    EmailStatus = "invalid"    // malformed address
+	EmailNoMX       EmailStatus = "no_mx"      // the domain has no mail servers
+	EmailUnknown    EmailStatus = "unknown"    // the DNS lookup failed; try again later
+)
+
+// Where a contact's email came from
+const (
+	EmailSourceProvided = "provided"
+	EmailSourceInferred = "inferred" // guessed from the company's email pattern
+)
+
+// Contact is a person working at a company. BuyerRole is one of the
+// constants.BuyerRole* values, derived from the title unless set explicitly.
+type Contact struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	CompanyID   uint       `gorm:"not null;index" json:"company_id"`
+	FirstName   string     `gorm:"type:varchar(100);not null" json:"first_name"`
+	LastName    string     `gorm:"type:varchar(100)" json:"last_name"`
+	Title       string     `gorm:"type:varchar(255)" json:"title"`
+	Seniority   Seniority  `gorm:"type:varchar(20);index" json:"seniority"`
+	Department  Department `gorm:"type:varchar(20);index" json:"department"`
+	BuyerRole   int        `gorm:"index" json:"buyer_role"`
+	Email       *string    `gorm:"type:varchar(255);index" json:"email"`
+	LinkedInURL *string    `gorm:"type:varchar(500)" json:"linkedin_url"`
+
+	// Email verification, reset whenever the email changes
+	EmailStatus     EmailStatus `gorm:"type:varchar(20);index" json:"email_status,omitempty"`
+	EmailSource     string      `gorm:"type:varchar(20)" json:"email_source,omitempty"`
+	EmailConfidence float64     `json:"email_confidence"` // 0..1
+	EmailCheckedAt  *time.Time  `json:"email_checked_at,omitempty"`
+
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Company *model.Company `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+}
+
+func (Contact) TableName() string {
+	return "contacts"
+}
@@ -0,0 +1,118 @@
+package contact
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+
+	"gorm.io/gorm"
+)
+
+// SearchFilter narrows a contact search. Zero values match everything.
+type SearchFilter struct {
+	Query      string // matched against name, title and email
+	CompanyID  uint
+	BuyerRoles []int
+	Seniority  Seniority
+	Department Department
+	Limit      int
+	Offset     int
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(ctx context.Context, contact *Contact) error {
+	return r.db.WithContext(ctx).Omit("Company").Create(contact).Error
+}
+
+func (r *Repository) GetByID(ctx context.Context, id uint) (*Contact, error) {
+	var contact Contact
+	if err := r.db.WithContext(ctx).Preload("Company").First(&contact, id).Error; err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+func (r *Repository) Update(ctx context.Context, contact *Contact) error {
+	return r.db.WithContext(ctx).Omit("Company").Save(contact).Error
+}
+
+func (r *Repository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&Contact{}, id).Error
+}
+
+// Search returns matching contacts ordered by name, plus the total number of matches.
+func (r *Repository) Search(ctx context.Context, filter SearchFilter) ([]Contact, int64, error) {
+	tx := r.db.WithContext(ctx).Model(&Contact{})
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		like := "%" + strings.ToLower(q) + "%"
+		tx = tx.Where("LOWER(first_name || ' ' || COALESCE(last_name, '')) LIKE ? OR LOWER(title) LIKE ? OR LOWER(email) LIKE ?",
+			like, like, like)
+	}
+	if filter.CompanyID != 0 {
+		tx = tx.Where("company_id = ?", filter.CompanyID)
+	}
+	if len(filter.BuyerRoles) > 0 {
+		tx = tx.Where("buyer_role IN ?", filter.BuyerRoles)
+	}
+	if filter.Seniority != "" {
+		tx = tx.Where("seniority = ?", filter.Seniority)
+	}
+	if filter.Department != "" {
+		tx = tx.Where("department = ?", filter.Department)
+	}
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var list []Contact
+	if err := tx.Order("first_name ASC, last_name ASC, id ASC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+// ListByCompany returns a company's contacts, optionally only those holding one
+// of buyerRoles.
+func (r *Repository) ListByCompany(ctx context.Context, companyID uint, buyerRoles []int) ([]Contact, error) {
+	var list []Contact
+	tx := r.db.WithContext(ctx).Where("company_id = ?", companyID)
+	if len(buyerRoles) > 0 {
+		tx = tx.Where("buyer_role IN ?", buyerRoles)
+	}
+	if err := tx.Order("id ASC").Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// EmailTaken reports whether another contact at the company already uses email.
+func (r *Repository) EmailTaken(ctx context.Context, companyID uint, email string, exceptID uint) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&Contact{}).
+		Where("company_id = ? AND LOWER(email) = ? AND id <> ?", companyID, strings.ToLower(email), exceptID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *Repository) GetCompany(ctx context.Context, id uint) (*model.Company, error) {
+	var company model.Company
+	if err := r.db.WithContext(ctx).First(&company, id).Error; err != nil {
+		return nil, err
+	}
+	return &company, nil
+}
@@ -0,0 +1,15 @@
+package contact
+
+import "github.com/gin-gonic/gin"
+
+func RegisterContactRoutes(rg *gin.RouterGroup, h *Handler) {
+	contacts := rg.Group("/contacts")
+	{
+		contacts.POST("", h.CreateContactHandler)
+		contacts.GET("", h.SearchContactsHandler)
+		contacts.GET("/:id", h.GetContactHandler)
+		contacts.PUT("/:id", h.UpdateContactHandler)
+		contacts.DELETE("/:id", h.DeleteContactHandler)
+	}
+	rg.GET("/companies/:id/decision-makers", h.DecisionMakersHandler)
+}
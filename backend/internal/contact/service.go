@@ -0,0 +1,268 @@
+package contact
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/bhati00/Fynelo/backend/internal/constants"
+	"github.com/bhati00/Fynelo/backend/internal/icp"
+	"github.com/bhati00/Fynelo/backend/internal/taxonomy"
+	"github.com/bhati00/Fynelo/backend/pkg/pagination"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCompanyNotFound   = errors.New("company not found")
+	ErrICPNotFound       = errors.New("ICP not found")
+	ErrDuplicateEmail    = errors.New("another contact at this company already has this email")
+	ErrInvalidSeniority  = errors.New("seniority must be one of: c_suite, vp, director, manager, individual")
+	ErrInvalidDepartment = errors.New("department must be one of: executive, engineering, it, product, marketing, sales, finance, hr, operations, other")
+	ErrInvalidBuyerRole  = errors.New("buyer_role must be one of the buyer role IDs (1-10)")
+)
+
+var departments = map[Department]struct{}{
+	DepartmentExecutive: {}, DepartmentEngineering: {}, DepartmentIT: {}, DepartmentProduct: {}, DepartmentMarketing: {},
+	DepartmentSales: {}, DepartmentFinance: {}, DepartmentHR: {}, DepartmentOperations: {}, DepartmentOther: {},
+}
+
+// ContactRequest creates or replaces a contact. Seniority, department and buyer
+// role are derived from the title when left empty.
+type ContactRequest struct {
+	CompanyID   uint       `json:"company_id" binding:"required"`
+	FirstName   string     `json:"first_name" binding:"required"`
+	LastName    string     `json:"last_name"`
+	Title       string     `json:"title"`
+	Seniority   Seniority  `json:"seniority"`
+	Department  Department `json:"department"`
+	BuyerRole   int        `json:"buyer_role"`
+	Email       string     `json:"email" binding:"omitempty,email"`
+	LinkedInURL string     `json:"linkedin_url" binding:"omitempty,url"`
+}
+
+type SearchRequest struct {
+	Q          string `form:"q"`
+	CompanyID  uint   `form:"company_id"`
+	BuyerRole  int    `form:"buyer_role"`
+	Seniority  string `form:"seniority"`
+	Department string `form:"department"`
+	Limit      int    `form:"limit"`
+	Offset     int    `form:"offset"`
+}
+
+type SearchResponse struct {
+	Contacts []Contact `json:"contacts"`
+	Total    int64     `json:"total"`
+	Limit    int       `json:"limit"`
+	Offset   int       `json:"offset"`
+}
+
+type DecisionMakersResponse struct {
+	CompanyID  uint      `json:"company_id"`
+	ICPID      uint      `json:"icp_id,omitempty"`
+	BuyerRoles []int     `json:"buyer_roles"`
+	Contacts   []Contact `json:"contacts"`
+}
+
+type Service struct {
+	repo    *Repository
+	icpRepo *icp.Repository
+}
+
+func NewService(repo *Repository, icpRepo *icp.Repository) *Service {
+	return &Service{repo: repo, icpRepo: icpRepo}
+}
+
+func (s *Service) CreateContact(ctx context.Context, req ContactRequest) (*Contact, error) {
+	contact := &Contact{}
+	if err := s.apply(ctx, contact, req); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, contact); err != nil {
+		return nil, err
+	}
+	return contact, nil
+}
+
+func (s *Service) GetContact(ctx context.Context, id uint) (*Contact, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *Service) UpdateContact(ctx context.Context, id uint, req ContactRequest) (*Contact, error) {
+	contact, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	contact.Company = nil
+	if err := s.apply(ctx, contact, req); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, contact); err != nil {
+		return nil, err
+	}
+	return contact, nil
+}
+
+func (s *Service) DeleteContact(ctx context.Context, id uint) error {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *Service) SearchContacts(ctx context.Context, req SearchRequest) (*SearchResponse, error) {
+	req.Limit, req.Offset = pagination.Page(req.Limit, req.Offset)
+	filter := SearchFilter{
+		Query:      req.Q,
+		CompanyID:  req.CompanyID,
+		Seniority:  Seniority(req.Seniority),
+		Department: Department(req.Department),
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+	}
+	if filter.Seniority != "" {
+		if _, ok := seniorityRank[filter.Seniority]; !ok {
+			return nil, ErrInvalidSeniority
+		}
+	}
+	if filter.Department != "" {
+		if _, ok := departments[filter.Department]; !ok {
+			return nil, ErrInvalidDepartment
+		}
+	}
+	if req.BuyerRole != 0 {
+		if !taxonomy.Default().Valid(taxonomy.BuyerRoles, req.BuyerRole) {
+			return nil, ErrInvalidBuyerRole
+		}
+		filter.BuyerRoles = []int{req.BuyerRole}
+	}
+
+	list, total, err := s.repo.Search(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &SearchResponse{Contacts: list, Total: total, Limit: req.Limit, Offset: req.Offset}, nil
+}
+
+// DecisionMakers lists a company's contacts holding the buyer roles an ICP
+// targets, most senior first. Without an ICP, or with one that names no buyer
+// role besides BuyerRoleOther, every role except BuyerRoleOther counts.
+func (s *Service) DecisionMakers(ctx context.Context, companyID, icpID uint) (*DecisionMakersResponse, error) {
+	if _, err := s.repo.GetCompany(ctx, companyID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCompanyNotFound
+		}
+		return nil, err
+	}
+
+	var roles []int
+	if icpID != 0 {
+		profile, err := s.icpRepo.GetICPByID(icpID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrICPNotFound
+			}
+			return nil, err
+		}
+		for _, role := range profile.BuyerRoles {
+			if taxonomy.Default().Valid(taxonomy.BuyerRoles, role) && role != constants.BuyerRoleOther {
+				roles = append(roles, role)
+			}
+		}
+	}
+	if len(roles) == 0 {
+		for _, term := range taxonomy.Default().Terms(taxonomy.BuyerRoles) {
+			if term.ID != constants.BuyerRoleOther {
+				roles = append(roles, term.ID)
+			}
+		}
+		sort.Ints(roles)
+	}
+
+	list, err := s.repo.ListByCompany(ctx, companyID, roles)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return seniorityRank[list[i].Seniority] > seniorityRank[list[j].Seniority]
+	})
+	return &DecisionMakersResponse{CompanyID: companyID, ICPID: icpID, BuyerRoles: roles, Contacts: list}, nil
+}
+
+// apply validates req and copies it onto contact, classifying the title for
+// any of seniority, department and buyer role not given explicitly.
+func (s *Service) apply(ctx context.Context, contact *Contact, req ContactRequest) error {
+	if req.Seniority != "" {
+		if _, ok := seniorityRank[req.Seniority]; !ok {
+			return ErrInvalidSeniority
+		}
+	}
+	if req.Department != "" {
+		if _, ok := departments[req.Department]; !ok {
+			return ErrInvalidDepartment
+		}
+	}
+	if req.BuyerRole != 0 {
+		if !taxonomy.Default().Valid(taxonomy.BuyerRoles, req.BuyerRole) {
+			return ErrInvalidBuyerRole
+		}
+	}
+	if _, err := s.repo.GetCompany(ctx, req.CompanyID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrCompanyNotFound
+		}
+		return err
+	}
+
+	email := strings.TrimSpace(req.Email)
+	if email != "" {
+		taken, err := s.repo.EmailTaken(ctx, req.CompanyID, email, contact.ID)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return ErrDuplicateEmail
+		}
+	}
+
+	seniority, department, role := ClassifyTitle(req.Title)
+	if req.Seniority != "" {
+		seniority = req.Seniority
+	}
+	if req.Department != "" {
+		department = req.Department
+	}
+	if req.BuyerRole != 0 {
+		role = req.BuyerRole
+	} else if req.Seniority != "" || req.Department != "" {
+		role = buyerRole(seniority, department)
+	}
+
+	contact.CompanyID = req.CompanyID
+	contact.FirstName = strings.TrimSpace(req.FirstName)
+	contact.LastName = strings.TrimSpace(req.LastName)
+	contact.Title = strings.TrimSpace(req.Title)
+	contact.Seniority = seniority
+	contact.Department = department
+	contact.BuyerRole = role
+	email = strings.ToLower(email)
+	if contact.Email == nil || *contact.Email != email {
+		contact.Email = optional(email)
+		contact.EmailStatus, contact.EmailSource, contact.EmailConfidence, contact.EmailCheckedAt = "", "", 0, nil
+		if email != "" {
+			contact.EmailStatus = EmailUnverified
+			contact.EmailSource = EmailSourceProvided
+		}
+	}
+	contact.LinkedInURL = optional(strings.TrimSpace(req.LinkedInURL))
+	return nil
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
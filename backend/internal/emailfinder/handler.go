@@ -0,0 +1,150 @@
+package emailfinder
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// EmailPatternHandler godoc
+// @Summary Infer a company's email pattern
+// @Description Scores address patterns (first.last, flast, first, ...) against the known emails of contacts at the company's domain
+// @Tags Email finder
+// @Accept json
+// @Produce json
+// @Param id path int true "Company ID"
+// @Success 200 {object} PatternInference
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /companies/{id}/email-pattern [get]
+func (h *Handler) EmailPatternHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company ID"})
+		return
+	}
+
+	inference, err := h.service.InferPattern(c.Request.Context(), uint(id))
+	if err != nil {
+		h.writeError(c, err, "Failed to infer email pattern")
+		return
+	}
+
+	c.JSON(http.StatusOK, inference)
+}
+
+// EmailCandidatesHandler godoc
+// @Summary Email candidates for a contact
+// @Description Generates possible addresses for a contact from the company's inferred email pattern, most likely first, and reports whether the domain accepts mail. Nothing is saved.
+// @Tags Email finder
+// @Accept json
+// @Produce json
+// @Param id path int true "Contact ID"
+// @Success 200 {object} CandidatesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /contacts/{id}/email-candidates [get]
+func (h *Handler) EmailCandidatesHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	candidates, err := h.service.Candidates(c.Request.Context(), uint(id))
+	if err != nil {
+		h.writeError(c, err, "Failed to generate email candidates")
+		return
+	}
+
+	c.JSON(http.StatusOK, candidates)
+}
+
+// FindEmailHandler godoc
+// @Summary Find a contact's email
+// @Description Picks the most likely candidate address, verifies its syntax and MX records and stores it with its status and confidence. Contacts with a provided email are not changed.
+// @Tags Email finder
+// @Accept json
+// @Produce json
+// @Param id path int true "Contact ID"
+// @Success 200 {object} EmailResult
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /contacts/{id}/find-email [post]
+func (h *Handler) FindEmailHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	result, err := h.service.FindEmail(c.Request.Context(), uint(id))
+	if err != nil {
+		h.writeError(c, err, "Failed to find email")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// VerifyEmailHandler godoc
+// @Summary Verify a contact's email
+// @Description Checks the contact's email syntax and MX records and stores the verification status and confidence
+// @Tags Email finder
+// @Accept json
+// @Produce json
+// @Param id path int true "Contact ID"
+// @Success 200 {object} EmailResult
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /contacts/{id}/verify-email [post]
+func (h *Handler) VerifyEmailHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	result, err := h.service.VerifyEmail(c.Request.Context(), uint(id))
+	if err != nil {
+		h.writeError(c, err, "Failed to verify email")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *Handler) writeError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Contact not found"})
+	case errors.Is(err, ErrCompanyNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrEmailProvided):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrNoDomain), errors.Is(err, ErrNoEmail), errors.Is(err, ErrNoCandidate):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -0,0 +1,65 @@
+package emailfinder
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Pattern builds the local part of an address from a normalized first and last name.
+type Pattern struct {
+	Name  string  // e.g. "first.last"
+	Prior float64 // share of companies using it, used before anything is known
+	local func(first, last string) string
+}
+
+// Patterns in order of how common they are
+var patterns = []Pattern{
+	{"first.last", 0.40, func(f, l string) string { return f + "." + l }},
+	{"flast", 0.18, func(f, l string) string { return initial(f) + l }},
+	{"first", 0.12, func(f, l string) string { return f }},
+	{"firstlast", 0.08, func(f, l string) string { return f + l }},
+	{"f.last", 0.06, func(f, l string) string { return initial(f) + "." + l }},
+	{"first_last", 0.04, func(f, l string) string { return f + "_" + l }},
+	{"firstl", 0.04, func(f, l string) string { return f + initial(l) }},
+	{"last", 0.03, func(f, l string) string { return l }},
+	{"last.first", 0.02, func(f, l string) string { return l + "." + f }},
+	{"first.l", 0.02, func(f, l string) string { return f + "." + initial(l) }},
+	{"lastf", 0.01, func(f, l string) string { return l + initial(f) }},
+}
+
+// Local returns the pattern's local part for a person, or "" when the name
+// lacks a part the pattern needs.
+func (p Pattern) Local(firstName, lastName string) string {
+	first, last := normalizeName(firstName), normalizeName(lastName)
+	if first == "" {
+		return ""
+	}
+	if last == "" && p.Name != "first" {
+		return ""
+	}
+	return p.local(first, last)
+}
+
+// normalizeName lowercases a name part, folds accents ("José" → "jose") and
+// drops everything but letters and digits ("O'Neil" → "oneil").
+func normalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(strings.ToLower(strings.TrimSpace(name))) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// combining accent
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func initial(s string) string {
+	if s == "" {
+		return ""
+	}
+	return s[:1]
+}
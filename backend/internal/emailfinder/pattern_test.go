@@ -0,0 +1,50 @@
+package emailfinder
+
+import "testing"
+
+func TestPatternLocal(t *testing.T) {
+	byName := map[string]Pattern{}
+	for _, p := range patterns {
+		byName[p.Name] = p
+	}
+	for _, tc := range []struct {
+		pattern, first, last, want string
+	}{
+		{"first.last", "Jane", "Doe", "jane.doe"},
+		{"flast", "Jane", "Doe", "jdoe"},
+		{"first", "Jane", "", "jane"},
+		{"firstlast", "Jane", "Doe", "janedoe"},
+		{"f.last", "Jane", "Doe", "j.doe"},
+		{"first_last", "Jane", "Doe", "jane_doe"},
+		{"firstl", "Jane", "Doe", "janed"},
+		{"last", "Jane", "Doe", "doe"},
+		{"last.first", "Jane", "Doe", "doe.jane"},
+		{"first.l", "Jane", "Doe", "jane.d"},
+		{"lastf", "Jane", "Doe", "doej"},
+		{"first.last", "José", "O'Neil", "jose.oneil"},
+		{"first.last", " Zoë ", "Müller-Lüdenscheidt", "zoe.mullerludenscheidt"},
+		{"first.last", "Jane", "", ""}, // needs a last name
+		{"first", "", "Doe", ""},       // needs a first name
+	} {
+		p, ok := byName[tc.pattern]
+		if !ok {
+			t.Fatalf("no pattern %q", tc.pattern)
+		}
+		if got := p.Local(tc.first, tc.last); got != tc.want {
+			t.Errorf("%s.Local(%q, %q) = %q, want %q", tc.pattern, tc.first, tc.last, got, tc.want)
+		}
+	}
+}
+
+func TestPatternPriorsAreOrdered(t *testing.T) {
+	total := 0.0
+	for i, p := range patterns {
+		total += p.Prior
+		if i > 0 && p.Prior > patterns[i-1].Prior {
+			t.Errorf("%s (%v) is listed after the less common %s (%v)", p.Name, p.Prior, patterns[i-1].Name, patterns[i-1].Prior)
+		}
+	}
+	if total < 0.99 || total > 1.01 {
+		t.Errorf("priors add up to %v, want 1", total)
+	}
+}
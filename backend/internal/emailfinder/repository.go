@@ -0,0 +1,76 @@
+package emailfinder
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/contact"
+
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) GetContact(ctx context.Context, id uint) (*contact.Contact, error) {
+	var c contact.Contact
+	if err := r.db.WithContext(ctx).First(&c, id).Error; err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *Repository) GetCompany(ctx context.Context, id uint) (*model.Company, error) {
+	var company model.Company
+	if err := r.db.WithContext(ctx).First(&company, id).Error; err != nil {
+		return nil, err
+	}
+	return &company, nil
+}
+
+// KnownContacts returns contacts with a provided email at domain that has not
+// been found to be bad. Inferred emails are left out so guesses do not feed
+// back into the pattern.
+func (r *Repository) KnownContacts(ctx context.Context, domain string) ([]contact.Contact, error) {
+	var list []contact.Contact
+	if err := r.db.WithContext(ctx).
+		Where("LOWER(email) LIKE ?", "%@"+domain).
+		Where("COALESCE(email_source, '') <> ?", contact.EmailSourceInferred).
+		Where("COALESCE(email_status, '') NOT IN ?", []contact.EmailStatus{contact.EmailInvalid, contact.EmailNoMX}).
+		Find(&list).Error; err != nil {
+		return nil, err
+	}
+
+	// LIKE treats "_" as a wildcard; keep exact matches only
+	known := list[:0]
+	for _, c := range list {
+		if c.Email != nil && strings.HasSuffix(strings.ToLower(*c.Email), "@"+domain) {
+			known = append(known, c)
+		}
+	}
+	return known, nil
+}
+
+// EmailTaken reports whether another contact at the company already uses email.
+func (r *Repository) EmailTaken(ctx context.Context, companyID uint, email string, exceptID uint) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&contact.Contact{}).
+		Where("company_id = ? AND LOWER(email) = ? AND id <> ?", companyID, strings.ToLower(email), exceptID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// SaveEmail stores a contact's email and its verification fields only.
+func (r *Repository) SaveEmail(ctx context.Context, c *contact.Contact) error {
+	return r.db.WithContext(ctx).Model(c).
+		Select("email", "email_status", "email_source", "email_confidence", "email_checked_at").
+		Updates(c).Error
+}
@@ -0,0 +1,36 @@
+package emailfinder
+
+import (
+	"context"
+	"net"
+	"strings"
+)
+
+// Resolver looks up mail servers. *net.Resolver implements it.
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+}
+
+// FakeResolver answers MX lookups from a fixed table without touching the
+// network. Domains missing from MX do not exist; domains in Failing fail with
+// a temporary error.
+type FakeResolver struct {
+	MX      map[string][]string // domain -> mail hosts
+	Failing map[string]bool
+}
+
+func (f *FakeResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	if f.Failing[name] {
+		return nil, &net.DNSError{Err: "server misbehaving", Name: name, IsTemporary: true}
+	}
+	hosts, ok := f.MX[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	records := make([]*net.MX, 0, len(hosts))
+	for i, host := range hosts {
+		records = append(records, &net.MX{Host: host + ".", Pref: uint16(10 * (i + 1))})
+	}
+	return records, nil
+}
@@ -0,0 +1,14 @@
+package emailfinder
+
+import "github.com/gin-gonic/gin"
+
+func RegisterEmailFinderRoutes(rg *gin.RouterGroup, h *Handler) {
+	rg.GET("/companies/:id/email-pattern", h.EmailPatternHandler)
+
+	contacts := rg.Group("/contacts/:id")
+	{
+		contacts.GET("/email-candidates", h.EmailCandidatesHandler)
+		contacts.POST("/find-email", h.FindEmailHandler)
+		contacts.POST("/verify-email", h.VerifyEmailHandler)
+	}
+}
@@ -0,0 +1,383 @@
+package emailfinder
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/contact"
+	"github.com/bhati00/Fynelo/backend/pkg/domain"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// Weight of the prior, in samples, when blending it with observed patterns
+	priorWeight = 2.0
+
+	// Confidence in an email someone provided whose domain accepts mail
+	providedConfidence = 0.9
+
+	// Confidence is scaled down by this when the DNS lookup failed
+	unknownPenalty = 0.5
+)
+
+var (
+	ErrCompanyNotFound = errors.New("company not found")
+	ErrNoDomain        = errors.New("company has no valid website domain")
+	ErrNoEmail         = errors.New("contact has no email to verify")
+	ErrEmailProvided   = errors.New("contact already has a provided email")
+	ErrNoCandidate     = errors.New("no email candidate could be built for this contact")
+)
+
+type PatternScore struct {
+	Pattern    string  `json:"pattern"`
+	Matches    int     `json:"matches"`    // known contacts whose email fits
+	Confidence float64 `json:"confidence"` // 0..1
+}
+
+// PatternInference is how likely each pattern is for a domain, given the
+// known emails there. Without samples the confidences are the priors.
+type PatternInference struct {
+	CompanyID uint           `json:"company_id"`
+	Domain    string         `json:"domain"`
+	Samples   int            `json:"samples"`
+	Patterns  []PatternScore `json:"patterns"` // most likely first
+}
+
+type Candidate struct {
+	Email      string  `json:"email"`
+	Pattern    string  `json:"pattern"`
+	Confidence float64 `json:"confidence"`
+}
+
+type CandidatesResponse struct {
+	ContactID    uint                `json:"contact_id"`
+	Domain       string              `json:"domain"`
+	DomainStatus contact.EmailStatus `json:"domain_status"` // valid, no_mx or unknown
+	Samples      int                 `json:"samples"`
+	Candidates   []Candidate         `json:"candidates"` // most likely first
+}
+
+// Verification is the outcome of checking one address.
+type Verification struct {
+	Email   string              `json:"email"`
+	Status  contact.EmailStatus `json:"status"`
+	MXHosts []string            `json:"mx_hosts,omitempty"`
+	Reason  string              `json:"reason,omitempty"`
+}
+
+type EmailResult struct {
+	Contact      *contact.Contact `json:"contact"`
+	Pattern      string           `json:"pattern,omitempty"` // set for inferred emails
+	Verification Verification     `json:"verification"`
+}
+
+type Service struct {
+	repo     *Repository
+	resolver Resolver
+}
+
+// NewService creates the email finder. A nil resolver uses the system DNS resolver.
+func NewService(repo *Repository, resolver Resolver) *Service {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	return &Service{repo: repo, resolver: resolver}
+}
+
+// InferPattern scores every pattern against the known emails at the company's domain.
+func (s *Service) InferPattern(ctx context.Context, companyID uint) (*PatternInference, error) {
+	company, err := s.repo.GetCompany(ctx, companyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCompanyNotFound
+		}
+		return nil, err
+	}
+	host, err := companyDomain(company)
+	if err != nil {
+		return nil, err
+	}
+	return s.infer(ctx, companyID, host)
+}
+
+func (s *Service) infer(ctx context.Context, companyID uint, host string) (*PatternInference, error) {
+	known, err := s.repo.KnownContacts(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make(map[string]int, len(patterns))
+	for _, c := range known {
+		local := strings.ToLower(strings.SplitN(*c.Email, "@", 2)[0])
+		for _, p := range patterns {
+			if l := p.Local(c.FirstName, c.LastName); l != "" && l == local {
+				matches[p.Name]++
+			}
+		}
+	}
+
+	inference := &PatternInference{CompanyID: companyID, Domain: host, Samples: len(known)}
+	for _, p := range patterns {
+		n := matches[p.Name]
+		inference.Patterns = append(inference.Patterns, PatternScore{
+			Pattern:    p.Name,
+			Matches:    n,
+			Confidence: round((float64(n) + p.Prior*priorWeight) / (float64(len(known)) + priorWeight)),
+		})
+	}
+	sort.SliceStable(inference.Patterns, func(i, j int) bool {
+		return inference.Patterns[i].Confidence > inference.Patterns[j].Confidence
+	})
+	return inference, nil
+}
+
+// Candidates lists possible addresses for a contact, most likely first, without
+// saving anything. Addresses used by other contacts at the company are skipped.
+func (s *Service) Candidates(ctx context.Context, contactID uint) (*CandidatesResponse, error) {
+	c, host, err := s.load(ctx, contactID)
+	if err != nil {
+		return nil, err
+	}
+	inference, candidates, err := s.candidates(ctx, c, host)
+	if err != nil {
+		return nil, err
+	}
+	mx := s.checkDomain(ctx, host)
+	return &CandidatesResponse{
+		ContactID:    c.ID,
+		Domain:       host,
+		DomainStatus: mx.Status,
+		Samples:      inference.Samples,
+		Candidates:   candidates,
+	}, nil
+}
+
+// FindEmail infers the most likely address for a contact, verifies it and
+// stores it. Contacts with a provided email are left alone; previously
+// inferred emails are re-inferred.
+func (s *Service) FindEmail(ctx context.Context, contactID uint) (*EmailResult, error) {
+	c, host, err := s.load(ctx, contactID)
+	if err != nil {
+		return nil, err
+	}
+	if c.Email != nil && c.EmailSource != contact.EmailSourceInferred {
+		return nil, ErrEmailProvided
+	}
+	_, candidates, err := s.candidates(ctx, c, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, ErrNoCandidate
+	}
+
+	best := candidates[0]
+	verification := s.Verify(ctx, best.Email)
+	c.Email = &best.Email
+	c.EmailSource = contact.EmailSourceInferred
+	s.record(c, verification, best.Confidence)
+	if err := s.repo.SaveEmail(ctx, c); err != nil {
+		return nil, err
+	}
+	return &EmailResult{Contact: c, Pattern: best.Pattern, Verification: verification}, nil
+}
+
+// VerifyEmail re-checks a contact's current email and stores the outcome.
+func (s *Service) VerifyEmail(ctx context.Context, contactID uint) (*EmailResult, error) {
+	c, err := s.repo.GetContact(ctx, contactID)
+	if err != nil {
+		return nil, err
+	}
+	if c.Email == nil {
+		return nil, ErrNoEmail
+	}
+
+	verification := s.Verify(ctx, *c.Email)
+	base := providedConfidence
+	var pattern string
+	if c.EmailSource == contact.EmailSourceInferred {
+		// the guess is only as good as the pattern that produced it
+		base = 0
+		if company, err := s.repo.GetCompany(ctx, c.CompanyID); err == nil {
+			if host, err := companyDomain(company); err == nil {
+				_, candidates, err := s.candidates(ctx, c, host)
+				if err != nil {
+					return nil, err
+				}
+				for _, cand := range candidates {
+					if strings.EqualFold(cand.Email, *c.Email) {
+						base, pattern = cand.Confidence, cand.Pattern
+						break
+					}
+				}
+			}
+		}
+	} else if c.EmailSource == "" {
+		c.EmailSource = contact.EmailSourceProvided
+	}
+	s.record(c, verification, base)
+	if err := s.repo.SaveEmail(ctx, c); err != nil {
+		return nil, err
+	}
+	return &EmailResult{Contact: c, Pattern: pattern, Verification: verification}, nil
+}
+
+// Verify checks an address's syntax and whether its domain accepts mail. It
+// does not contact the mail server, so a valid address may still bounce.
+func (s *Service) Verify(ctx context.Context, email string) Verification {
+	v := Verification{Email: email}
+	_, host, ok := splitAddress(email)
+	if !ok {
+		v.Status = contact.EmailInvalid
+		v.Reason = "malformed address"
+		return v
+	}
+	mx := s.checkDomain(ctx, host)
+	v.Status, v.MXHosts, v.Reason = mx.Status, mx.MXHosts, mx.Reason
+	return v
+}
+
+func (s *Service) checkDomain(ctx context.Context, host string) Verification {
+	v := Verification{}
+	records, err := s.resolver.LookupMX(ctx, host)
+	if err != nil {
+		// Only a definite NXDOMAIN means there is no mail server. A resolver
+		// that cannot reach DNS reports temporary errors or timeouts, which
+		// say nothing about the domain.
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound && !dnsErr.IsTemporary && !dnsErr.IsTimeout {
+			v.Status = contact.EmailNoMX
+			v.Reason = "domain has no MX records"
+		} else {
+			v.Status = contact.EmailUnknown
+			v.Reason = err.Error()
+		}
+		return v
+	}
+	for _, mx := range records {
+		if h := strings.TrimSuffix(mx.Host, "."); h != "" {
+			v.MXHosts = append(v.MXHosts, h)
+		}
+	}
+	if len(v.MXHosts) == 0 {
+		// RFC 7505 null MX: the domain explicitly accepts no mail
+		v.Status = contact.EmailNoMX
+		v.Reason = "domain does not accept mail"
+		return v
+	}
+	v.Status = contact.EmailValid
+	return v
+}
+
+func (s *Service) load(ctx context.Context, contactID uint) (*contact.Contact, string, error) {
+	c, err := s.repo.GetContact(ctx, contactID)
+	if err != nil {
+		return nil, "", err
+	}
+	company, err := s.repo.GetCompany(ctx, c.CompanyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", ErrCompanyNotFound
+		}
+		return nil, "", err
+	}
+	host, err := companyDomain(company)
+	if err != nil {
+		return nil, "", err
+	}
+	return c, host, nil
+}
+
+func (s *Service) candidates(ctx context.Context, c *contact.Contact, host string) (*PatternInference, []Candidate, error) {
+	inference, err := s.infer(ctx, c.CompanyID, host)
+	if err != nil {
+		return nil, nil, err
+	}
+	byName := make(map[string]Pattern, len(patterns))
+	for _, p := range patterns {
+		byName[p.Name] = p
+	}
+
+	var candidates []Candidate
+	seen := map[string]bool{}
+	for _, score := range inference.Patterns {
+		local := byName[score.Pattern].Local(c.FirstName, c.LastName)
+		if local == "" {
+			continue
+		}
+		email := local + "@" + host
+		if seen[email] {
+			continue // short names make several patterns collide
+		}
+		seen[email] = true
+		if _, _, ok := splitAddress(email); !ok {
+			continue
+		}
+		taken, err := s.repo.EmailTaken(ctx, c.CompanyID, email, c.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if taken {
+			continue
+		}
+		candidates = append(candidates, Candidate{Email: email, Pattern: score.Pattern, Confidence: score.Confidence})
+	}
+	return inference, candidates, nil
+}
+
+// record stores a verification outcome on the contact, scaling base confidence
+// by what the check found.
+func (s *Service) record(c *contact.Contact, v Verification, base float64) {
+	now := time.Now()
+	c.EmailStatus = v.Status
+	c.EmailCheckedAt = &now
+	switch v.Status {
+	case contact.EmailValid:
+		c.EmailConfidence = round(base)
+	case contact.EmailUnknown:
+		c.EmailConfidence = round(base * unknownPenalty)
+	default:
+		c.EmailConfidence = 0
+	}
+}
+
+// splitAddress checks that email is a plain addr-spec and returns its parts
+// with the domain lowercased.
+func splitAddress(email string) (string, string, bool) {
+	if len(email) > 254 {
+		return "", "", false
+	}
+	parsed, err := mail.ParseAddress(email)
+	if err != nil || parsed.Name != "" || parsed.Address != email {
+		return "", "", false
+	}
+	at := strings.LastIndex(email, "@")
+	local, host := email[:at], strings.ToLower(email[at+1:])
+	if local == "" || len(local) > 64 || !strings.Contains(host, ".") {
+		return "", "", false
+	}
+	return local, host, true
+}
+
+func companyDomain(company *model.Company) (string, error) {
+	if company.Domain != nil && *company.Domain != "" {
+		return *company.Domain, nil
+	}
+	if company.Website != nil {
+		if host, err := domain.Canonicalize(*company.Website); err == nil {
+			return host, nil
+		}
+	}
+	return "", ErrNoDomain
+}
+
+func round(f float64) float64 {
+	return float64(int(f*1000+0.5)) / 1000
+}
@@ -0,0 +1,238 @@
+package emailfinder
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/contact"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type fixture struct {
+	svc     *Service
+	db      *gorm.DB
+	company model.Company
+}
+
+// newFixture sets up an in-memory database with one company at acme.com and
+// a service resolving names through resolver.
+func newFixture(t *testing.T, resolver Resolver) *fixture {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&model.Company{}, &contact.Contact{}); err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	t.Cleanup(func() { sqlDB.Close() })
+
+	website := "https://www.acme.com"
+	f := &fixture{svc: NewService(NewRepository(db), resolver), db: db, company: model.Company{Name: "Acme", Website: &website}}
+	if err := db.Create(&f.company).Error; err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// addContact stores a contact at the fixture company; an empty email leaves
+// it without one.
+func (f *fixture) addContact(t *testing.T, first, last, email string) *contact.Contact {
+	t.Helper()
+	c := &contact.Contact{CompanyID: f.company.ID, FirstName: first, LastName: last}
+	if email != "" {
+		c.Email = &email
+		c.EmailSource = contact.EmailSourceProvided
+	}
+	if err := f.db.Create(c).Error; err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func workingResolver() *FakeResolver {
+	return &FakeResolver{MX: map[string][]string{
+		"acme.com": {"mx1.acme.com", "mx2.acme.com"},
+	}}
+}
+
+func TestInferPatternWithoutSamplesUsesPriors(t *testing.T) {
+	f := newFixture(t, workingResolver())
+
+	inference, err := f.svc.InferPattern(context.Background(), f.company.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inference.Domain != "acme.com" || inference.Samples != 0 {
+		t.Fatalf("inference = %+v", inference)
+	}
+	if len(inference.Patterns) != len(patterns) {
+		t.Fatalf("got %d patterns, want %d", len(inference.Patterns), len(patterns))
+	}
+	for i, p := range patterns {
+		if got := inference.Patterns[i]; got.Pattern != p.Name || got.Confidence != round(p.Prior) {
+			t.Errorf("pattern %d = %+v, want %s at its prior %v", i, got, p.Name, p.Prior)
+		}
+	}
+}
+
+func TestInferPatternLearnsFromKnownEmails(t *testing.T) {
+	f := newFixture(t, workingResolver())
+	f.addContact(t, "Jane", "Doe", "jdoe@acme.com")
+	f.addContact(t, "John", "Smith", "JSmith@Acme.com")
+	f.addContact(t, "Ann", "Lee", "ann.lee@acme.com")
+	f.addContact(t, "Bob", "Ray", "bob@other.com") // another domain
+
+	inference, err := f.svc.InferPattern(context.Background(), f.company.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inference.Samples != 3 {
+		t.Fatalf("samples = %d, want 3", inference.Samples)
+	}
+	best := inference.Patterns[0]
+	if best.Pattern != "flast" || best.Matches != 2 {
+		t.Errorf("best pattern = %+v, want flast with 2 matches", best)
+	}
+	// (2 matches + 0.18 prior * 2) / (3 samples + 2)
+	if best.Confidence != 0.472 {
+		t.Errorf("flast confidence = %v, want 0.472", best.Confidence)
+	}
+	if second := inference.Patterns[1]; second.Pattern != "first.last" || second.Matches != 1 {
+		t.Errorf("second pattern = %+v, want first.last with 1 match", second)
+	}
+}
+
+func TestInferPatternUnknownCompany(t *testing.T) {
+	f := newFixture(t, workingResolver())
+	if _, err := f.svc.InferPattern(context.Background(), f.company.ID+1); err != ErrCompanyNotFound {
+		t.Errorf("err = %v, want ErrCompanyNotFound", err)
+	}
+}
+
+func TestCandidatesFollowInferredPatterns(t *testing.T) {
+	f := newFixture(t, workingResolver())
+	f.addContact(t, "Jane", "Doe", "jdoe@acme.com")
+	f.addContact(t, "John", "Smith", "jsmith@acme.com")
+	f.addContact(t, "Ann", "Lee", "alee@acme.com")
+	f.addContact(t, "Mary", "Brown", "mary.brown@acme.com")
+	f.addContact(t, "Alan", "Lee", "alan@acme.com") // takes alan@ from the new Alan Lee below
+	target := f.addContact(t, "Alan", "Lee", "")
+
+	resp, err := f.svc.Candidates(context.Background(), target.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Domain != "acme.com" || resp.DomainStatus != contact.EmailValid || resp.Samples != 5 {
+		t.Errorf("response = %+v", resp)
+	}
+
+	var emails []string
+	for i, c := range resp.Candidates {
+		emails = append(emails, c.Email)
+		if i > 0 && c.Confidence > resp.Candidates[i-1].Confidence {
+			t.Errorf("candidates not ordered by confidence: %+v", resp.Candidates)
+		}
+	}
+	// alee@ is taken by Ann Lee and alan@ by the other Alan Lee
+	want := []string{"alan.lee@acme.com", "alanlee@acme.com", "a.lee@acme.com", "alan_lee@acme.com",
+		"alanl@acme.com", "lee@acme.com", "lee.alan@acme.com", "alan.l@acme.com", "leea@acme.com"}
+	if !reflect.DeepEqual(emails, want) {
+		t.Errorf("candidates = %v\nwant %v", emails, want)
+	}
+	if resp.Candidates[0].Pattern != "first.last" {
+		t.Errorf("best candidate pattern = %s", resp.Candidates[0].Pattern)
+	}
+}
+
+func TestFindEmailStoresBestCandidate(t *testing.T) {
+	f := newFixture(t, workingResolver())
+	f.addContact(t, "Jane", "Doe", "jane.doe@acme.com")
+	target := f.addContact(t, "John", "Smith", "")
+
+	result, err := f.svc.FindEmail(context.Background(), target.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Pattern != "first.last" || result.Verification.Status != contact.EmailValid {
+		t.Errorf("result = %+v", result)
+	}
+
+	var stored contact.Contact
+	if err := f.db.First(&stored, target.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.Email == nil || *stored.Email != "john.smith@acme.com" || stored.EmailSource != contact.EmailSourceInferred {
+		t.Errorf("stored email = %v (%s)", stored.Email, stored.EmailSource)
+	}
+	if stored.EmailStatus != contact.EmailValid || stored.EmailConfidence <= 0 {
+		t.Errorf("stored status %s, confidence %v", stored.EmailStatus, stored.EmailConfidence)
+	}
+
+	provided := f.addContact(t, "Ann", "Lee", "ann@acme.com")
+	if _, err := f.svc.FindEmail(context.Background(), provided.ID); err != ErrEmailProvided {
+		t.Errorf("provided email: err = %v, want ErrEmailProvided", err)
+	}
+}
+
+func TestVerifyClassifiesDomains(t *testing.T) {
+	resolver := workingResolver()
+	resolver.MX["nullmx.com"] = []string{""}
+	resolver.Failing = map[string]bool{"flaky.com": true}
+	f := newFixture(t, resolver)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		email string
+		want  contact.EmailStatus
+	}{
+		{"jane@acme.com", contact.EmailValid},
+		{"jane@ACME.com", contact.EmailValid},
+		{"jane@missing.com", contact.EmailNoMX},
+		{"jane@nullmx.com", contact.EmailNoMX},
+		{"jane@flaky.com", contact.EmailUnknown},
+		{"not an address", contact.EmailInvalid},
+		{"Jane <jane@acme.com>", contact.EmailInvalid},
+		{"jane@localhost", contact.EmailInvalid},
+	} {
+		if got := f.svc.Verify(ctx, tc.email); got.Status != tc.want {
+			t.Errorf("Verify(%q) = %s (%s), want %s", tc.email, got.Status, got.Reason, tc.want)
+		}
+	}
+
+	v := f.svc.Verify(ctx, "jane@acme.com")
+	if want := []string{"mx1.acme.com", "mx2.acme.com"}; !reflect.DeepEqual(v.MXHosts, want) {
+		t.Errorf("MX hosts = %v, want %v", v.MXHosts, want)
+	}
+}
+
+func TestVerifyTreatsUnreachableDNSAsUnknown(t *testing.T) {
+	for _, dnsErr := range []*net.DNSError{
+		{Err: "i/o timeout", IsTimeout: true, IsTemporary: true, IsNotFound: true},
+		{Err: "server misbehaving", IsTemporary: true, IsNotFound: true},
+		{Err: "connection refused", IsTemporary: true},
+	} {
+		t.Run(dnsErr.Err, func(t *testing.T) {
+			f := newFixture(t, errorResolver{dnsErr})
+			if v := f.svc.Verify(context.Background(), "jane@acme.com"); v.Status != contact.EmailUnknown {
+				t.Errorf("status = %s (%s), want unknown", v.Status, v.Reason)
+			}
+		})
+	}
+}
+
+// errorResolver fails every lookup with err.
+type errorResolver struct{ err *net.DNSError }
+
+func (r errorResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	err := *r.err
+	err.Name = name
+	return nil, &err
+}
@@ -0,0 +1,228 @@
+// Package fx converts amounts between currencies with a table of dated
+// exchange rates. The table bundled with the binary holds approximate annual
+// averages against USD, so conversion works offline; deployments can extend
+// or correct it with a CSV file in the same format (date,currency,per_usd).
+package fx
+
+import (
+	"embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+//go:embed data/rates.csv
+var dataFS embed.FS
+
+// USD is the currency normalized amounts are stored in.
+const USD = "USD"
+
+var ErrUnknownCurrency = errors.New("unknown currency")
+
+// Rate is the number of units of the target currency one unit of the source
+// currency buys, and the date of the oldest table entry it was derived from.
+type Rate struct {
+	Value float64   `json:"value"`
+	Date  time.Time `json:"date"`
+}
+
+type point struct {
+	date   time.Time
+	perUSD float64 // units of the currency per US dollar
+}
+
+// Table holds the known rates of each currency against USD, by date.
+type Table struct {
+	rates map[string][]point // ISO-4217 code -> points by ascending date
+}
+
+var current atomic.Pointer[Table]
+
+// Default returns the table last loaded with LoadFile, or the bundled one.
+func Default() *Table {
+	if t := current.Load(); t != nil {
+		return t
+	}
+	t, err := bundled()
+	if err != nil {
+		// the data is embedded at build time, so this is a programming error
+		panic(fmt.Sprintf("fx: invalid bundled rates: %v", err))
+	}
+	current.CompareAndSwap(nil, t)
+	return current.Load()
+}
+
+// LoadFile makes the default table the bundled rates overlaid with those in
+// the CSV file at path; a rate in the file replaces the bundled rate for the
+// same currency and date. A missing file leaves the bundled rates in use.
+func LoadFile(path string) error {
+	t, err := bundled()
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		current.Store(t)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := t.read(f); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	current.Store(t)
+	return nil
+}
+
+func bundled() (*Table, error) {
+	f, err := dataFS.Open("data/rates.csv")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	t := &Table{rates: map[string][]point{}}
+	if err := t.read(f); err != nil {
+		return nil, fmt.Errorf("data/rates.csv: %w", err)
+	}
+	return t, nil
+}
+
+// read adds the rates of a CSV with a header row to the table.
+func (t *Table) read(r io.Reader) error {
+	cr := csv.NewReader(r)
+	if _, err := cr.Read(); err != nil { // header
+		return err
+	}
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if len(rec) != 3 {
+			return fmt.Errorf("line %d: want date,currency,per_usd", line)
+		}
+		date, err := time.Parse("2006-01-02", strings.TrimSpace(rec[0]))
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		code := Normalize(rec[1])
+		if len(code) != 3 {
+			return fmt.Errorf("line %d: currency %q is not an ISO-4217 code", line, rec[1])
+		}
+		perUSD, err := strconv.ParseFloat(strings.TrimSpace(rec[2]), 64)
+		if err != nil || perUSD <= 0 {
+			return fmt.Errorf("line %d: invalid rate %q", line, rec[2])
+		}
+		t.set(code, point{date: date, perUSD: perUSD})
+	}
+	for code := range t.rates {
+		points := t.rates[code]
+		sort.Slice(points, func(i, j int) bool { return points[i].date.Before(points[j].date) })
+	}
+	return nil
+}
+
+func (t *Table) set(code string, p point) {
+	for i, existing := range t.rates[code] {
+		if existing.date.Equal(p.date) {
+			t.rates[code][i] = p
+			return
+		}
+	}
+	t.rates[code] = append(t.rates[code], p)
+}
+
+// Normalize returns a currency code in its canonical upper-case form.
+func Normalize(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Known reports whether amounts in currency can be converted.
+func (t *Table) Known(currency string) bool {
+	currency = Normalize(currency)
+	_, ok := t.rates[currency]
+	return ok || currency == USD
+}
+
+// Currencies lists the convertible currencies, USD included, alphabetically.
+func (t *Table) Currencies() []string {
+	codes := []string{USD}
+	for code := range t.rates {
+		if code != USD {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// perUSD returns the rate of currency in force on a date: the latest entry
+// on or before it, or the earliest entry for dates before the table starts.
+func (t *Table) perUSD(currency string, on time.Time) (point, error) {
+	currency = Normalize(currency)
+	points, ok := t.rates[currency]
+	if !ok {
+		if currency == USD {
+			return point{perUSD: 1}, nil
+		}
+		return point{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, currency)
+	}
+	i := sort.Search(len(points), func(i int) bool { return points[i].date.After(on) })
+	if i == 0 {
+		return points[0], nil
+	}
+	return points[i-1], nil
+}
+
+// Rate returns the rate from one currency to another on a date.
+func (t *Table) Rate(from, to string, on time.Time) (Rate, error) {
+	src, err := t.perUSD(from, on)
+	if err != nil {
+		return Rate{}, err
+	}
+	dst, err := t.perUSD(to, on)
+	if err != nil {
+		return Rate{}, err
+	}
+	date := src.date
+	if date.IsZero() || (!dst.date.IsZero() && dst.date.Before(date)) {
+		date = dst.date
+	}
+	return Rate{Value: dst.perUSD / src.perUSD, Date: date}, nil
+}
+
+// Convert converts an amount between currencies at the rate of a date.
+func (t *Table) Convert(amount float64, from, to string, on time.Time) (float64, Rate, error) {
+	rate, err := t.Rate(from, to, on)
+	if err != nil {
+		return 0, Rate{}, err
+	}
+	return round(amount * rate.Value), rate, nil
+}
+
+// ToUSD converts an amount to USD, or returns nil when the currency is unknown.
+func (t *Table) ToUSD(amount float64, currency string, on time.Time) *float64 {
+	usd, _, err := t.Convert(amount, currency, USD, on)
+	if err != nil {
+		return nil
+	}
+	return &usd
+}
+
+// round keeps cents.
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,325 @@
+// Package geo resolves free-text place names to ISO-3166 country and region
+// codes and coordinates using a small gazetteer bundled with the binary, so
+// location normalization and radius search work fully offline.
+package geo
+
+import (
+	"embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed data/*.csv
+var dataFS embed.FS
+
+// Country is an ISO-3166-1 country.
+type Country struct {
+	Code   string `json:"code"`   // alpha-2, e.g. "DE"
+	Alpha3 string `json:"alpha3"` // e.g. "DEU"
+	Name   string `json:"name"`
+}
+
+// Region is an ISO-3166-2 subdivision such as a US state.
+type Region struct {
+	Code        string `json:"code"` // e.g. "US-CA"
+	CountryCode string `json:"country_code"`
+	Name        string `json:"name"`
+}
+
+// City is a gazetteer city with its coordinates.
+type City struct {
+	Name        string  `json:"name"`
+	CountryCode string  `json:"country_code"`
+	RegionCode  string  `json:"region_code,omitempty"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+}
+
+// Place is the result of resolving a location. Empty fields were not resolved.
+type Place struct {
+	CountryCode string
+	RegionCode  string
+	City        *City
+}
+
+// Gazetteer indexes countries, regions and cities by name, code and alias.
+type Gazetteer struct {
+	countries    map[string]Country  // alpha-2 -> country
+	countryIndex map[string]string   // normalized name/alias/code -> alpha-2
+	regions      map[string]Region   // region code -> region
+	regionIndex  map[string][]string // normalized name/alias -> region codes
+	cities       []City
+	cityIndex    map[string][]int // normalized name/alias -> indexes into cities
+}
+
+var (
+	defaultGazetteer *Gazetteer
+	loadOnce         sync.Once
+)
+
+// Default returns the gazetteer built from the bundled data files.
+func Default() *Gazetteer {
+	loadOnce.Do(func() {
+		g, err := load()
+		if err != nil {
+			// the data is embedded at build time, so this is a programming error
+			panic(fmt.Sprintf("geo: invalid bundled gazetteer: %v", err))
+		}
+		defaultGazetteer = g
+	})
+	return defaultGazetteer
+}
+
+func load() (*Gazetteer, error) {
+	g := &Gazetteer{
+		countries:    map[string]Country{},
+		countryIndex: map[string]string{},
+		regions:      map[string]Region{},
+		regionIndex:  map[string][]string{},
+		cityIndex:    map[string][]int{},
+	}
+
+	if err := readCSV("data/countries.csv", func(rec []string) error {
+		c := Country{Code: rec[0], Alpha3: rec[1], Name: rec[2]}
+		g.countries[c.Code] = c
+		for _, key := range append([]string{c.Code, c.Alpha3, c.Name}, aliases(rec[3])...) {
+			g.countryIndex[normalize(key)] = c.Code
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := readCSV("data/regions.csv", func(rec []string) error {
+		r := Region{Code: rec[0], CountryCode: rec[1], Name: rec[2]}
+		g.regions[r.Code] = r
+		for _, key := range append([]string{r.Code, r.Name}, aliases(rec[3])...) {
+			k := normalize(key)
+			g.regionIndex[k] = append(g.regionIndex[k], r.Code)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := readCSV("data/cities.csv", func(rec []string) error {
+		lat, err := strconv.ParseFloat(rec[3], 64)
+		if err != nil {
+			return err
+		}
+		lon, err := strconv.ParseFloat(rec[4], 64)
+		if err != nil {
+			return err
+		}
+		c := City{Name: rec[0], CountryCode: rec[1], RegionCode: rec[2], Latitude: lat, Longitude: lon}
+		idx := len(g.cities)
+		g.cities = append(g.cities, c)
+		for _, key := range append([]string{c.Name}, aliases(rec[5])...) {
+			k := normalize(key)
+			g.cityIndex[k] = append(g.cityIndex[k], idx)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+func readCSV(name string, fn func(rec []string) error) error {
+	f, err := dataFS.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	if _, err := r.Read(); err != nil { // header
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if err := fn(rec); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+}
+
+func aliases(field string) []string {
+	if field == "" {
+		return nil
+	}
+	return strings.Split(field, "|")
+}
+
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a", "å", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o", "ø", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n", "ș", "s", "ş", "s", "ț", "t", "ß", "ss",
+)
+
+// normalize lowercases, folds common accents and collapses whitespace.
+func normalize(s string) string {
+	s = accentReplacer.Replace(strings.ToLower(strings.TrimSpace(s)))
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// CountryByCode returns the country for an alpha-2 code.
+func (g *Gazetteer) CountryByCode(code string) (Country, bool) {
+	c, ok := g.countries[strings.ToUpper(code)]
+	return c, ok
+}
+
+// RegionByCode returns the region for an ISO-3166-2 code.
+func (g *Gazetteer) RegionByCode(code string) (Region, bool) {
+	r, ok := g.regions[strings.ToUpper(code)]
+	return r, ok
+}
+
+// Country resolves a country name, alias, alpha-2 or alpha-3 code.
+func (g *Gazetteer) Country(name string) (Country, bool) {
+	code, ok := g.countryIndex[normalize(name)]
+	if !ok {
+		return Country{}, false
+	}
+	return g.countries[code], true
+}
+
+// Region resolves a region name, alias or code. When countryCode is set only
+// regions of that country match; otherwise the name must be unambiguous.
+func (g *Gazetteer) Region(name, countryCode string) (Region, bool) {
+	codes := g.regionIndex[normalize(name)]
+	var match []string
+	for _, code := range codes {
+		if countryCode == "" || g.regions[code].CountryCode == countryCode {
+			match = append(match, code)
+		}
+	}
+	if len(match) != 1 {
+		return Region{}, false
+	}
+	return g.regions[match[0]], true
+}
+
+// City resolves a city by name or alias, optionally restricted to a country
+// and region. The first (most prominent) candidate wins when ambiguous.
+func (g *Gazetteer) City(name, countryCode, regionCode string) (City, bool) {
+	for _, idx := range g.cityIndex[normalize(name)] {
+		c := g.cities[idx]
+		if countryCode != "" && c.CountryCode != countryCode {
+			continue
+		}
+		if regionCode != "" && c.RegionCode != regionCode {
+			continue
+		}
+		return c, true
+	}
+	return City{}, false
+}
+
+// Normalize resolves separate city, state and country fields, filling in
+// whatever can be inferred (e.g. the country of a known city).
+func (g *Gazetteer) Normalize(city, state, country string) Place {
+	var countryHints, regionHints []string
+	if country != "" {
+		countryHints = append(countryHints, country)
+	}
+	if state != "" {
+		regionHints = append(regionHints, state)
+	}
+	return g.resolve(city, regionHints, countryHints)
+}
+
+// ParsePlace resolves free text such as "Berlin", "Berlin, Germany" or
+// "Austin, TX". Later comma-separated parts are treated as region or country.
+func (g *Gazetteer) ParsePlace(text string) Place {
+	parts := strings.Split(text, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	if len(parts) == 1 {
+		// a single term may be a city, a region or a country
+		if c, ok := g.City(parts[0], "", ""); ok {
+			return Place{CountryCode: c.CountryCode, RegionCode: c.RegionCode, City: &c}
+		}
+		return g.resolve("", parts, parts)
+	}
+	return g.resolve(parts[0], parts[1:], parts[1:])
+}
+
+func (g *Gazetteer) resolve(city string, regionHints, countryHints []string) Place {
+	var place Place
+	for _, hint := range countryHints {
+		if c, ok := g.Country(hint); ok {
+			place.CountryCode = c.Code
+			break
+		}
+	}
+
+	var regions []Region
+	for _, hint := range regionHints {
+		for _, code := range g.regionIndex[normalize(hint)] {
+			regions = append(regions, g.regions[code])
+		}
+	}
+
+	// Prefer a city that agrees with a region hint, then with the country hint.
+	if city != "" {
+		for _, r := range regions {
+			if c, ok := g.City(city, r.CountryCode, r.Code); ok {
+				return Place{CountryCode: c.CountryCode, RegionCode: c.RegionCode, City: &c}
+			}
+		}
+		if c, ok := g.City(city, place.CountryCode, ""); ok {
+			return Place{CountryCode: c.CountryCode, RegionCode: c.RegionCode, City: &c}
+		}
+	}
+
+	for _, r := range regions {
+		if place.CountryCode == "" || r.CountryCode == place.CountryCode {
+			place.RegionCode = r.Code
+			place.CountryCode = r.CountryCode
+			break
+		}
+	}
+	return place
+}
+
+// earthRadiusKm is the mean Earth radius used for distance calculations.
+const earthRadiusKm = 6371.0
+
+// DistanceKm returns the great-circle distance between two coordinates.
+func DistanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := func(d float64) float64 { return d * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+// BoundingBox returns the latitude/longitude box enclosing a circle of
+// radiusKm around a point.
+func BoundingBox(lat, lon, radiusKm float64) (minLat, maxLat, minLon, maxLon float64) {
+	dLat := radiusKm / (earthRadiusKm * math.Pi / 180)
+	cosLat := math.Cos(lat * math.Pi / 180)
+	dLon := 180.0
+	if cosLat > 0.01 {
+		dLon = dLat / cosLat
+	}
+	return lat - dLat, lat + dLat, lon - dLon, lon + dLon
+}
@@ -0,0 +1,223 @@
+// Package headcount parses employee counts as imports and enrichment sources
+// report them, e.g. "250", "~250", "1,200 employees", "1k-5k", "500+" or
+// "under 50", and maps them to the company sizes taxonomy.
+package headcount
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/bhati00/Fynelo/backend/internal/taxonomy"
+)
+
+// Largest count accepted, to catch values that are not headcounts
+const MaxCount = 10_000_000
+
+var ErrInvalid = errors.New(`employees must be a count or a range such as "250", "~250", "1k-5k" or "500+"`)
+
+// Range is a parsed headcount. Min equals Max for a single number; Max is 0
+// when the range is open-ended ("500+").
+type Range struct {
+	Min       int  `json:"min"`
+	Max       int  `json:"max,omitempty"`
+	Estimated bool `json:"estimated"` // approximate ("~250") or a range
+}
+
+// Exact returns the range of a known count.
+func Exact(n int) Range {
+	return Range{Min: n, Max: n}
+}
+
+// Count is the single number stored for a range: the number itself, the
+// midpoint of a closed range, or the lower bound of an open one.
+func (r Range) Count() int {
+	if r.Max == 0 || r.Max == r.Min {
+		return r.Min
+	}
+	return r.Min + (r.Max-r.Min)/2
+}
+
+// Contains reports whether every count in o is within r.
+func (r Range) Contains(o Range) bool {
+	if o.Min < r.Min {
+		return false
+	}
+	if r.Max == 0 {
+		return true
+	}
+	return o.Max != 0 && o.Max <= r.Max
+}
+
+// Words that mark a count as approximate
+var approximate = []string{"~", "approximately", "approx.", "approx", "about", "around", "circa", "ca.", "est.", "estimated", "roughly", "nearly"}
+
+// Prefixes of open-ended counts
+var (
+	atLeast = []string{">=", "≥", "at least", "more than", "over", "above", ">"}
+	atMost  = []string{"<=", "≤", "up to", "less than", "fewer than", "under", "below", "<"}
+)
+
+// Nouns that may follow a count
+var nouns = []string{"employees", "employee", "people", "staff", "ftes", "fte"}
+
+// Parse reads a headcount. Ranges use "-", "–", "—" or "to"; numbers may
+// have thousands separators and k or m suffixes ("1.5k", "2 thousand").
+func Parse(s string) (Range, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, noun := range nouns {
+		s = strings.TrimSpace(strings.TrimSuffix(s, noun))
+	}
+
+	var r Range
+	for _, marker := range approximate {
+		if strings.HasPrefix(s, marker) {
+			s = strings.TrimSpace(s[len(marker):])
+			r.Estimated = true
+			break
+		}
+	}
+	if s == "" {
+		return Range{}, ErrInvalid
+	}
+
+	switch {
+	case strings.HasSuffix(s, "+"):
+		n, _, err := number(strings.TrimSuffix(s, "+"))
+		if err != nil {
+			return Range{}, err
+		}
+		r.Min = n
+	case hasPrefix(s, atLeast) != "":
+		prefix := hasPrefix(s, atLeast)
+		n, _, err := number(s[len(prefix):])
+		if err != nil {
+			return Range{}, err
+		}
+		if prefix != ">=" && prefix != "≥" && prefix != "at least" {
+			n++
+		}
+		r.Min = n
+	case hasPrefix(s, atMost) != "":
+		prefix := hasPrefix(s, atMost)
+		n, _, err := number(s[len(prefix):])
+		if err != nil {
+			return Range{}, err
+		}
+		if prefix != "<=" && prefix != "≤" && prefix != "up to" {
+			n--
+		}
+		// "under 1" holds no counts; Max 0 would read as open-ended
+		if n < 1 {
+			return Range{}, ErrInvalid
+		}
+		r.Min, r.Max = 1, n
+	default:
+		lo, hi, ok := split(s)
+		if !ok {
+			n, _, err := number(s)
+			if err != nil {
+				return Range{}, err
+			}
+			r.Min, r.Max = n, n
+			break
+		}
+		hiN, hiScale, err := number(hi)
+		if err != nil {
+			return Range{}, err
+		}
+		loN, loScale, err := number(lo)
+		if err != nil {
+			return Range{}, err
+		}
+		// "1-5k" means 1,000 to 5,000
+		if loScale == 1 && hiScale > 1 && loN < hiN/hiScale {
+			loN *= hiScale
+		}
+		r.Min, r.Max = loN, hiN
+	}
+
+	if r.Min < 1 || r.Min > MaxCount || r.Max > MaxCount || (r.Max != 0 && r.Max < r.Min) {
+		return Range{}, ErrInvalid
+	}
+	if r.Max != r.Min {
+		r.Estimated = true
+	}
+	return r, nil
+}
+
+func hasPrefix(s string, prefixes []string) string {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return p
+		}
+	}
+	return ""
+}
+
+// split cuts a range into its bounds.
+func split(s string) (lo, hi string, ok bool) {
+	for _, sep := range []string{" to ", "–", "—", "-"} {
+		if i := strings.Index(s, sep); i > 0 {
+			return s[:i], s[i+len(sep):], true
+		}
+	}
+	return "", "", false
+}
+
+// number parses a count such as "1,200", "1.5k" or "2 million" and returns
+// it with the multiplier of its suffix.
+func number(s string) (int, int, error) {
+	s = strings.TrimSpace(s)
+	scale := 1
+	for _, suffix := range []struct {
+		text  string
+		scale int
+	}{{"thousand", 1_000}, {"million", 1_000_000}, {"k", 1_000}, {"m", 1_000_000}} {
+		if strings.HasSuffix(s, suffix.text) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, suffix.text))
+			scale = suffix.scale
+			break
+		}
+	}
+	s = strings.ReplaceAll(s, ",", "")
+	if s == "" {
+		return 0, 0, ErrInvalid
+	}
+	if scale == 1 {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, 0, ErrInvalid
+		}
+		return n, scale, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 || f > MaxCount {
+		return 0, 0, ErrInvalid
+	}
+	return int(f*float64(scale) + 0.5), scale, nil
+}
+
+// Bucket returns the company size a headcount falls in: the first size
+// whose range holds all of r, otherwise the one holding r.Count(). Sizes
+// whose names are not ranges ("Enterprise") are never chosen.
+func Bucket(r Range) (int, bool) {
+	var byCount *int
+	for _, term := range taxonomy.Default().Terms(taxonomy.CompanySizes) {
+		size, err := Parse(term.Name)
+		if err != nil {
+			continue
+		}
+		if size.Contains(r) {
+			return term.ID, true
+		}
+		if byCount == nil && size.Contains(Exact(r.Count())) {
+			id := term.ID
+			byCount = &id
+		}
+	}
+	if byCount == nil {
+		return 0, false
+	}
+	return *byCount, true
+}
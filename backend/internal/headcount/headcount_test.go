@@ -0,0 +1,48 @@
+package headcount
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want Range
+	}{
+		{"250", Range{Min: 250, Max: 250}},
+		{"~250", Range{Min: 250, Max: 250, Estimated: true}},
+		{"about 250 employees", Range{Min: 250, Max: 250, Estimated: true}},
+		{"1,200 employees", Range{Min: 1200, Max: 1200}},
+		{"1.5k", Range{Min: 1500, Max: 1500}},
+		{"2 thousand", Range{Min: 2000, Max: 2000}},
+		{"1k-5k", Range{Min: 1000, Max: 5000, Estimated: true}},
+		{"1-5k", Range{Min: 1000, Max: 5000, Estimated: true}},
+		{"11–50", Range{Min: 11, Max: 50, Estimated: true}},
+		{"51 to 200", Range{Min: 51, Max: 200, Estimated: true}},
+		{"500+", Range{Min: 500, Estimated: true}},
+		{"at least 100", Range{Min: 100, Estimated: true}},
+		{"over 100", Range{Min: 101, Estimated: true}},
+		{"under 50", Range{Min: 1, Max: 49, Estimated: true}},
+		{"up to 50", Range{Min: 1, Max: 50, Estimated: true}},
+		{"<2", Range{Min: 1, Max: 1}},
+	} {
+		got, err := Parse(tc.in)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tc.in, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, in := range []string{
+		"", "~", "employees", "lots", "0", "-5", "0+",
+		"under 1", "<1", "<=0", "less than 0",
+		"5k-1k", "20m", "1.5",
+	} {
+		if got, err := Parse(in); err != ErrInvalid {
+			t.Errorf("Parse(%q) = %+v, %v, want ErrInvalid", in, got, err)
+		}
+	}
+}
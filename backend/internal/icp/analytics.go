@@ -0,0 +1,246 @@
+package icp
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Metric names something counted per profile over time.
+type Metric string
+
+const (
+	MetricMatches       Metric = "matches"        // companies first matched by a run
+	MetricRuns          Metric = "runs"           // runs of the profile
+	MetricJobsQueued    Metric = "jobs_queued"    // enrichment jobs queued by runs
+	MetricJobsCompleted Metric = "jobs_completed" // enrichment jobs that finished successfully
+	MetricListAdditions Metric = "list_additions" // matched companies added to prospect lists
+	MetricQualified     Metric = "qualified"      // matched companies moved to qualified on a list
+)
+
+var metrics = []Metric{MetricMatches, MetricRuns, MetricJobsQueued, MetricJobsCompleted, MetricListAdditions, MetricQualified}
+
+// Analytics bucket sizes
+const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+// Most buckets in one series
+const maxBuckets = 366
+
+var (
+	ErrInvalidInterval = errors.New("interval must be one of: day, week, month")
+	ErrInvalidRange    = errors.New("from and to must be RFC 3339 times or YYYY-MM-DD dates, with from before to")
+	ErrTooManyBuckets  = errors.New("the range spans too many buckets; use a longer interval or a shorter range")
+)
+
+// AnalyticsRequest selects the period and bucket size of a report. to
+// defaults to now and from to 30 days, 12 weeks or 12 months before it.
+type AnalyticsRequest struct {
+	Interval string `form:"interval"` // day (default), week or month
+	From     string `form:"from"`     // RFC 3339 time or YYYY-MM-DD date
+	To       string `form:"to"`
+}
+
+// ICPMetrics counts a profile's events, keyed by Metric.
+type ICPMetrics struct {
+	Matches       int64 `json:"matches"`
+	Runs          int64 `json:"runs"`
+	JobsQueued    int64 `json:"jobs_queued"`
+	JobsCompleted int64 `json:"jobs_completed"`
+	ListAdditions int64 `json:"list_additions"`
+	Qualified     int64 `json:"qualified"`
+}
+
+func (m *ICPMetrics) count(metric Metric) *int64 {
+	switch metric {
+	case MetricRuns:
+		return &m.Runs
+	case MetricJobsQueued:
+		return &m.JobsQueued
+	case MetricJobsCompleted:
+		return &m.JobsCompleted
+	case MetricListAdditions:
+		return &m.ListAdditions
+	case MetricQualified:
+		return &m.Qualified
+	default:
+		return &m.Matches
+	}
+}
+
+// AnalyticsBucket counts the events from Start until the next bucket.
+type AnalyticsBucket struct {
+	Start time.Time `json:"start"`
+	ICPMetrics
+}
+
+type AnalyticsResponse struct {
+	ICPID    uint              `json:"icp_id"`
+	Interval string            `json:"interval"`
+	From     time.Time         `json:"from"`
+	To       time.Time         `json:"to"`
+	Totals   ICPMetrics        `json:"totals"`
+	Series   []AnalyticsBucket `json:"series"`
+}
+
+// ICPSummary totals one profile's metrics for a period.
+type ICPSummary struct {
+	ICPID      uint       `json:"icp_id"`
+	Name       string     `json:"name"`
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+	Totals     ICPMetrics `json:"totals"`
+}
+
+// SummaryResponse ranks profiles by qualified accounts, then list additions
+// and matches.
+type SummaryResponse struct {
+	From time.Time    `json:"from"`
+	To   time.Time    `json:"to"`
+	ICPs []ICPSummary `json:"icps"`
+}
+
+// Analytics reports a profile's metrics over a period in buckets of the
+// requested interval, starting at midnight UTC, on Mondays for weeks and on
+// the first of the month for months.
+func (s *Service) Analytics(ctx context.Context, id uint, req AnalyticsRequest) (*AnalyticsResponse, error) {
+	if _, err := s.repo.GetICPByID(id); err != nil {
+		return nil, err
+	}
+	interval, from, to, err := analyticsPeriod(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &AnalyticsResponse{ICPID: id, Interval: interval, From: from, To: to, Series: []AnalyticsBucket{}}
+	index := map[time.Time]int{}
+	for start := bucketStart(from, interval); start.Before(to); start = nextBucket(start, interval) {
+		if len(resp.Series) == maxBuckets {
+			return nil, ErrTooManyBuckets
+		}
+		index[start] = len(resp.Series)
+		resp.Series = append(resp.Series, AnalyticsBucket{Start: start})
+	}
+
+	for _, metric := range metrics {
+		times, err := s.repo.MetricTimes(ctx, metric, id, from, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range times {
+			if i, ok := index[bucketStart(t, interval)]; ok {
+				*resp.Series[i].count(metric)++
+				*resp.Totals.count(metric)++
+			}
+		}
+	}
+	return resp, nil
+}
+
+// Summary totals every profile's metrics over a period, archived profiles
+// included, so profiles can be compared.
+func (s *Service) Summary(ctx context.Context, req AnalyticsRequest) (*SummaryResponse, error) {
+	_, from, to, err := analyticsPeriod(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	profiles, err := s.repo.ListAllICPs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &SummaryResponse{From: from, To: to, ICPs: make([]ICPSummary, len(profiles))}
+	for i, p := range profiles {
+		resp.ICPs[i] = ICPSummary{ICPID: p.ID, Name: p.Name, ArchivedAt: p.ArchivedAt}
+	}
+	for _, metric := range metrics {
+		counts, err := s.repo.MetricCounts(ctx, metric, from, to)
+		if err != nil {
+			return nil, err
+		}
+		for i := range resp.ICPs {
+			*resp.ICPs[i].Totals.count(metric) = counts[resp.ICPs[i].ICPID]
+		}
+	}
+	sort.SliceStable(resp.ICPs, func(i, j int) bool {
+		a, b := resp.ICPs[i].Totals, resp.ICPs[j].Totals
+		if a.Qualified != b.Qualified {
+			return a.Qualified > b.Qualified
+		}
+		if a.ListAdditions != b.ListAdditions {
+			return a.ListAdditions > b.ListAdditions
+		}
+		return a.Matches > b.Matches
+	})
+	return resp, nil
+}
+
+// analyticsPeriod resolves the interval and the [from, to) range of a request.
+func analyticsPeriod(req AnalyticsRequest, now time.Time) (interval string, from, to time.Time, err error) {
+	interval = strings.ToLower(strings.TrimSpace(req.Interval))
+	if interval == "" {
+		interval = IntervalDay
+	}
+	if interval != IntervalDay && interval != IntervalWeek && interval != IntervalMonth {
+		return "", from, to, ErrInvalidInterval
+	}
+
+	to = now.UTC()
+	if req.To != "" {
+		if to, err = parseTime(req.To); err != nil {
+			return "", from, to, ErrInvalidRange
+		}
+	}
+	switch interval {
+	case IntervalWeek:
+		from = bucketStart(to, interval).AddDate(0, 0, -7*11)
+	case IntervalMonth:
+		from = bucketStart(to, interval).AddDate(0, -11, 0)
+	default:
+		from = bucketStart(to, interval).AddDate(0, 0, -29)
+	}
+	if req.From != "" {
+		if from, err = parseTime(req.From); err != nil {
+			return "", from, to, ErrInvalidRange
+		}
+	}
+	if !from.Before(to) {
+		return "", from, to, ErrInvalidRange
+	}
+	return interval, from, to, nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+func bucketStart(t time.Time, interval string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch interval {
+	case IntervalWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case IntervalMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	default:
+		return day
+	}
+}
+
+func nextBucket(start time.Time, interval string) time.Time {
+	switch interval {
+	case IntervalWeek:
+		return start.AddDate(0, 0, 7)
+	case IntervalMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
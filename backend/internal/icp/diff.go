@@ -0,0 +1,93 @@
+package icp
+
+// ICPDiff lists what changed in a profile between two versions. Version 0
+// stands for an empty profile, so diffing from it lists every value.
+type ICPDiff struct {
+	ICPID   uint          `json:"icp_id"`
+	From    int           `json:"from"`
+	To      int           `json:"to"`
+	Changes []FieldChange `json:"changes"`
+}
+
+// FieldChange describes the change to one field, named as in the profile's
+// JSON. List criteria report the values added and removed; text fields report
+// the old and new text.
+type FieldChange struct {
+	Field   string      `json:"field"`
+	Added   interface{} `json:"added,omitempty"`
+	Removed interface{} `json:"removed,omitempty"`
+	From    *string     `json:"from,omitempty"`
+	To      *string     `json:"to,omitempty"`
+}
+
+// diffRevisions compares two revisions, in the order the profile's fields are
+// declared.
+func diffRevisions(from, to *ICPRevision) []FieldChange {
+	changes := []FieldChange{}
+	text := func(field, a, b string) {
+		if a != b {
+			changes = append(changes, FieldChange{Field: field, From: &a, To: &b})
+		}
+	}
+	list := func(field string, added, removed interface{}, nAdded, nRemoved int) {
+		if nAdded == 0 && nRemoved == 0 {
+			return
+		}
+		change := FieldChange{Field: field}
+		if nAdded > 0 {
+			change.Added = added
+		}
+		if nRemoved > 0 {
+			change.Removed = removed
+		}
+		changes = append(changes, change)
+	}
+	ints := func(field string, a, b []int) {
+		added, removed := diffInts(a, b), diffInts(b, a)
+		list(field, added, removed, len(added), len(removed))
+	}
+	strs := func(field string, a, b []string) {
+		added, removed := diffStrings(a, b), diffStrings(b, a)
+		list(field, added, removed, len(added), len(removed))
+	}
+
+	a, b := from.Criteria, to.Criteria
+	text("name", from.Name, to.Name)
+	ints("business_types", a.BusinessTypes, b.BusinessTypes)
+	ints("industries", a.Industries, b.Industries)
+	ints("company_sizes", a.CompanySizes, b.CompanySizes)
+	ints("buyer_roles", a.BuyerRoles, b.BuyerRoles)
+	strs("geographies", a.Geographies, b.Geographies)
+	strs("required_technologies", a.RequiredTechnologies, b.RequiredTechnologies)
+	strs("excluded_technologies", a.ExcludedTechnologies, b.ExcludedTechnologies)
+	strs("funding_stages", a.FundingStages, b.FundingStages)
+	ints("revenue_bands", a.RevenueBands, b.RevenueBands)
+	text("problem_statement", a.ProblemStatement, b.ProblemStatement)
+	return changes
+}
+
+// diffInts returns the values of b missing from a.
+func diffInts(a, b []int) []int {
+	in := map[int]bool{}
+	for _, v := range a {
+		in[v] = true
+	}
+	var out []int
+	for _, v := range b {
+		if !in[v] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// diffStrings returns the values of b missing from a.
+func diffStrings(a, b []string) []string {
+	var out []string
+	for _, v := range b {
+		if !containsString(a, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
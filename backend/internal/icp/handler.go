@@ -1,10 +1,15 @@
 package icp
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type Handler struct {
@@ -17,23 +22,28 @@ func NewHandler(service *Service) *Handler {
 
 // CreateICPHandler godoc
 // @Summary Create a new ICP profile
-// @Description Creates a new Ideal Customer Profile for a given user
+// @Description Creates a new Ideal Customer Profile for a given user. Criteria are lists of IDs from the constants taxonomies, ISO country or region codes (names are accepted), funding stages and technology names; invalid values are reported per field.
 // @Tags ICP
 // @Accept json
 // @Produce json
 // @Param profile body ICPProfile true "ICP Profile Data"
 // @Success 201 {object} ICPProfile
-// @Failure 400 {object} map[string]string
+// @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]string
 // @Router /icp [post]
 func (h *Handler) CreateICPHandler(c *gin.Context) {
 	var profile ICPProfile
 	if err := c.ShouldBindJSON(&profile); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		writeBindError(c, err)
 		return
 	}
 
 	if err := h.service.CreateICP(&profile); err != nil {
+		var invalid *ValidationError
+		if errors.As(err, &invalid) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ICP criteria", "fields": invalid.Fields})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create ICP"})
 		return
 	}
@@ -76,6 +86,7 @@ func (h *Handler) GetICPByIDHandler(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param user_id path int true "User ID"
+// @Param include_archived query bool false "Include archived profiles"
 // @Success 200 {array} ICPProfile
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -88,7 +99,8 @@ func (h *Handler) ListICPsByUserHandler(c *gin.Context) {
 	// 	return
 	// }
 
-	profiles, err := h.service.ListICPsByUser(0)
+	includeArchived, _ := strconv.ParseBool(c.Query("include_archived"))
+	profiles, err := h.service.ListICPsByUser(0, includeArchived)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch ICPs"})
 		return
@@ -99,14 +111,16 @@ func (h *Handler) ListICPsByUserHandler(c *gin.Context) {
 
 // UpdateICPHandler godoc
 // @Summary Update an ICP profile
-// @Description Updates an existing ICP profile by ID
+// @Description Replaces an existing ICP profile by ID. Criteria are validated as on create. A change to the name or criteria is kept as a new revision; archived profiles cannot be updated.
 // @Tags ICP
 // @Accept json
 // @Produce json
 // @Param id path int true "ICP ID"
 // @Param profile body ICPProfile true "Updated ICP Profile Data"
 // @Success 200 {object} ICPProfile
-// @Failure 400 {object} map[string]string
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /icp/{id} [put]
 func (h *Handler) UpdateICPHandler(c *gin.Context) {
@@ -119,13 +133,23 @@ func (h *Handler) UpdateICPHandler(c *gin.Context) {
 
 	var profile ICPProfile
 	if err := c.ShouldBindJSON(&profile); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		writeBindError(c, err)
 		return
 	}
 
 	profile.ID = uint(id)
 	if err := h.service.UpdateICP(&profile); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update ICP"})
+		var invalid *ValidationError
+		switch {
+		case errors.As(err, &invalid):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ICP criteria", "fields": invalid.Fields})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "ICP not found"})
+		case errors.Is(err, ErrArchived):
+			c.JSON(http.StatusConflict, gin.H{"error": "ICP is archived"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update ICP"})
+		}
 		return
 	}
 
@@ -133,14 +157,15 @@ func (h *Handler) UpdateICPHandler(c *gin.Context) {
 }
 
 // DeleteICPHandler godoc
-// @Summary Delete an ICP profile
-// @Description Deletes an ICP profile by ID
+// @Summary Archive an ICP profile
+// @Description Archives an ICP profile by ID. Archived profiles keep their revisions and runs, stay readable by ID and can be cloned or unarchived, but are left out of listings and cannot be updated or run.
 // @Tags ICP
 // @Accept json
 // @Produce json
 // @Param id path int true "ICP ID"
-// @Success 204
+// @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /icp/{id} [delete]
 func (h *Handler) DeleteICPHandler(c *gin.Context) {
@@ -152,9 +177,473 @@ func (h *Handler) DeleteICPHandler(c *gin.Context) {
 	}
 
 	if err := h.service.DeleteICP(uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete ICP"})
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "ICP not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to archive ICP"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "ICP archived successfully"})
+}
+
+// UnarchiveICPHandler godoc
+// @Summary Unarchive an ICP profile
+// @Description Restores an archived ICP profile
+// @Tags ICP
+// @Produce json
+// @Param id path int true "ICP ID"
+// @Success 200 {object} ICPProfile
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /icp/{id}/unarchive [post]
+func (h *Handler) UnarchiveICPHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	profile, err := h.service.UnarchiveICP(uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "ICP not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unarchive ICP"})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// ListICPRevisionsHandler godoc
+// @Summary List the revisions of an ICP profile
+// @Description Lists the numbered snapshots of a profile's name and criteria, newest first
+// @Tags ICP
+// @Produce json
+// @Param id path int true "ICP ID"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Revisions to skip"
+// @Success 200 {object} RevisionsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /icp/{id}/revisions [get]
+func (h *Handler) ListICPRevisionsHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	var req PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	resp, err := h.service.ListRevisions(c.Request.Context(), uint(id), req)
+	if err != nil {
+		writeRevisionError(c, err, "Failed to list ICP revisions")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetICPRevisionHandler godoc
+// @Summary Get a revision of an ICP profile
+// @Tags ICP
+// @Produce json
+// @Param id path int true "ICP ID"
+// @Param version path int true "Revision number"
+// @Success 200 {object} ICPRevision
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /icp/{id}/revisions/{version} [get]
+func (h *Handler) GetICPRevisionHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
 		return
 	}
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version"})
+		return
+	}
+
+	revision, err := h.service.GetRevision(c.Request.Context(), uint(id), version)
+	if err != nil {
+		writeRevisionError(c, err, "Failed to fetch ICP revision")
+		return
+	}
+
+	c.JSON(http.StatusOK, revision)
+}
+
+// DiffICPHandler godoc
+// @Summary Compare two revisions of an ICP profile
+// @Description Lists the values added to and removed from each criterion, and the old and new text of the name and problem statement, between two versions. to defaults to the current version and from to the one before it; version 0 stands for an empty profile.
+// @Tags ICP
+// @Produce json
+// @Param id path int true "ICP ID"
+// @Param from query int false "Earlier version"
+// @Param to query int false "Later version"
+// @Success 200 {object} ICPDiff
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /icp/{id}/diff [get]
+func (h *Handler) DiffICPHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	from, to := -1, 0
+	for name, v := range map[string]*int{"from": &from, "to": &to} {
+		if raw := c.Query(name); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid %s version", name)})
+				return
+			}
+			*v = n
+		}
+	}
+
+	diff, err := h.service.Diff(c.Request.Context(), uint(id), from, to)
+	if err != nil {
+		writeRevisionError(c, err, "Failed to compare ICP revisions")
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// CloneICPHandler godoc
+// @Summary Clone an ICP profile
+// @Description Creates a new profile from the current or a given version of an existing one, archived or not
+// @Tags ICP
+// @Accept json
+// @Produce json
+// @Param id path int true "ICP ID"
+// @Param request body CloneRequest false "Clone name and source version"
+// @Success 201 {object} ICPProfile
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /icp/{id}/clone [post]
+func (h *Handler) CloneICPHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	var req CloneRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			writeBindError(c, err)
+			return
+		}
+	}
+
+	profile, err := h.service.CloneICP(c.Request.Context(), uint(id), req)
+	if err != nil {
+		var invalid *ValidationError
+		if errors.As(err, &invalid) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ICP criteria", "fields": invalid.Fields})
+			return
+		}
+		writeRevisionError(c, err, "Failed to clone ICP")
+		return
+	}
+
+	c.JSON(http.StatusCreated, profile)
+}
+
+// ListTemplatesHandler godoc
+// @Summary List the built-in ICP templates
+// @Description Lists the built-in starting points for new profiles, with their criteria spelled out using the taxonomy names
+// @Tags ICP
+// @Produce json
+// @Success 200 {array} ICPTemplate
+// @Router /icp/templates [get]
+func (h *Handler) ListTemplatesHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, Templates())
+}
 
-	c.JSON(http.StatusOK, gin.H{"message": "ICP deleted successfully"})
+// CreateFromTemplateHandler godoc
+// @Summary Create an ICP profile from a template
+// @Tags ICP
+// @Accept json
+// @Produce json
+// @Param key path string true "Template key"
+// @Param request body TemplateRequest false "Profile name and owner"
+// @Success 201 {object} ICPProfile
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /icp/templates/{key} [post]
+func (h *Handler) CreateFromTemplateHandler(c *gin.Context) {
+	var req TemplateRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			writeBindError(c, err)
+			return
+		}
+	}
+
+	profile, err := h.service.CreateFromTemplate(c.Param("key"), req)
+	if err != nil {
+		var invalid *ValidationError
+		switch {
+		case errors.As(err, &invalid):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ICP criteria", "fields": invalid.Fields})
+		case errors.Is(err, ErrTemplateNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create ICP"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, profile)
+}
+
+// RunICPHandler godoc
+// @Summary Run an ICP profile as a search
+// @Description Translates the profile into an advanced company search, returns a page of the matching local companies and queues an enrichment job carrying the profile ID. Each run is recorded, attributing companies not matched by earlier runs to the profile. queued_job is omitted when the queue is unavailable. Profiles without company criteria are rejected, as are profiles whose criteria no longer resolve (e.g. a deactivated industry); those values are listed per field.
+// @Tags ICP
+// @Produce json
+// @Param id path int true "ICP ID"
+// @Param limit query int false "Companies per page (default 20, max 100)"
+// @Param offset query int false "Companies to skip"
+// @Success 200 {object} RunResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /icp/{id}/run [post]
+func (h *Handler) RunICPHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	var req PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	resp, err := h.service.RunICP(c.Request.Context(), uint(id), req)
+	if err != nil {
+		var stale *StaleCriteriaError
+		switch {
+		case errors.As(err, &stale):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ICP criteria no longer resolve", "fields": stale.Fields})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "ICP not found"})
+		case errors.Is(err, ErrArchived):
+			c.JSON(http.StatusConflict, gin.H{"error": "ICP is archived"})
+		case errors.Is(err, ErrNoCriteria):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to run ICP"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListICPRunsHandler godoc
+// @Summary List the runs of an ICP profile
+// @Description Lists a profile's runs, newest first, with their local match counts and the outcome of their enrichment jobs
+// @Tags ICP
+// @Produce json
+// @Param id path int true "ICP ID"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Runs to skip"
+// @Success 200 {object} RunsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /icp/{id}/runs [get]
+func (h *Handler) ListICPRunsHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	var req PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	resp, err := h.service.ListRuns(c.Request.Context(), uint(id), req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "ICP not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list ICP runs"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// LookalikesHandler godoc
+// @Summary Propose an ICP from seed companies
+// @Description Analyzes the industries, sizes, funding rounds, technologies and locations of seed companies, such as the best customers, and proposes an unsaved draft ICP with the share of seeds holding each value. Other companies sharing any of those values are returned ranked by similarity (0-1).
+// @Tags ICP
+// @Accept json
+// @Produce json
+// @Param request body LookalikeRequest true "Seed company IDs"
+// @Success 200 {object} LookalikeResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /icp/lookalikes [post]
+func (h *Handler) LookalikesHandler(c *gin.Context) {
+	var req LookalikeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+
+	resp, err := h.service.Lookalikes(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, ErrNoSeeds) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to find lookalikes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ICPAnalyticsHandler godoc
+// @Summary Get the performance of an ICP profile
+// @Description Counts, per time bucket, the companies the profile's runs first matched, its runs, the enrichment jobs they queued and completed, and how many matched companies were added to prospect lists or moved to qualified after being matched. Buckets start at midnight UTC, on Mondays for weeks and on the first for months.
+// @Tags ICP
+// @Produce json
+// @Param id path int true "ICP ID"
+// @Param interval query string false "Bucket size: day (default), week or month"
+// @Param from query string false "Start, RFC 3339 or YYYY-MM-DD (default 30 days, 12 weeks or 12 months back)"
+// @Param to query string false "End, RFC 3339 or YYYY-MM-DD (default now)"
+// @Success 200 {object} AnalyticsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /icp/{id}/analytics [get]
+func (h *Handler) ICPAnalyticsHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	var req AnalyticsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	resp, err := h.service.Analytics(c.Request.Context(), uint(id), req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "ICP not found"})
+			return
+		}
+		writeAnalyticsError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ICPSummaryHandler godoc
+// @Summary Compare the performance of ICP profiles
+// @Description Totals the analytics metrics of every profile, archived ones included, over a period, ranked by qualified accounts, then list additions and matches
+// @Tags ICP
+// @Produce json
+// @Param from query string false "Start, RFC 3339 or YYYY-MM-DD (default 30 days back)"
+// @Param to query string false "End, RFC 3339 or YYYY-MM-DD (default now)"
+// @Success 200 {object} SummaryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /icp/analytics [get]
+func (h *Handler) ICPSummaryHandler(c *gin.Context) {
+	var req AnalyticsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	resp, err := h.service.Summary(c.Request.Context(), req)
+	if err != nil {
+		writeAnalyticsError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func writeAnalyticsError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidInterval), errors.Is(err, ErrInvalidRange), errors.Is(err, ErrTooManyBuckets):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute ICP analytics"})
+	}
+}
+
+// writeRevisionError maps a missing profile or revision to 404.
+func writeRevisionError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "ICP not found"})
+	case errors.Is(err, ErrRevisionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// writeBindError explains why an ICP payload could not be decoded, naming the
+// offending field when the JSON has the wrong type.
+func writeBindError(c *gin.Context, err error) {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Invalid request payload",
+			"fields": map[string]string{typeErr.Field: fmt.Sprintf("expected %s, got %s", typeErr.Type, typeErr.Value)},
+		})
+		return
+	}
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid request payload: malformed JSON at offset %d", syntaxErr.Offset)})
+		return
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: JSON ends unexpectedly"})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
 }
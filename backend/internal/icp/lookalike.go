@@ -0,0 +1,367 @@
+package icp
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+)
+
+const (
+	maxSeeds = 100
+
+	// Candidates read at a time; only companies sharing a seed value are read
+	lookalikeBatchSize = 500
+
+	// A value joins the draft when at least this share of the seeds has it
+	draftMinShare = 1.0 / 3
+	// Technologies become required when nearly every seed uses them
+	requiredTechMinShare = 0.75
+	maxRequiredTechs     = 5
+)
+
+// How much each criterion contributes to a lookalike's similarity. Criteria
+// the seeds have no data for are left out and the rest reweighted.
+var criterionWeights = map[string]float64{
+	"industries":     0.30,
+	"technologies":   0.25,
+	"company_sizes":  0.20,
+	"geographies":    0.15,
+	"funding_stages": 0.10,
+}
+
+var ErrNoSeeds = errors.New("none of the seed companies exist")
+
+// LookalikeRequest names the seed companies, typically the best customers.
+type LookalikeRequest struct {
+	CompanyIDs []uint `json:"company_ids" binding:"required,min=1"`
+	Limit      int    `json:"limit"` // lookalikes to return (default 20, max 100)
+}
+
+// LookalikeWeights holds, per criterion, the share of seed companies having
+// each value. Technologies are lowercased and geographies are country codes.
+type LookalikeWeights struct {
+	Industries    map[int]float64    `json:"industries"`
+	CompanySizes  map[int]float64    `json:"company_sizes"`
+	FundingStages map[string]float64 `json:"funding_stages"`
+	Technologies  map[string]float64 `json:"technologies"`
+	Geographies   map[string]float64 `json:"geographies"`
+}
+
+// Lookalike is a company ranked by its similarity to the seeds, between 0
+// and 1, with the criteria it shares values with.
+type Lookalike struct {
+	Company    model.Company `json:"company"`
+	Similarity float64       `json:"similarity"`
+	Matched    []string      `json:"matched"`
+}
+
+type LookalikeResponse struct {
+	Draft      ICPProfile       `json:"draft"` // unsaved; create it with POST /icp
+	Weights    LookalikeWeights `json:"weights"`
+	SeedIDs    []uint           `json:"seed_ids"`
+	MissingIDs []uint           `json:"missing_ids,omitempty"`
+	Candidates int              `json:"candidates"` // companies scored, all sharing a seed value
+	Lookalikes []Lookalike      `json:"lookalikes"`
+}
+
+// Lookalikes analyzes the seed companies' industries, sizes, funding rounds,
+// technologies and locations, proposes a draft profile covering their common
+// values and ranks the other companies by similarity to them.
+func (s *Service) Lookalikes(ctx context.Context, req LookalikeRequest) (*LookalikeResponse, error) {
+	ids := dedupeIDs(req.CompanyIDs)
+	if len(ids) > maxSeeds {
+		ids = ids[:maxSeeds]
+	}
+	seeds, err := s.repo.Companies(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(seeds) == 0 {
+		return nil, ErrNoSeeds
+	}
+
+	resp := &LookalikeResponse{SeedIDs: []uint{}, Lookalikes: []Lookalike{}}
+	found := map[uint]bool{}
+	for _, c := range seeds {
+		found[c.ID] = true
+		resp.SeedIDs = append(resp.SeedIDs, c.ID)
+	}
+	for _, id := range ids {
+		if !found[id] {
+			resp.MissingIDs = append(resp.MissingIDs, id)
+		}
+	}
+
+	resp.Weights = seedWeights(seeds)
+	resp.Draft = draftProfile(resp.Weights)
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	// every candidate is scored, keeping only the best limit of them
+	var last uint
+	for {
+		candidates, err := s.repo.LookalikeCandidatesAfter(ctx, ids, resp.Weights, last, lookalikeBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range candidates {
+			last = c.ID
+			if similarity, matched := resp.Weights.similarity(c); similarity > 0 {
+				resp.Lookalikes = keepBest(resp.Lookalikes, Lookalike{Company: c, Similarity: similarity, Matched: matched}, limit)
+			}
+		}
+		resp.Candidates += len(candidates)
+		if len(candidates) < lookalikeBatchSize {
+			break
+		}
+	}
+	return resp, nil
+}
+
+// keepBest inserts l into best, ordered by similarity with earlier companies
+// first among equals, and drops whatever falls beyond limit.
+func keepBest(best []Lookalike, l Lookalike, limit int) []Lookalike {
+	i := sort.Search(len(best), func(i int) bool { return best[i].Similarity < l.Similarity })
+	if i >= limit {
+		return best
+	}
+	best = append(best, Lookalike{})
+	copy(best[i+1:], best[i:])
+	best[i] = l
+	if len(best) > limit {
+		best = best[:limit]
+	}
+	return best
+}
+
+// seedWeights counts, per criterion, the share of seeds having each value.
+func seedWeights(seeds []model.Company) LookalikeWeights {
+	w := LookalikeWeights{
+		Industries:    map[int]float64{},
+		CompanySizes:  map[int]float64{},
+		FundingStages: map[string]float64{},
+		Technologies:  map[string]float64{},
+		Geographies:   map[string]float64{},
+	}
+	share := 1 / float64(len(seeds))
+	for _, c := range seeds {
+		if c.IndustryID != nil {
+			w.Industries[*c.IndustryID] += share
+		}
+		if c.EmployeeSizeID != nil {
+			w.CompanySizes[*c.EmployeeSizeID] += share
+		}
+		for v := range fundingStagesOf(c) {
+			w.FundingStages[v] += share
+		}
+		for v := range technologiesOf(c) {
+			w.Technologies[v] += share
+		}
+		for v := range countriesOf(c) {
+			w.Geographies[v] += share
+		}
+	}
+	for _, m := range []map[string]float64{w.FundingStages, w.Technologies, w.Geographies} {
+		for k, v := range m {
+			m[k] = round(v)
+		}
+	}
+	for _, m := range []map[int]float64{w.Industries, w.CompanySizes} {
+		for k, v := range m {
+			m[k] = round(v)
+		}
+	}
+	return w
+}
+
+// draftProfile keeps the values a third of the seeds share and requires the
+// technologies nearly all of them use.
+func draftProfile(w LookalikeWeights) ICPProfile {
+	draft := ICPProfile{ICPCriteria: ICPCriteria{
+		Industries:    commonInts(w.Industries, draftMinShare),
+		CompanySizes:  commonInts(w.CompanySizes, draftMinShare),
+		FundingStages: []string{},
+		Geographies:   commonStrings(w.Geographies, draftMinShare),
+	}}
+	for _, stage := range commonStrings(w.FundingStages, draftMinShare) {
+		if isFundingStage(stage) {
+			draft.FundingStages = append(draft.FundingStages, stage)
+		}
+	}
+	techs := commonStrings(w.Technologies, requiredTechMinShare)
+	sort.SliceStable(techs, func(i, j int) bool { return w.Technologies[techs[i]] > w.Technologies[techs[j]] })
+	if len(techs) > maxRequiredTechs {
+		techs = techs[:maxRequiredTechs]
+	}
+	draft.RequiredTechnologies = techs
+	normalize(&draft.ICPCriteria)
+	return draft
+}
+
+// similarity scores a company against the seed weights. Industry, size,
+// funding and location score the seeds' share of the company's value relative
+// to the most common one; technologies score the share of the seeds'
+// technology weight the company covers.
+func (w LookalikeWeights) similarity(c model.Company) (float64, []string) {
+	var score, total float64
+	matched := []string{}
+	add := func(criterion string, weights int, s float64) {
+		if weights == 0 {
+			return
+		}
+		total += criterionWeights[criterion]
+		if s > 0 {
+			score += criterionWeights[criterion] * s
+			matched = append(matched, criterion)
+		}
+	}
+
+	industry := 0.0
+	if c.IndustryID != nil && len(w.Industries) > 0 {
+		industry = w.Industries[*c.IndustryID] / topShare(w.Industries)
+	}
+	add("industries", len(w.Industries), industry)
+
+	size := 0.0
+	if c.EmployeeSizeID != nil && len(w.CompanySizes) > 0 {
+		size = w.CompanySizes[*c.EmployeeSizeID] / topShare(w.CompanySizes)
+	}
+	add("company_sizes", len(w.CompanySizes), size)
+
+	add("funding_stages", len(w.FundingStages), bestShare(w.FundingStages, fundingStagesOf(c)))
+	add("geographies", len(w.Geographies), bestShare(w.Geographies, countriesOf(c)))
+
+	var covered, all float64
+	techs := technologiesOf(c)
+	for tech, v := range w.Technologies {
+		all += v
+		if techs[tech] {
+			covered += v
+		}
+	}
+	tech := 0.0
+	if all > 0 {
+		tech = covered / all
+	}
+	add("technologies", len(w.Technologies), tech)
+
+	if total == 0 {
+		return 0, matched
+	}
+	return round(score / total), matched
+}
+
+func fundingStagesOf(c model.Company) map[string]bool {
+	stages := map[string]bool{}
+	for _, r := range c.FundingRounds {
+		stages[string(r.RoundType)] = true
+	}
+	return stages
+}
+
+func technologiesOf(c model.Company) map[string]bool {
+	techs := map[string]bool{}
+	for _, t := range c.Technologies {
+		if name := strings.ToLower(strings.TrimSpace(t.TechnologyName)); name != "" {
+			techs[name] = true
+		}
+	}
+	return techs
+}
+
+func countriesOf(c model.Company) map[string]bool {
+	countries := map[string]bool{}
+	for _, l := range c.Locations {
+		if l.CountryCode != nil && *l.CountryCode != "" {
+			countries[*l.CountryCode] = true
+		}
+	}
+	return countries
+}
+
+// bestShare is the weight of the company's most common value among the seeds
+// relative to the most common seed value.
+func bestShare(weights map[string]float64, values map[string]bool) float64 {
+	best, top := 0.0, 0.0
+	for k, v := range weights {
+		top = math.Max(top, v)
+		if values[k] {
+			best = math.Max(best, v)
+		}
+	}
+	if top == 0 {
+		return 0
+	}
+	return best / top
+}
+
+func topShare(weights map[int]float64) float64 {
+	top := 0.0
+	for _, v := range weights {
+		top = math.Max(top, v)
+	}
+	return top
+}
+
+func commonInts(weights map[int]float64, minShare float64) []int {
+	var out []int
+	for k, v := range weights {
+		if v >= round(minShare) {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+func commonStrings(weights map[string]float64, minShare float64) []string {
+	var out []string
+	for k, v := range weights {
+		if v >= round(minShare) {
+			out = append(out, k)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func keysInt(m map[int]float64) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func keysString(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func dedupeIDs(ids []uint) []uint {
+	var out []uint
+	seen := map[uint]bool{}
+	for _, id := range ids {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+func round(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
@@ -1,9 +1,95 @@
 package icp
 
 import (
+	"log"
+
+	"github.com/bhati00/Fynelo/backend/internal/taxonomy"
 	"github.com/bhati00/Fynelo/backend/pkg/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func Migrate() {
-	database.DB.AutoMigrate(&ICPProfile{})
+	database.DB.AutoMigrate(&ICPProfile{}, &ICPRevision{}, &ICPRun{}, &ICPMatch{})
+	if err := backfillLegacyCriteria(); err != nil {
+		log.Printf("ICP migration: failed to convert single-value criteria: %v", err)
+	}
+	if err := backfillRevisions(); err != nil {
+		log.Printf("ICP migration: failed to record initial revisions: %v", err)
+	}
+}
+
+// backfillRevisions records profiles created before revisions were kept as
+// version 1.
+func backfillRevisions() error {
+	var profiles []ICPProfile
+	if err := database.DB.Where("version = 0 OR version IS NULL").Find(&profiles).Error; err != nil {
+		return err
+	}
+	for _, profile := range profiles {
+		profile.Version = 1
+		err := database.DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&profile).UpdateColumn("version", profile.Version).Error; err != nil {
+				return err
+			}
+			return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(profile.revision()).Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillLegacyCriteria copies the single-value business_type, industry,
+// company_size and buyer_roles columns of profiles created before criteria
+// became lists into the list columns. The old columns are left in place.
+func backfillLegacyCriteria() error {
+	db := database.DB
+	legacy := []string{"business_type", "industry", "company_size", "buyer_roles"}
+	for _, column := range legacy {
+		if !db.Migrator().HasColumn(&ICPProfile{}, column) {
+			return nil
+		}
+	}
+
+	var rows []struct {
+		ID           uint
+		BusinessType int
+		Industry     string
+		CompanySize  int
+		BuyerRoles   int
+	}
+	if err := db.Table("icp_profiles").
+		Select("id, business_type, industry, company_size, buyer_roles").
+		Where("business_types IS NULL AND industries IS NULL AND company_sizes IS NULL AND buyer_role_ids IS NULL").
+		Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		profile := ICPProfile{ID: row.ID}
+		terms := taxonomy.Default()
+		if terms.Valid(taxonomy.BusinessTypes, row.BusinessType) {
+			profile.BusinessTypes = []int{row.BusinessType}
+		}
+		if id, ok := terms.Lookup(taxonomy.Industries, row.Industry); ok {
+			profile.Industries = []int{id}
+		}
+		if terms.Valid(taxonomy.CompanySizes, row.CompanySize) {
+			profile.CompanySizes = []int{row.CompanySize}
+		}
+		if terms.Valid(taxonomy.BuyerRoles, row.BuyerRoles) {
+			profile.BuyerRoles = []int{row.BuyerRoles}
+		}
+		normalize(&profile.ICPCriteria)
+		if err := db.Model(&profile).
+			Select("business_types", "industries", "company_sizes", "buyer_role_ids", "geographies",
+				"required_technologies", "excluded_technologies", "funding_stages", "revenue_bands").
+			UpdateColumns(&profile).Error; err != nil {
+			return err
+		}
+	}
+	return nil
 }
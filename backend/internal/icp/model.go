@@ -6,16 +6,33 @@ import (
 	"gorm.io/gorm"
 )
 
+// ICPProfile describes the companies and people a user sells to. Updates
+// that change its name or criteria are kept as numbered revisions, and
+// deleting a profile archives it.
 type ICPProfile struct {
-	ID               uint      `gorm:"primaryKey" json:"id"`
-	UserId           uint      `json:"user_id"`
-	BusinessType     int       `json:"business_type"`
-	Industry         string    `json:"industry"`
-	CompanySize      int       `json:"company_size"`
-	BuyerRoles       int       `json:"buyer_roles"`
-	ProblemStatement string    `json:"problem_statement"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	UserId      uint       `json:"user_id"`
+	Name        string     `gorm:"size:200" json:"name"`
+	ICPCriteria            // criteria columns and JSON fields are inlined
+	Version     int        `json:"version"`                            // latest revision
+	ArchivedAt  *time.Time `gorm:"index" json:"archived_at,omitempty"` // set instead of deleting
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// ICPCriteria holds the criteria of a profile. Every criterion is a list; an
+// empty list places no constraint. IDs refer to the taxonomies in constants.
+type ICPCriteria struct {
+	BusinessTypes        []int    `gorm:"serializer:json" json:"business_types"`                    // constants.BusinessType*
+	Industries           []int    `gorm:"serializer:json" json:"industries"`                        // constants.Industry*
+	CompanySizes         []int    `gorm:"serializer:json" json:"company_sizes"`                     // constants.CompanySize*
+	BuyerRoles           []int    `gorm:"column:buyer_role_ids;serializer:json" json:"buyer_roles"` // constants.BuyerRole*
+	Geographies          []string `gorm:"serializer:json" json:"geographies"`                       // ISO-3166 country or ISO-3166-2 region codes
+	RequiredTechnologies []string `gorm:"serializer:json" json:"required_technologies"`             // companies must use all of these
+	ExcludedTechnologies []string `gorm:"serializer:json" json:"excluded_technologies"`             // companies must use none of these
+	FundingStages        []string `gorm:"serializer:json" json:"funding_stages"`                    // model.FundingRoundType values
+	RevenueBands         []int    `gorm:"serializer:json" json:"revenue_bands"`                     // constants.RevenueBand*
+	ProblemStatement     string   `json:"problem_statement"`
 }
 
 func (p *ICPProfile) BeforeCreate(tx *gorm.DB) error {
@@ -30,3 +47,57 @@ func (p *ICPProfile) BeforeUpdate(tx *gorm.DB) error {
 	p.UpdatedAt = time.Now()
 	return nil
 }
+
+// ICPRun records one run of a profile as a company search: the query it
+// translated to, the local matches at the time and the enrichment job queued
+// for it. The job columns are filled in by the worker when the job finishes.
+type ICPRun struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	ICPID          uint       `gorm:"index;not null" json:"icp_id"`
+	Query          string     `gorm:"type:text" json:"query"` // advanced-mode search query
+	Matched        int64      `json:"matched"`                // local companies matching the profile
+	NewMatches     int        `json:"new_matches"`            // matches not seen on earlier runs
+	JobID          string     `gorm:"size:64;index" json:"job_id,omitempty"`
+	JobStatus      string     `gorm:"size:20" json:"job_status,omitempty"` // queue.JobStatus values
+	JobResultCount int        `json:"job_result_count"`
+	JobError       string     `gorm:"type:text" json:"job_error,omitempty"`
+	JobFinishedAt  *time.Time `json:"job_finished_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func (ICPRun) TableName() string {
+	return "icp_runs"
+}
+
+// ICPMatch attributes a company to the profile whose run first matched it.
+type ICPMatch struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	ICPID     uint      `gorm:"not null;uniqueIndex:idx_icp_matches_icp_company" json:"icp_id"`
+	CompanyID uint      `gorm:"not null;uniqueIndex:idx_icp_matches_icp_company" json:"company_id"`
+	RunID     uint      `gorm:"not null" json:"run_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (ICPMatch) TableName() string {
+	return "icp_matches"
+}
+
+// ICPRevision is a numbered snapshot of a profile's name and criteria, taken
+// when the profile is created and whenever an update changes them.
+type ICPRevision struct {
+	ID        uint        `gorm:"primaryKey" json:"id"`
+	ICPID     uint        `gorm:"not null;uniqueIndex:idx_icp_revisions_icp_version" json:"icp_id"`
+	Version   int         `gorm:"not null;uniqueIndex:idx_icp_revisions_icp_version" json:"version"`
+	Name      string      `gorm:"size:200" json:"name"`
+	Criteria  ICPCriteria `gorm:"serializer:json" json:"criteria"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+func (ICPRevision) TableName() string {
+	return "icp_revisions"
+}
+
+// revision snapshots the profile at its current version.
+func (p *ICPProfile) revision() *ICPRevision {
+	return &ICPRevision{ICPID: p.ID, Version: p.Version, Name: p.Name, Criteria: p.ICPCriteria, CreatedAt: p.UpdatedAt}
+}
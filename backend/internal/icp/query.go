@@ -0,0 +1,113 @@
+package icp
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/querylang"
+)
+
+// StaleCriteriaError lists the criteria values that no longer resolve, such
+// as an industry deactivated since the profile was saved, keyed by the
+// criterion's JSON field name.
+type StaleCriteriaError struct {
+	Fields map[string][]string `json:"fields"`
+}
+
+func (e *StaleCriteriaError) Error() string {
+	names := make([]string, 0, len(e.Fields))
+	for name := range e.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + ": " + strings.Join(e.Fields[name], ", ")
+	}
+	return "ICP criteria no longer resolve: " + strings.Join(parts, "; ")
+}
+
+// Expression translates the criteria into a company search expression: values
+// within a criterion are alternatives, criteria must all hold, and every
+// required technology must be present. Business types and buyer roles do not
+// describe companies and are not part of it. Values that do not resolve fail
+// with a StaleCriteriaError rather than being dropped, which would widen the
+// search; a nil result matches every company.
+func (c *ICPCriteria) Expression() (querylang.Node, error) {
+	b := &expressionBuilder{stale: map[string][]string{}}
+	var terms []querylang.Node
+	terms = append(terms, b.anyOf("industries", querylang.FieldIndustry, intValues(c.Industries)))
+	terms = append(terms, b.anyOf("company_sizes", querylang.FieldSize, intValues(c.CompanySizes)))
+	terms = append(terms, b.anyOf("funding_stages", querylang.FieldFunding, c.FundingStages))
+	terms = append(terms, b.anyOf("revenue_bands", querylang.FieldRevenue, intValues(c.RevenueBands)))
+
+	var places []querylang.Node
+	for _, code := range c.Geographies {
+		field := querylang.FieldCountry
+		if strings.Contains(code, "-") {
+			field = querylang.FieldState
+		}
+		places = append(places, b.term("geographies", field, code))
+	}
+	terms = append(terms, querylang.Or(places...))
+
+	for _, tech := range c.RequiredTechnologies {
+		terms = append(terms, b.term("required_technologies", querylang.FieldTech, tech))
+	}
+	for _, tech := range c.ExcludedTechnologies {
+		if t := b.term("excluded_technologies", querylang.FieldTech, tech); t != nil {
+			terms = append(terms, &querylang.NotNode{Expr: t})
+		}
+	}
+	if len(b.stale) > 0 {
+		return nil, &StaleCriteriaError{Fields: b.stale}
+	}
+	return querylang.And(terms...), nil
+}
+
+// CompanyExpression is the expression the profile searches companies by.
+// Profiles without company criteria would match every company and fail with
+// ErrNoCriteria; criteria that no longer resolve fail as in Expression.
+func (p *ICPProfile) CompanyExpression() (querylang.Node, error) {
+	expr, err := p.Expression()
+	if err != nil {
+		return nil, err
+	}
+	if expr == nil {
+		return nil, ErrNoCriteria
+	}
+	return expr, nil
+}
+
+// expressionBuilder collects the criteria values that do not resolve.
+type expressionBuilder struct {
+	stale map[string][]string
+}
+
+func (b *expressionBuilder) anyOf(criterion string, field querylang.Field, values []string) querylang.Node {
+	var terms []querylang.Node
+	for _, v := range values {
+		terms = append(terms, b.term(criterion, field, v))
+	}
+	return querylang.Or(terms...)
+}
+
+// term returns nil rather than a typed nil pointer when value does not resolve,
+// so querylang.And and Or skip it.
+func (b *expressionBuilder) term(criterion string, field querylang.Field, value string) querylang.Node {
+	t, err := querylang.NewTerm(field, querylang.OpEq, value)
+	if err != nil {
+		b.stale[criterion] = append(b.stale[criterion], value)
+		return nil
+	}
+	return t
+}
+
+func intValues(ids []int) []string {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = strconv.Itoa(id)
+	}
+	return values
+}
@@ -1,9 +1,16 @@
 package icp
 
 import (
+	"context"
 	"errors"
+	"time"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/lists"
+	"github.com/bhati00/Fynelo/backend/internal/queue"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository struct {
@@ -14,9 +21,14 @@ func NewRepository(db *gorm.DB) *Repository {
 	return &Repository{db: db}
 }
 
-// Create a new ICPProfile
+// Create a new ICPProfile along with its first revision
 func (r *Repository) CreateICP(profile *ICPProfile) error {
-	return r.db.Create(profile).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(profile).Error; err != nil {
+			return err
+		}
+		return tx.Create(profile.revision()).Error
+	})
 }
 
 // Get a single ICPProfile by ID
@@ -28,24 +40,230 @@ func (r *Repository) GetICPByID(id uint) (*ICPProfile, error) {
 	return &profile, nil
 }
 
-// Get all ICPProfiles for a user
-func (r *Repository) ListICPsByUser(userID uint) ([]ICPProfile, error) {
+// Get all ICPProfiles for a user, leaving out archived ones unless asked
+func (r *Repository) ListICPsByUser(userID uint, includeArchived bool) ([]ICPProfile, error) {
 	var profiles []ICPProfile
-	if err := r.db.Where("user_id = ?", userID).Find(&profiles).Error; err != nil {
+	tx := r.db.Where("user_id = ?", userID)
+	if !includeArchived {
+		tx = tx.Where("archived_at IS NULL")
+	}
+	if err := tx.Find(&profiles).Error; err != nil {
 		return nil, err
 	}
 	return profiles, nil
 }
 
-// Update an existing ICPProfile
-func (r *Repository) UpdateICP(profile *ICPProfile) error {
+// Update an existing ICPProfile, recording its current version as a new
+// revision when revised is set
+func (r *Repository) UpdateICP(profile *ICPProfile, revised bool) error {
 	if profile.ID == 0 {
 		return errors.New("profile ID must be set")
 	}
-	return r.db.Save(profile).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(profile).Error; err != nil {
+			return err
+		}
+		if !revised {
+			return nil
+		}
+		return tx.Create(profile.revision()).Error
+	})
+}
+
+// SetArchived archives an ICPProfile at the given time, or restores it when
+// archivedAt is nil
+func (r *Repository) SetArchived(id uint, archivedAt *time.Time) error {
+	result := r.db.Model(&ICPProfile{}).Where("id = ?", id).UpdateColumn("archived_at", archivedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Revisions returns a profile's revisions, newest first, and their total.
+func (r *Repository) Revisions(ctx context.Context, icpID uint, limit, offset int) ([]ICPRevision, int64, error) {
+	tx := r.db.WithContext(ctx).Model(&ICPRevision{}).Where("icp_id = ?", icpID)
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var revisions []ICPRevision
+	if err := tx.Order("version DESC").Limit(limit).Offset(offset).Find(&revisions).Error; err != nil {
+		return nil, 0, err
+	}
+	return revisions, total, nil
+}
+
+// Revision returns one version of a profile.
+func (r *Repository) Revision(ctx context.Context, icpID uint, version int) (*ICPRevision, error) {
+	var revision ICPRevision
+	if err := r.db.WithContext(ctx).Where("icp_id = ? AND version = ?", icpID, version).First(&revision).Error; err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// MatchedCompanyIDs returns the companies earlier runs of a profile matched.
+func (r *Repository) MatchedCompanyIDs(ctx context.Context, icpID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).Model(&ICPMatch{}).Where("icp_id = ?", icpID).Pluck("company_id", &ids).Error
+	return ids, err
+}
+
+// SaveRun records a run and attributes the given companies to it.
+func (r *Repository) SaveRun(ctx context.Context, run *ICPRun, companyIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(run).Error; err != nil {
+			return err
+		}
+		matches := make([]ICPMatch, len(companyIDs))
+		for i, companyID := range companyIDs {
+			matches[i] = ICPMatch{ICPID: run.ICPID, CompanyID: companyID, RunID: run.ID}
+		}
+		if len(matches) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(matches, 100).Error
+	})
+}
+
+// Runs returns a profile's runs, newest first, and their total.
+func (r *Repository) Runs(ctx context.Context, icpID uint, limit, offset int) ([]ICPRun, int64, error) {
+	tx := r.db.WithContext(ctx).Model(&ICPRun{}).Where("icp_id = ?", icpID)
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var runs []ICPRun
+	if err := tx.Order("id DESC").Limit(limit).Offset(offset).Find(&runs).Error; err != nil {
+		return nil, 0, err
+	}
+	return runs, total, nil
 }
 
-// Delete an ICPProfile
-func (r *Repository) DeleteICP(id uint) error {
-	return r.db.Delete(&ICPProfile{}, id).Error
+// FinishJob stores the outcome of the enrichment job queued by a run.
+func (r *Repository) FinishJob(ctx context.Context, jobID, status string, resultCount int, errorMsg string, finishedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&ICPRun{}).Where("job_id = ?", jobID).UpdateColumns(map[string]interface{}{
+		"job_status":       status,
+		"job_result_count": resultCount,
+		"job_error":        errorMsg,
+		"job_finished_at":  finishedAt,
+	}).Error
+}
+
+// Companies loads companies with the child rows lookalike analysis reads.
+func (r *Repository) Companies(ctx context.Context, ids []uint) ([]model.Company, error) {
+	var companies []model.Company
+	err := r.withProfileData(ctx).Where("id IN ?", ids).Order("id").Find(&companies).Error
+	return companies, err
+}
+
+// LookalikeCandidatesAfter loads, in ID order, up to limit companies after
+// the given ID and outside exclude that share at least one industry, size,
+// funding stage, technology or country with the given values.
+func (r *Repository) LookalikeCandidatesAfter(ctx context.Context, exclude []uint, w LookalikeWeights, after uint, limit int) ([]model.Company, error) {
+	db := r.db.WithContext(ctx)
+	shared := db.Where("1 = 0")
+	if ids := keysInt(w.Industries); len(ids) > 0 {
+		shared = shared.Or("industry_id IN ?", ids)
+	}
+	if ids := keysInt(w.CompanySizes); len(ids) > 0 {
+		shared = shared.Or("employee_size_id IN ?", ids)
+	}
+	if stages := keysString(w.FundingStages); len(stages) > 0 {
+		shared = shared.Or("id IN (?)", db.Model(&model.FundingRound{}).Select("company_id").Where("round_type IN ?", stages))
+	}
+	if techs := keysString(w.Technologies); len(techs) > 0 {
+		shared = shared.Or("id IN (?)", db.Model(&model.Technology{}).Select("company_id").Where("LOWER(technology_name) IN ?", techs))
+	}
+	if countries := keysString(w.Geographies); len(countries) > 0 {
+		shared = shared.Or("id IN (?)", db.Model(&model.Location{}).Select("company_id").Where("country_code IN ?", countries))
+	}
+
+	var companies []model.Company
+	err := r.withProfileData(ctx).
+		Where("id > ? AND id NOT IN ?", after, exclude).
+		Where(shared).
+		Order("id").Limit(limit).
+		Find(&companies).Error
+	return companies, err
+}
+
+func (r *Repository) withProfileData(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Preload("FundingRounds").
+		Preload("Technologies").
+		Preload("Locations")
+}
+
+// metricEvents selects one (icp_id, at) row per event counted by a metric.
+// List activity counts toward a profile once one of its runs has matched the
+// company.
+func (r *Repository) metricEvents(ctx context.Context, metric Metric) *gorm.DB {
+	db := r.db.WithContext(ctx)
+	switch metric {
+	case MetricRuns:
+		return db.Model(&ICPRun{}).Select("icp_id, created_at AS at")
+	case MetricJobsQueued:
+		return db.Model(&ICPRun{}).Select("icp_id, created_at AS at").Where("job_id <> ''")
+	case MetricJobsCompleted:
+		return db.Model(&ICPRun{}).Select("icp_id, job_finished_at AS at").Where("job_status = ?", queue.StatusCompleted)
+	case MetricListAdditions:
+		return db.Model(&lists.Addition{}).
+			Select("icp_matches.icp_id, prospect_list_additions.created_at AS at").
+			Joins("JOIN icp_matches ON icp_matches.company_id = prospect_list_additions.company_id AND icp_matches.created_at <= prospect_list_additions.created_at")
+	case MetricQualified:
+		return db.Model(&lists.StatusChange{}).
+			Select("icp_matches.icp_id, prospect_list_status_changes.created_at AS at").
+			Joins("JOIN icp_matches ON icp_matches.company_id = prospect_list_status_changes.company_id AND icp_matches.created_at <= prospect_list_status_changes.created_at").
+			Where("prospect_list_status_changes.to_status = ?", lists.StatusQualified)
+	default:
+		return db.Model(&ICPMatch{}).Select("icp_id, created_at AS at")
+	}
+}
+
+// MetricTimes returns when a profile's events for a metric happened in [from, to).
+func (r *Repository) MetricTimes(ctx context.Context, metric Metric, icpID uint, from, to time.Time) ([]time.Time, error) {
+	var times []time.Time
+	err := r.db.WithContext(ctx).
+		Table("(?) AS events", r.metricEvents(ctx, metric)).
+		Where("icp_id = ? AND at >= ? AND at < ?", icpID, from, to).
+		Pluck("at", &times).Error
+	return times, err
+}
+
+// MetricCounts counts each profile's events for a metric in [from, to).
+func (r *Repository) MetricCounts(ctx context.Context, metric Metric, from, to time.Time) (map[uint]int64, error) {
+	var rows []struct {
+		ICPID uint
+		Count int64
+	}
+	err := r.db.WithContext(ctx).
+		Table("(?) AS events", r.metricEvents(ctx, metric)).
+		Select("icp_id, COUNT(*) AS count").
+		Where("at >= ? AND at < ?", from, to).
+		Group("icp_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.ICPID] = row.Count
+	}
+	return counts, nil
+}
+
+// ListAllICPs returns every profile, archived ones included.
+func (r *Repository) ListAllICPs(ctx context.Context) ([]ICPProfile, error) {
+	var profiles []ICPProfile
+	err := r.db.WithContext(ctx).Order("id").Find(&profiles).Error
+	return profiles, err
 }
@@ -6,9 +6,21 @@ func RegisterICPRoutes(rg *gin.RouterGroup, h *Handler) {
 	icp := rg.Group("/icp")
 	{
 		icp.POST("", h.CreateICPHandler)
+		icp.POST("/lookalikes", h.LookalikesHandler)
+		icp.GET("/templates", h.ListTemplatesHandler)
+		icp.GET("/analytics", h.ICPSummaryHandler)
+		icp.POST("/templates/:key", h.CreateFromTemplateHandler)
 		icp.GET("/:id", h.GetICPByIDHandler)
 		icp.GET("/user", h.ListICPsByUserHandler)
 		icp.PUT("/:id", h.UpdateICPHandler)
 		icp.DELETE("/:id", h.DeleteICPHandler)
+		icp.POST("/:id/unarchive", h.UnarchiveICPHandler)
+		icp.POST("/:id/clone", h.CloneICPHandler)
+		icp.GET("/:id/revisions", h.ListICPRevisionsHandler)
+		icp.GET("/:id/revisions/:version", h.GetICPRevisionHandler)
+		icp.GET("/:id/diff", h.DiffICPHandler)
+		icp.GET("/:id/analytics", h.ICPAnalyticsHandler)
+		icp.POST("/:id/run", h.RunICPHandler)
+		icp.GET("/:id/runs", h.ListICPRunsHandler)
 	}
 }
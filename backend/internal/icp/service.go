@@ -1,34 +1,336 @@
 package icp
 
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/company/querylang"
+	"github.com/bhati00/Fynelo/backend/internal/company/service"
+	"github.com/bhati00/Fynelo/backend/pkg/pagination"
+
+	"gorm.io/gorm"
+)
+
+// Most matches attributed to a profile per run; broader profiles are truncated
+const maxTrackedMatches = 5000
+
+var (
+	ErrArchived         = errors.New("ICP is archived")
+	ErrRevisionNotFound = errors.New("ICP revision not found")
+	ErrTemplateNotFound = errors.New("ICP template not found")
+	ErrNoCriteria       = errors.New("ICP has no industry, size, geography, funding, revenue or technology criteria to search by")
+)
+
+// PageRequest pages the companies, runs or revisions of a profile.
+type PageRequest struct {
+	Limit  int `form:"limit"`
+	Offset int `form:"offset"`
+}
+
+// RunResponse is the outcome of running a profile: the recorded run, a page
+// of the local companies it matches and the enrichment job queued for it.
+type RunResponse struct {
+	Run       ICPRun             `json:"run"`
+	Companies []model.Company    `json:"companies"`
+	Total     int64              `json:"total"`
+	Limit     int                `json:"limit"`
+	Offset    int                `json:"offset"`
+	QueuedJob *service.QueuedJob `json:"queued_job,omitempty"`
+}
+
+type RevisionsResponse struct {
+	Revisions []ICPRevision `json:"revisions"`
+	Total     int64         `json:"total"`
+	Limit     int           `json:"limit"`
+	Offset    int           `json:"offset"`
+}
+
+// CloneRequest names the clone and the version to copy, by default the
+// current one. The name defaults to the source's with " (copy)" appended.
+type CloneRequest struct {
+	Name    string `json:"name"`
+	Version int    `json:"version"`
+}
+
+// TemplateRequest names the profile created from a template, by default
+// after the template, and the user it belongs to.
+type TemplateRequest struct {
+	Name   string `json:"name"`
+	UserId uint   `json:"user_id"`
+}
+
+type RunsResponse struct {
+	Runs   []ICPRun `json:"runs"`
+	Total  int64    `json:"total"`
+	Limit  int      `json:"limit"`
+	Offset int      `json:"offset"`
+}
+
 type Service struct {
-	repo *Repository
+	repo      *Repository
+	companies service.CompanyService
 }
 
-func NewService(repo *Repository) *Service {
-	return &Service{repo: repo}
+func NewService(repo *Repository, companies service.CompanyService) *Service {
+	return &Service{repo: repo, companies: companies}
 }
 
-// CreateICP creates a new ICP profile
+// CreateICP validates and creates a new ICP profile as version 1
 func (s *Service) CreateICP(profile *ICPProfile) error {
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+	profile.Version, profile.ArchivedAt = 1, nil
 	return s.repo.CreateICP(profile)
 }
 
-// GetICPByID retrieves a specific ICP profile by ID
+// GetICPByID retrieves a specific ICP profile by ID, archived or not
 func (s *Service) GetICPByID(id uint) (*ICPProfile, error) {
 	return s.repo.GetICPByID(id)
 }
 
-// ListICPsByUser retrieves all ICP profiles for a specific user
-func (s *Service) ListICPsByUser(userID uint) ([]ICPProfile, error) {
-	return s.repo.ListICPsByUser(userID)
+// ListICPsByUser retrieves the ICP profiles of a specific user
+func (s *Service) ListICPsByUser(userID uint, includeArchived bool) ([]ICPProfile, error) {
+	return s.repo.ListICPsByUser(userID, includeArchived)
 }
 
-// UpdateICP updates an existing ICP profile
+// UpdateICP validates and replaces an existing ICP profile, keeping its creation time.
+// A change to the name or criteria is recorded as a new revision.
 func (s *Service) UpdateICP(profile *ICPProfile) error {
-	return s.repo.UpdateICP(profile)
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+	existing, err := s.repo.GetICPByID(profile.ID)
+	if err != nil {
+		return err
+	}
+	if existing.ArchivedAt != nil {
+		return ErrArchived
+	}
+	profile.CreatedAt, profile.ArchivedAt = existing.CreatedAt, nil
+	profile.Version = existing.Version
+	revised := len(diffRevisions(existing.revision(), profile.revision())) > 0
+	if revised {
+		profile.Version++
+	}
+	return s.repo.UpdateICP(profile, revised)
 }
 
-// DeleteICP deletes an ICP profile by ID
+// DeleteICP archives an ICP profile by ID. Its revisions and runs are kept.
 func (s *Service) DeleteICP(id uint) error {
-	return s.repo.DeleteICP(id)
+	now := time.Now()
+	return s.repo.SetArchived(id, &now)
+}
+
+// UnarchiveICP restores an archived ICP profile
+func (s *Service) UnarchiveICP(id uint) (*ICPProfile, error) {
+	if err := s.repo.SetArchived(id, nil); err != nil {
+		return nil, err
+	}
+	return s.repo.GetICPByID(id)
+}
+
+// ListRevisions returns a profile's revisions, newest first.
+func (s *Service) ListRevisions(ctx context.Context, id uint, req PageRequest) (*RevisionsResponse, error) {
+	if _, err := s.repo.GetICPByID(id); err != nil {
+		return nil, err
+	}
+	limit, offset := pagination.Page(req.Limit, req.Offset)
+	revisions, total, err := s.repo.Revisions(ctx, id, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return &RevisionsResponse{Revisions: revisions, Total: total, Limit: limit, Offset: offset}, nil
+}
+
+// GetRevision returns one version of a profile.
+func (s *Service) GetRevision(ctx context.Context, id uint, version int) (*ICPRevision, error) {
+	if _, err := s.repo.GetICPByID(id); err != nil {
+		return nil, err
+	}
+	return s.revision(ctx, id, version)
+}
+
+// Diff compares two versions of a profile. A zero to means the current
+// version and a negative from the one before to.
+func (s *Service) Diff(ctx context.Context, id uint, from, to int) (*ICPDiff, error) {
+	profile, err := s.repo.GetICPByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if to == 0 {
+		to = profile.Version
+	}
+	if from < 0 {
+		from = to - 1
+	}
+
+	revisions := make([]*ICPRevision, 2)
+	for i, version := range []int{from, to} {
+		if version == 0 {
+			revisions[i] = &ICPRevision{ICPID: id}
+			continue
+		}
+		if revisions[i], err = s.revision(ctx, id, version); err != nil {
+			return nil, err
+		}
+	}
+	return &ICPDiff{ICPID: id, From: from, To: to, Changes: diffRevisions(revisions[0], revisions[1])}, nil
+}
+
+// CloneICP creates a new profile from a version of an existing one, by
+// default its current version. Archived profiles can be cloned.
+func (s *Service) CloneICP(ctx context.Context, id uint, req CloneRequest) (*ICPProfile, error) {
+	source, err := s.repo.GetICPByID(id)
+	if err != nil {
+		return nil, err
+	}
+	clone := &ICPProfile{UserId: source.UserId, Name: copyName(source.Name), ICPCriteria: source.ICPCriteria}
+	if req.Version != 0 {
+		revision, err := s.revision(ctx, id, req.Version)
+		if err != nil {
+			return nil, err
+		}
+		clone.Name, clone.ICPCriteria = copyName(revision.Name), revision.Criteria
+	}
+	if req.Name != "" {
+		clone.Name = req.Name
+	}
+	if err := s.CreateICP(clone); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// CreateFromTemplate creates a new profile from a built-in template.
+func (s *Service) CreateFromTemplate(key string, req TemplateRequest) (*ICPProfile, error) {
+	template, ok := Template(key)
+	if !ok {
+		return nil, ErrTemplateNotFound
+	}
+	profile := &ICPProfile{UserId: req.UserId, Name: template.Name, ICPCriteria: template.Criteria}
+	if req.Name != "" {
+		profile.Name = req.Name
+	}
+	if err := s.CreateICP(profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// copyName names a clone after its source, keeping within the name limit.
+func copyName(name string) string {
+	const suffix = " (copy)"
+	for len(name)+len(suffix) > maxNameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return strings.TrimSpace(name + suffix)
+}
+
+func (s *Service) revision(ctx context.Context, id uint, version int) (*ICPRevision, error) {
+	revision, err := s.repo.Revision(ctx, id, version)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRevisionNotFound
+	}
+	return revision, err
+}
+
+// SearchRequest translates the profile into an advanced-mode company search.
+// It fails as CompanyExpression does.
+func (p *ICPProfile) SearchRequest() (service.CompanySearchRequest, error) {
+	expr, err := p.CompanyExpression()
+	if err != nil {
+		return service.CompanySearchRequest{}, err
+	}
+	return service.CompanySearchRequest{
+		Query:     querylang.Format(expr),
+		QueryMode: service.QueryModeAdvanced,
+		ICPID:     p.ID,
+	}, nil
+}
+
+// RunICP searches the local companies matching a profile, attributes the
+// ones earlier runs had not matched to it and queues an enrichment job
+// carrying the profile ID. The job is omitted when the queue is unavailable.
+// Profiles without company criteria would match every company and are
+// rejected with ErrNoCriteria, and ones whose criteria no longer resolve
+// with a StaleCriteriaError.
+func (s *Service) RunICP(ctx context.Context, id uint, req PageRequest) (*RunResponse, error) {
+	profile, err := s.repo.GetICPByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if profile.ArchivedAt != nil {
+		return nil, ErrArchived
+	}
+	search, err := profile.SearchRequest()
+	if err != nil {
+		return nil, err
+	}
+	ids, err := s.companies.SearchCompanyIDs(ctx, search, maxTrackedMatches)
+	if err != nil {
+		return nil, err
+	}
+	search.Limit, search.Offset = req.Limit, req.Offset
+	result, err := s.companies.SearchCompanies(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	matchedIDs, err := s.repo.MatchedCompanyIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	matched := make(map[uint]bool, len(matchedIDs))
+	for _, companyID := range matchedIDs {
+		matched[companyID] = true
+	}
+	var unseen []uint
+	for _, companyID := range ids {
+		if !matched[companyID] {
+			unseen = append(unseen, companyID)
+		}
+	}
+
+	run := &ICPRun{
+		ICPID:      id,
+		Query:      search.Query,
+		Matched:    result.Total,
+		NewMatches: len(unseen),
+		CreatedAt:  time.Now(),
+	}
+	response := &RunResponse{
+		Companies: result.Companies,
+		Total:     result.Total,
+		Limit:     result.Limit,
+		Offset:    result.Offset,
+	}
+	if len(result.QueuedJobs) > 0 {
+		job := result.QueuedJobs[0]
+		run.JobID, run.JobStatus = job.ID, job.Status
+		response.QueuedJob = &job
+	}
+	if err := s.repo.SaveRun(ctx, run, unseen); err != nil {
+		return nil, err
+	}
+	response.Run = *run
+	return response, nil
+}
+
+// ListRuns returns a profile's runs, newest first.
+func (s *Service) ListRuns(ctx context.Context, id uint, req PageRequest) (*RunsResponse, error) {
+	if _, err := s.repo.GetICPByID(id); err != nil {
+		return nil, err
+	}
+	limit, offset := pagination.Page(req.Limit, req.Offset)
+	runs, total, err := s.repo.Runs(ctx, id, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return &RunsResponse{Runs: runs, Total: total, Limit: limit, Offset: offset}, nil
 }
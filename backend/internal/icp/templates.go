@@ -0,0 +1,144 @@
+package icp
+
+import (
+	"strings"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/constants"
+	"github.com/bhati00/Fynelo/backend/internal/taxonomy"
+)
+
+// ICPTemplate is a built-in starting point for a profile. Summary spells
+// the criteria out with the taxonomy names.
+type ICPTemplate struct {
+	Key      string      `json:"key"`
+	Name     string      `json:"name"`
+	Summary  string      `json:"summary"`
+	Criteria ICPCriteria `json:"criteria"`
+}
+
+var templates = []ICPTemplate{
+	{
+		Key:  "seed-b2b-saas",
+		Name: "Seed-stage B2B SaaS, 11-50, CTO buyers",
+		Criteria: ICPCriteria{
+			BusinessTypes: []int{constants.BusinessTypeB2BSaaS},
+			Industries:    []int{constants.IndustryTechnology, constants.IndustryCloudComputing},
+			CompanySizes:  []int{constants.CompanySize11To50},
+			BuyerRoles:    []int{constants.BuyerRoleCTOVPEngineering},
+			FundingStages: []string{string(model.RoundSeed)},
+		},
+	},
+	{
+		Key:  "series-a-fintech",
+		Name: "Series A fintech, 51-200, finance and engineering buyers",
+		Criteria: ICPCriteria{
+			BusinessTypes: []int{constants.BusinessTypeFinancial},
+			Industries:    []int{constants.IndustryFintech, constants.IndustryBanking, constants.IndustryInsurance},
+			CompanySizes:  []int{constants.CompanySize51To200},
+			BuyerRoles:    []int{constants.BuyerRoleFinanceDirector, constants.BuyerRoleCTOVPEngineering},
+			FundingStages: []string{string(model.RoundSeriesA)},
+		},
+	},
+	{
+		Key:  "venture-backed-ai",
+		Name: "Venture-backed AI startups, 11-200, product and engineering buyers",
+		Criteria: ICPCriteria{
+			BusinessTypes: []int{constants.BusinessTypeB2BSaaS},
+			Industries:    []int{constants.IndustryAI},
+			CompanySizes:  []int{constants.CompanySize11To50, constants.CompanySize51To200},
+			BuyerRoles:    []int{constants.BuyerRoleCTOVPEngineering, constants.BuyerRoleProductManager},
+			FundingStages: []string{string(model.RoundSeed), string(model.RoundSeriesA), string(model.RoundSeriesB)},
+		},
+	},
+	{
+		Key:  "ecommerce-growth",
+		Name: "Growing e-commerce brands, 11-200, marketing buyers",
+		Criteria: ICPCriteria{
+			BusinessTypes: []int{constants.BusinessTypeECommerce},
+			Industries:    []int{constants.IndustryECommerce, constants.IndustryRetail, constants.IndustryFashion},
+			CompanySizes:  []int{constants.CompanySize11To50, constants.CompanySize51To200},
+			BuyerRoles:    []int{constants.BuyerRoleMarketingDirector},
+		},
+	},
+	{
+		Key:  "midmarket-cybersecurity",
+		Name: "Mid-market cybersecurity, 201-1000, engineering and IT buyers",
+		Criteria: ICPCriteria{
+			Industries:   []int{constants.IndustryCybersecurity},
+			CompanySizes: []int{constants.CompanySize201To500, constants.CompanySize501To1000},
+			BuyerRoles:   []int{constants.BuyerRoleCTOVPEngineering, constants.BuyerRoleITManager},
+		},
+	},
+	{
+		Key:  "enterprise-healthcare",
+		Name: "Enterprise healthcare, 1000+, $100M+ revenue, IT buyers",
+		Criteria: ICPCriteria{
+			BusinessTypes: []int{constants.BusinessTypeHealthcare},
+			Industries:    []int{constants.IndustryHealthcare, constants.IndustryBiotechnology},
+			CompanySizes:  []int{constants.CompanySize1000Plus},
+			BuyerRoles:    []int{constants.BuyerRoleITManager, constants.BuyerRoleCTOVPEngineering},
+			RevenueBands:  []int{constants.RevenueBand100To500M, constants.RevenueBand500MPlus},
+		},
+	},
+	{
+		Key:  "small-agencies",
+		Name: "Small agencies and consultancies, 1-50, founder buyers",
+		Criteria: ICPCriteria{
+			BusinessTypes: []int{constants.BusinessTypeConsulting},
+			Industries:    []int{constants.IndustryConsulting, constants.IndustryMarketing},
+			CompanySizes:  []int{constants.CompanySize1To10, constants.CompanySize11To50},
+			BuyerRoles:    []int{constants.BuyerRoleCEOFounder},
+		},
+	},
+}
+
+// Templates returns the built-in templates with their criteria normalized
+// and summarized.
+func Templates() []ICPTemplate {
+	out := make([]ICPTemplate, len(templates))
+	for i, t := range templates {
+		normalize(&t.Criteria)
+		t.Summary = summarize(t.Criteria)
+		out[i] = t
+	}
+	return out
+}
+
+// Template returns the built-in template with the given key.
+func Template(key string) (ICPTemplate, bool) {
+	for _, t := range Templates() {
+		if t.Key == key {
+			return t, true
+		}
+	}
+	return ICPTemplate{}, false
+}
+
+// summarize describes criteria with the taxonomy names, e.g.
+// "B2B SaaS; Technology, Cloud Computing; 11-50 employees; seed; CTO/VP Engineering".
+func summarize(c ICPCriteria) string {
+	var parts []string
+	add := func(values []string, suffix string) {
+		if len(values) > 0 {
+			parts = append(parts, strings.Join(values, ", ")+suffix)
+		}
+	}
+	terms := taxonomy.Default()
+	add(names(c.BusinessTypes, terms.Names(taxonomy.BusinessTypes)), "")
+	add(names(c.Industries, terms.Names(taxonomy.Industries)), "")
+	add(names(c.CompanySizes, terms.Names(taxonomy.CompanySizes)), " employees")
+	add(names(c.RevenueBands, constants.RevenueBandNames), " revenue")
+	add(c.FundingStages, "")
+	add(c.Geographies, "")
+	add(names(c.BuyerRoles, terms.Names(taxonomy.BuyerRoles)), "")
+	return strings.Join(parts, "; ")
+}
+
+func names(ids []int, labels map[int]string) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, labels[id])
+	}
+	return out
+}
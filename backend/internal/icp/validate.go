@@ -0,0 +1,242 @@
+package icp
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/constants"
+	"github.com/bhati00/Fynelo/backend/internal/geo"
+	"github.com/bhati00/Fynelo/backend/internal/taxonomy"
+)
+
+const (
+	maxCriteria         = 50 // values per criterion
+	maxTechnologyLength = 100
+	maxNameLength       = 200
+)
+
+var fundingStages = []model.FundingRoundType{
+	model.RoundSeed, model.RoundSeriesA, model.RoundSeriesB, model.RoundSeriesC,
+	model.RoundSeriesD, model.RoundIPO, model.RoundAcquisition,
+}
+
+// ValidationError lists what is wrong with each invalid criterion, keyed by
+// its JSON field name.
+type ValidationError struct {
+	Fields map[string]string `json:"fields"`
+}
+
+func (e *ValidationError) Error() string {
+	names := make([]string, 0, len(e.Fields))
+	for name := range e.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + ": " + e.Fields[name]
+	}
+	return "invalid ICP: " + strings.Join(parts, "; ")
+}
+
+// Validate trims the profile's name and checks it along with the criteria.
+func (p *ICPProfile) Validate() error {
+	fields := map[string]string{}
+	p.Name = strings.TrimSpace(p.Name)
+	if len(p.Name) > maxNameLength {
+		fields["name"] = fmt.Sprintf("at most %d characters are allowed", maxNameLength)
+	}
+	p.ICPCriteria.check(fields)
+	return validationError(fields)
+}
+
+// Validate normalizes the criteria and checks them against the
+// taxonomies. Geographies given by name are replaced by their ISO codes.
+func (c *ICPCriteria) Validate() error {
+	fields := map[string]string{}
+	c.check(fields)
+	return validationError(fields)
+}
+
+func (c *ICPCriteria) check(fields map[string]string) {
+	normalize(c)
+
+	terms := taxonomy.Default()
+	checkIDs(fields, "business_types", "business type", c.BusinessTypes, terms.Names(taxonomy.BusinessTypes))
+	checkIDs(fields, "industries", "industry", c.Industries, terms.Names(taxonomy.Industries))
+	checkIDs(fields, "company_sizes", "company size", c.CompanySizes, terms.Names(taxonomy.CompanySizes))
+	checkIDs(fields, "buyer_roles", "buyer role", c.BuyerRoles, terms.Names(taxonomy.BuyerRoles))
+	checkIDs(fields, "revenue_bands", "revenue band", c.RevenueBands, constants.RevenueBandNames)
+
+	for i, place := range c.Geographies {
+		code, ok := geographyCode(place)
+		if !ok {
+			fields["geographies"] = fmt.Sprintf("unknown country or region %q", place)
+			break
+		}
+		c.Geographies[i] = code
+	}
+	c.Geographies = dedupeStrings(c.Geographies)
+
+	for _, stage := range c.FundingStages {
+		if !isFundingStage(stage) {
+			names := make([]string, len(fundingStages))
+			for i, s := range fundingStages {
+				names[i] = string(s)
+			}
+			fields["funding_stages"] = fmt.Sprintf("unknown funding stage %q; valid stages are %s", stage, strings.Join(names, ", "))
+			break
+		}
+	}
+
+	checkTechnologies(fields, "required_technologies", c.RequiredTechnologies)
+	checkTechnologies(fields, "excluded_technologies", c.ExcludedTechnologies)
+	for _, tech := range c.RequiredTechnologies {
+		if containsString(c.ExcludedTechnologies, tech) {
+			fields["excluded_technologies"] = fmt.Sprintf("%q is also required", tech)
+			break
+		}
+	}
+
+	lists := map[string]int{
+		"business_types": len(c.BusinessTypes), "industries": len(c.Industries), "company_sizes": len(c.CompanySizes),
+		"buyer_roles": len(c.BuyerRoles), "geographies": len(c.Geographies), "required_technologies": len(c.RequiredTechnologies),
+		"excluded_technologies": len(c.ExcludedTechnologies), "funding_stages": len(c.FundingStages), "revenue_bands": len(c.RevenueBands),
+	}
+	for name, n := range lists {
+		if n > maxCriteria {
+			fields[name] = fmt.Sprintf("at most %d values are allowed", maxCriteria)
+		}
+	}
+
+}
+
+func validationError(fields map[string]string) error {
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+// normalize turns missing criteria into empty lists and sorts and dedupes
+// them, lowercasing technologies and funding stages.
+func normalize(c *ICPCriteria) {
+	c.BusinessTypes = dedupeInts(c.BusinessTypes)
+	c.Industries = dedupeInts(c.Industries)
+	c.CompanySizes = dedupeInts(c.CompanySizes)
+	c.BuyerRoles = dedupeInts(c.BuyerRoles)
+	c.RevenueBands = dedupeInts(c.RevenueBands)
+	c.Geographies = dedupeStrings(c.Geographies)
+	c.RequiredTechnologies = dedupeStrings(lowerAll(c.RequiredTechnologies))
+	c.ExcludedTechnologies = dedupeStrings(lowerAll(c.ExcludedTechnologies))
+	c.FundingStages = dedupeStrings(lowerAll(c.FundingStages))
+}
+
+func checkIDs(fields map[string]string, field, noun string, ids []int, valid map[int]string) {
+	for _, id := range ids {
+		if _, ok := valid[id]; !ok {
+			fields[field] = fmt.Sprintf("unknown %s ID %d; valid IDs are %s", noun, id, idRange(valid))
+			return
+		}
+	}
+}
+
+func checkTechnologies(fields map[string]string, field string, techs []string) {
+	for _, tech := range techs {
+		if len(tech) > maxTechnologyLength {
+			fields[field] = fmt.Sprintf("technology names are at most %d characters", maxTechnologyLength)
+			return
+		}
+	}
+}
+
+// geographyCode resolves a country or region name or code to its ISO code.
+func geographyCode(place string) (string, bool) {
+	g := geo.Default()
+	if country, ok := g.Country(place); ok {
+		return country.Code, true
+	}
+	if region, ok := g.RegionByCode(place); ok {
+		return region.Code, true
+	}
+	if region, ok := g.Region(place, ""); ok {
+		return region.Code, true
+	}
+	return "", false
+}
+
+func isFundingStage(stage string) bool {
+	for _, s := range fundingStages {
+		if string(s) == stage {
+			return true
+		}
+	}
+	return false
+}
+
+// idRange describes the valid IDs of a taxonomy, e.g. "1-29".
+func idRange(valid map[int]string) string {
+	ids := make([]int, 0, len(valid))
+	for id := range valid {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	if len(ids) == 0 {
+		return "none"
+	}
+	if ids[len(ids)-1]-ids[0] == len(ids)-1 {
+		return fmt.Sprintf("%d-%d", ids[0], ids[len(ids)-1])
+	}
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func dedupeInts(values []int) []int {
+	out := []int{}
+	seen := map[int]bool{}
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	sort.Ints(out)
+	return out
+}
+
+// dedupeStrings trims values and drops empty and repeated ones, keeping order.
+func dedupeStrings(values []string) []string {
+	out := []string{}
+	seen := map[string]bool{}
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v != "" && !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func lowerAll(values []string) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = strings.ToLower(v)
+	}
+	return out
+}
+
+func containsString(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,242 @@
+// Package industry classifies companies into the industries taxonomy from
+// NAICS and SIC codes and free-text descriptions, using rules bundled with
+// the binary so classification works offline. Unmapped values are curated
+// through the taxonomy: a term's name or alias matches free text, and aliases
+// such as "naics:5415" or "sic:7372" map codes.
+package industry
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/bhati00/Fynelo/backend/internal/constants"
+	"github.com/bhati00/Fynelo/backend/internal/taxonomy"
+)
+
+// Source is the kind of input a classification came from.
+type Source string
+
+const (
+	SourceNAICS Source = "naics"
+	SourceSIC   Source = "sic"
+	SourceText  Source = "text"
+)
+
+// Method is how an input was matched.
+type Method string
+
+const (
+	MethodCode     Method = "code"     // built-in NAICS or SIC prefix
+	MethodTaxonomy Method = "taxonomy" // taxonomy name or alias
+	MethodKeyword  Method = "keyword"  // words in a description
+)
+
+// Confidence of a match on the taxonomy name or alias of a term
+const taxonomyConfidence = 0.95
+
+// Confidence of a code match by the length of the matched prefix
+var (
+	naicsConfidence = map[int]float64{2: 0.6, 3: 0.7, 4: 0.8, 5: 0.85, 6: 0.95}
+	sicConfidence   = map[int]float64{2: 0.6, 3: 0.7, 4: 0.85}
+)
+
+// Input is what an import or enrichment source says about a company's
+// industry. Any of the fields may be empty.
+type Input struct {
+	NAICS string `json:"naics_code"`
+	SIC   string `json:"sic_code"`
+	Text  string `json:"text"`
+}
+
+// Classification maps one input to an industry.
+type Classification struct {
+	IndustryID int     `json:"industry_id"`
+	Industry   string  `json:"industry"`
+	Confidence float64 `json:"confidence"` // 0..1
+	Source     Source  `json:"source"`
+	Method     Method  `json:"method"`
+	Match      string  `json:"match"` // code prefix, name or keywords that matched
+}
+
+// Value is an input the classifier could not map.
+type Value struct {
+	Source Source `json:"source"`
+	Value  string `json:"value"`
+}
+
+// Result holds the best classification of an Input, nil when no input
+// matched. When several inputs agree on the best industry its confidence
+// combines theirs.
+type Result struct {
+	Classification *Classification  `json:"classification"`
+	Candidates     []Classification `json:"candidates"` // one per matched input, most confident first
+	Unmapped       []Value          `json:"unmapped"`
+}
+
+// Classify maps the codes and description of in to industries.
+func Classify(in Input) Result {
+	result := Result{Candidates: []Classification{}, Unmapped: []Value{}}
+	add := func(source Source, value string, c *Classification) {
+		if c != nil {
+			result.Candidates = append(result.Candidates, *c)
+		} else {
+			result.Unmapped = append(result.Unmapped, Value{Source: source, Value: value})
+		}
+	}
+	if code := strings.TrimSpace(in.NAICS); code != "" {
+		add(SourceNAICS, code, classifyCode(SourceNAICS, code, naicsPrefixes, naicsConfidence))
+	}
+	if code := strings.TrimSpace(in.SIC); code != "" {
+		add(SourceSIC, code, classifyCode(SourceSIC, code, sicPrefixes, sicConfidence))
+	}
+	if text := strings.TrimSpace(in.Text); text != "" {
+		add(SourceText, text, classifyText(text))
+	}
+	if len(result.Candidates) == 0 {
+		return result
+	}
+
+	sort.SliceStable(result.Candidates, func(i, j int) bool {
+		return result.Candidates[i].Confidence > result.Candidates[j].Confidence
+	})
+	best := result.Candidates[0]
+	doubt := 1.0
+	for _, c := range result.Candidates {
+		if c.IndustryID == best.IndustryID {
+			doubt *= 1 - c.Confidence
+		}
+	}
+	best.Confidence = round(math.Min(1-doubt, 0.99))
+	result.Classification = &best
+	return result
+}
+
+// classifyCode matches the longest prefix of code, trying curated taxonomy
+// aliases before the built-in table.
+func classifyCode(source Source, code string, prefixes map[string]int, confidence map[int]float64) *Classification {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, code)
+
+	terms := taxonomy.Default()
+	for n := len(digits); n >= 2; n-- {
+		conf, ok := confidence[n]
+		if !ok {
+			continue
+		}
+		prefix := digits[:n]
+		if id, ok := terms.Lookup(taxonomy.Industries, string(source)+":"+prefix); ok {
+			return classification(id, conf, source, MethodTaxonomy, prefix)
+		}
+		if id, ok := prefixes[prefix]; ok {
+			return classification(id, conf, source, MethodCode, prefix)
+		}
+	}
+	return nil
+}
+
+// classifyText matches a description against the taxonomy names and
+// aliases, then scores the keywords and taxonomy names it contains. The
+// confidence grows with the weight of the winning industry's keywords and
+// shrinks with that of the other industries'.
+func classifyText(text string) *Classification {
+	terms := taxonomy.Default()
+	if id, ok := terms.Lookup(taxonomy.Industries, text); ok {
+		return classification(id, taxonomyConfidence, SourceText, MethodTaxonomy, text)
+	}
+
+	padded := " " + words(text) + " "
+	matched := map[int]map[string]float64{}
+	match := func(phrase string, id int, weight float64) {
+		if phrase == "" || !strings.Contains(padded, " "+phrase+" ") {
+			return
+		}
+		if matched[id] == nil {
+			matched[id] = map[string]float64{}
+		}
+		matched[id][phrase] = math.Max(matched[id][phrase], weight)
+	}
+	for _, k := range keywords {
+		match(k.phrase, k.industry, k.weight)
+	}
+	// names and aliases of terms, including ones added by admins, count as
+	// specific keywords unless the built-in keywords already weigh them
+	for _, t := range terms.Terms(taxonomy.Industries) {
+		if t.ID == constants.IndustryOther {
+			continue
+		}
+		for _, name := range append([]string{t.Name}, t.Aliases...) {
+			if strings.Contains(name, ":") {
+				continue // a code alias
+			}
+			phrase := words(name)
+			if _, weighed := matched[t.ID][phrase]; !weighed {
+				match(phrase, t.ID, specific)
+			}
+		}
+	}
+
+	bestID, best, total := 0, 0.0, 0.0
+	for id, phrases := range matched {
+		score := 0.0
+		for _, w := range phrases {
+			score += w
+		}
+		total += score
+		if score > best || score == best && prefer(terms, id, bestID) {
+			bestID, best = id, score
+		}
+	}
+	if bestID == 0 {
+		return nil
+	}
+
+	phrases := make([]string, 0, len(matched[bestID]))
+	for p := range matched[bestID] {
+		phrases = append(phrases, p)
+	}
+	sort.Strings(phrases)
+	conf := 0.4 + 0.5*(best/(best+1))*(best/total)
+	return classification(bestID, conf, SourceText, MethodKeyword, strings.Join(phrases, ", "))
+}
+
+// prefer breaks a keyword tie in favour of the narrower term, then the lower ID.
+func prefer(terms *taxonomy.Registry, id, other int) bool {
+	if other == 0 {
+		return true
+	}
+	a, _ := terms.Term(taxonomy.Industries, id)
+	b, _ := terms.Term(taxonomy.Industries, other)
+	if (a.ParentID != nil) != (b.ParentID != nil) {
+		return a.ParentID != nil
+	}
+	return id < other
+}
+
+func classification(id int, confidence float64, source Source, method Method, match string) *Classification {
+	return &Classification{
+		IndustryID: id,
+		Industry:   taxonomy.Default().Name(taxonomy.Industries, id),
+		Confidence: round(confidence),
+		Source:     source,
+		Method:     method,
+		Match:      match,
+	}
+}
+
+// words lowercases s and reduces it to letters and digits separated by
+// single spaces, so "E-Commerce" becomes "e commerce".
+func words(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+func round(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,390 @@
+package industry
+
+import "github.com/bhati00/Fynelo/backend/internal/constants"
+
+// naicsPrefixes maps NAICS code prefixes (2017 and 2022 editions) to
+// industries. The longest matching prefix wins, so specific codes override
+// their sector; sectors without a fitting industry, such as construction,
+// are left out and reported as unmapped.
+var naicsPrefixes = map[string]int{
+	"11":     constants.IndustryAgriculture,
+	"211":    constants.IndustryEnergy,
+	"2111":   constants.IndustryEnergy,
+	"22":     constants.IndustryEnergy,
+	"31":     constants.IndustryManufacturing,
+	"32":     constants.IndustryManufacturing,
+	"33":     constants.IndustryManufacturing,
+	"311":    constants.IndustryFoodBeverage,
+	"312":    constants.IndustryFoodBeverage,
+	"315":    constants.IndustryFashion,
+	"316":    constants.IndustryFashion,
+	"324":    constants.IndustryEnergy,
+	"3254":   constants.IndustryBiotechnology,
+	"3341":   constants.IndustryTechnology,
+	"3344":   constants.IndustryTechnology,
+	"3361":   constants.IndustryAutomotive,
+	"3362":   constants.IndustryAutomotive,
+	"3363":   constants.IndustryAutomotive,
+	"3364":   constants.IndustryAerospace,
+	"44":     constants.IndustryRetail,
+	"45":     constants.IndustryRetail,
+	"448":    constants.IndustryFashion,
+	"4541":   constants.IndustryECommerce,
+	"48":     constants.IndustryLogistics,
+	"49":     constants.IndustryLogistics,
+	"481":    constants.IndustryTravel,
+	"51":     constants.IndustryMedia,
+	"5112":   constants.IndustryTechnology,
+	"5132":   constants.IndustryTechnology,
+	"517":    constants.IndustryTelecom,
+	"518":    constants.IndustryCloudComputing,
+	"5192":   constants.IndustryTechnology,
+	"52":     constants.IndustryBanking,
+	"5223":   constants.IndustryFintech,
+	"524":    constants.IndustryInsurance,
+	"53":     constants.IndustryRealEstate,
+	"531":    constants.IndustryRealEstate,
+	"5415":   constants.IndustryTechnology,
+	"5416":   constants.IndustryConsulting,
+	"541714": constants.IndustryBiotechnology,
+	"5418":   constants.IndustryMarketing,
+	"5615":   constants.IndustryTravel,
+	"61":     constants.IndustryEducation,
+	"62":     constants.IndustryHealthcare,
+	"71":     constants.IndustryMedia,
+	"721":    constants.IndustryTravel,
+	"722":    constants.IndustryFoodBeverage,
+}
+
+// sicPrefixes maps SIC code prefixes to industries, like naicsPrefixes.
+var sicPrefixes = map[string]int{
+	"01":   constants.IndustryAgriculture,
+	"02":   constants.IndustryAgriculture,
+	"07":   constants.IndustryAgriculture,
+	"13":   constants.IndustryEnergy,
+	"20":   constants.IndustryFoodBeverage,
+	"21":   constants.IndustryManufacturing,
+	"22":   constants.IndustryFashion,
+	"23":   constants.IndustryFashion,
+	"24":   constants.IndustryManufacturing,
+	"25":   constants.IndustryManufacturing,
+	"26":   constants.IndustryManufacturing,
+	"27":   constants.IndustryMedia,
+	"28":   constants.IndustryManufacturing,
+	"283":  constants.IndustryBiotechnology,
+	"29":   constants.IndustryEnergy,
+	"30":   constants.IndustryManufacturing,
+	"31":   constants.IndustryFashion,
+	"32":   constants.IndustryManufacturing,
+	"33":   constants.IndustryManufacturing,
+	"34":   constants.IndustryManufacturing,
+	"35":   constants.IndustryManufacturing,
+	"357":  constants.IndustryTechnology,
+	"36":   constants.IndustryManufacturing,
+	"366":  constants.IndustryTelecom,
+	"367":  constants.IndustryTechnology,
+	"37":   constants.IndustryManufacturing,
+	"371":  constants.IndustryAutomotive,
+	"372":  constants.IndustryAerospace,
+	"376":  constants.IndustryAerospace,
+	"38":   constants.IndustryManufacturing,
+	"384":  constants.IndustryHealthcare,
+	"39":   constants.IndustryManufacturing,
+	"40":   constants.IndustryLogistics,
+	"42":   constants.IndustryLogistics,
+	"44":   constants.IndustryLogistics,
+	"45":   constants.IndustryTravel,
+	"47":   constants.IndustryLogistics,
+	"4724": constants.IndustryTravel,
+	"48":   constants.IndustryTelecom,
+	"483":  constants.IndustryMedia,
+	"484":  constants.IndustryMedia,
+	"49":   constants.IndustryEnergy,
+	"52":   constants.IndustryRetail,
+	"53":   constants.IndustryRetail,
+	"54":   constants.IndustryRetail,
+	"56":   constants.IndustryFashion,
+	"57":   constants.IndustryRetail,
+	"58":   constants.IndustryFoodBeverage,
+	"59":   constants.IndustryRetail,
+	"5961": constants.IndustryECommerce,
+	"60":   constants.IndustryBanking,
+	"61":   constants.IndustryBanking,
+	"62":   constants.IndustryBanking,
+	"63":   constants.IndustryInsurance,
+	"64":   constants.IndustryInsurance,
+	"65":   constants.IndustryRealEstate,
+	"67":   constants.IndustryBanking,
+	"70":   constants.IndustryTravel,
+	"731":  constants.IndustryMarketing,
+	"737":  constants.IndustryTechnology,
+	"7374": constants.IndustryCloudComputing,
+	"78":   constants.IndustryMedia,
+	"80":   constants.IndustryHealthcare,
+	"82":   constants.IndustryEducation,
+	"8731": constants.IndustryBiotechnology,
+	"8742": constants.IndustryConsulting,
+	"8743": constants.IndustryMarketing,
+}
+
+// keyword is a word or phrase suggesting an industry. Generic terms weigh
+// less so that "AI platform" lands on Artificial Intelligence rather than
+// Technology.
+type keyword struct {
+	phrase   string
+	industry int
+	weight   float64
+}
+
+const (
+	generic  = 0.5
+	specific = 1.0
+)
+
+var keywords = []keyword{
+	{"technology", constants.IndustryTechnology, generic},
+	{"tech", constants.IndustryTechnology, generic},
+	{"software", constants.IndustryTechnology, specific},
+	{"saas", constants.IndustryTechnology, specific},
+	{"information technology", constants.IndustryTechnology, specific},
+	{"it services", constants.IndustryTechnology, specific},
+	{"developer tools", constants.IndustryTechnology, specific},
+	{"internet", constants.IndustryTechnology, generic},
+	{"computer", constants.IndustryTechnology, generic},
+	{"semiconductor", constants.IndustryTechnology, specific},
+	{"platform", constants.IndustryTechnology, generic},
+
+	{"fintech", constants.IndustryFintech, specific},
+	{"financial technology", constants.IndustryFintech, specific},
+	{"payments", constants.IndustryFintech, specific},
+	{"payment processing", constants.IndustryFintech, specific},
+	{"neobank", constants.IndustryFintech, specific},
+	{"digital banking", constants.IndustryFintech, specific},
+	{"lending platform", constants.IndustryFintech, specific},
+
+	{"healthcare", constants.IndustryHealthcare, specific},
+	{"health care", constants.IndustryHealthcare, specific},
+	{"health", constants.IndustryHealthcare, generic},
+	{"hospital", constants.IndustryHealthcare, specific},
+	{"clinic", constants.IndustryHealthcare, specific},
+	{"medical", constants.IndustryHealthcare, specific},
+	{"medical devices", constants.IndustryHealthcare, specific},
+	{"telehealth", constants.IndustryHealthcare, specific},
+	{"healthtech", constants.IndustryHealthcare, specific},
+	{"dental", constants.IndustryHealthcare, specific},
+	{"pharmacy", constants.IndustryHealthcare, specific},
+
+	{"education", constants.IndustryEducation, specific},
+	{"edtech", constants.IndustryEducation, specific},
+	{"e learning", constants.IndustryEducation, specific},
+	{"elearning", constants.IndustryEducation, specific},
+	{"school", constants.IndustryEducation, specific},
+	{"university", constants.IndustryEducation, specific},
+	{"tutoring", constants.IndustryEducation, specific},
+	{"learning", constants.IndustryEducation, generic},
+
+	{"e commerce", constants.IndustryECommerce, specific},
+	{"ecommerce", constants.IndustryECommerce, specific},
+	{"online store", constants.IndustryECommerce, specific},
+	{"online retail", constants.IndustryECommerce, specific},
+	{"online shopping", constants.IndustryECommerce, specific},
+	{"marketplace", constants.IndustryECommerce, generic},
+	{"direct to consumer", constants.IndustryECommerce, specific},
+	{"d2c", constants.IndustryECommerce, specific},
+	{"dtc", constants.IndustryECommerce, specific},
+
+	{"manufacturing", constants.IndustryManufacturing, specific},
+	{"manufacturer", constants.IndustryManufacturing, specific},
+	{"factory", constants.IndustryManufacturing, specific},
+	{"industrial", constants.IndustryManufacturing, generic},
+	{"machinery", constants.IndustryManufacturing, specific},
+	{"fabrication", constants.IndustryManufacturing, specific},
+
+	{"real estate", constants.IndustryRealEstate, specific},
+	{"realestate", constants.IndustryRealEstate, specific},
+	{"proptech", constants.IndustryRealEstate, specific},
+	{"property management", constants.IndustryRealEstate, specific},
+	{"realty", constants.IndustryRealEstate, specific},
+	{"property", constants.IndustryRealEstate, generic},
+
+	{"consulting", constants.IndustryConsulting, specific},
+	{"consultancy", constants.IndustryConsulting, specific},
+	{"advisory", constants.IndustryConsulting, generic},
+	{"professional services", constants.IndustryConsulting, specific},
+	{"management consulting", constants.IndustryConsulting, specific},
+
+	{"marketing", constants.IndustryMarketing, specific},
+	{"advertising", constants.IndustryMarketing, specific},
+	{"adtech", constants.IndustryMarketing, specific},
+	{"martech", constants.IndustryMarketing, specific},
+	{"seo", constants.IndustryMarketing, specific},
+	{"public relations", constants.IndustryMarketing, specific},
+	{"branding", constants.IndustryMarketing, specific},
+	{"agency", constants.IndustryMarketing, generic},
+
+	{"retail", constants.IndustryRetail, specific},
+	{"retailer", constants.IndustryRetail, specific},
+	{"consumer goods", constants.IndustryRetail, specific},
+	{"grocery", constants.IndustryRetail, specific},
+	{"store", constants.IndustryRetail, generic},
+	{"shop", constants.IndustryRetail, generic},
+
+	{"logistics", constants.IndustryLogistics, specific},
+	{"shipping", constants.IndustryLogistics, specific},
+	{"freight", constants.IndustryLogistics, specific},
+	{"supply chain", constants.IndustryLogistics, specific},
+	{"warehousing", constants.IndustryLogistics, specific},
+	{"trucking", constants.IndustryLogistics, specific},
+	{"courier", constants.IndustryLogistics, specific},
+	{"transportation", constants.IndustryLogistics, generic},
+	{"delivery", constants.IndustryLogistics, generic},
+
+	{"energy", constants.IndustryEnergy, specific},
+	{"oil", constants.IndustryEnergy, specific},
+	{"gas", constants.IndustryEnergy, generic},
+	{"renewable", constants.IndustryEnergy, specific},
+	{"renewables", constants.IndustryEnergy, specific},
+	{"solar", constants.IndustryEnergy, specific},
+	{"wind power", constants.IndustryEnergy, specific},
+	{"utilities", constants.IndustryEnergy, specific},
+	{"cleantech", constants.IndustryEnergy, specific},
+	{"battery", constants.IndustryEnergy, generic},
+	{"electricity", constants.IndustryEnergy, specific},
+
+	{"media", constants.IndustryMedia, specific},
+	{"publishing", constants.IndustryMedia, specific},
+	{"news", constants.IndustryMedia, specific},
+	{"entertainment", constants.IndustryMedia, specific},
+	{"film", constants.IndustryMedia, specific},
+	{"television", constants.IndustryMedia, specific},
+	{"broadcasting", constants.IndustryMedia, specific},
+	{"music", constants.IndustryMedia, specific},
+	{"streaming", constants.IndustryMedia, generic},
+	{"content", constants.IndustryMedia, generic},
+
+	{"gaming", constants.IndustryGaming, specific},
+	{"video game", constants.IndustryGaming, specific},
+	{"video games", constants.IndustryGaming, specific},
+	{"game studio", constants.IndustryGaming, specific},
+	{"esports", constants.IndustryGaming, specific},
+	{"games", constants.IndustryGaming, generic},
+
+	{"automotive", constants.IndustryAutomotive, specific},
+	{"vehicle", constants.IndustryAutomotive, generic},
+	{"vehicles", constants.IndustryAutomotive, generic},
+	{"electric vehicles", constants.IndustryAutomotive, specific},
+	{"car", constants.IndustryAutomotive, generic},
+	{"cars", constants.IndustryAutomotive, generic},
+	{"mobility", constants.IndustryAutomotive, generic},
+
+	{"agriculture", constants.IndustryAgriculture, specific},
+	{"agritech", constants.IndustryAgriculture, specific},
+	{"agtech", constants.IndustryAgriculture, specific},
+	{"farming", constants.IndustryAgriculture, specific},
+	{"farm", constants.IndustryAgriculture, generic},
+	{"crop", constants.IndustryAgriculture, specific},
+	{"livestock", constants.IndustryAgriculture, specific},
+
+	{"aerospace", constants.IndustryAerospace, specific},
+	{"aviation", constants.IndustryAerospace, specific},
+	{"aircraft", constants.IndustryAerospace, specific},
+	{"satellite", constants.IndustryAerospace, specific},
+	{"space", constants.IndustryAerospace, generic},
+	{"drone", constants.IndustryAerospace, specific},
+	{"drones", constants.IndustryAerospace, specific},
+	{"defense", constants.IndustryAerospace, generic},
+
+	{"telecom", constants.IndustryTelecom, specific},
+	{"telecommunications", constants.IndustryTelecom, specific},
+	{"wireless", constants.IndustryTelecom, specific},
+	{"broadband", constants.IndustryTelecom, specific},
+	{"5g", constants.IndustryTelecom, specific},
+	{"isp", constants.IndustryTelecom, specific},
+	{"mobile network", constants.IndustryTelecom, specific},
+
+	{"biotech", constants.IndustryBiotechnology, specific},
+	{"biotechnology", constants.IndustryBiotechnology, specific},
+	{"life sciences", constants.IndustryBiotechnology, specific},
+	{"pharmaceutical", constants.IndustryBiotechnology, specific},
+	{"pharmaceuticals", constants.IndustryBiotechnology, specific},
+	{"pharma", constants.IndustryBiotechnology, specific},
+	{"genomics", constants.IndustryBiotechnology, specific},
+	{"drug discovery", constants.IndustryBiotechnology, specific},
+	{"therapeutics", constants.IndustryBiotechnology, specific},
+
+	{"cybersecurity", constants.IndustryCybersecurity, specific},
+	{"cyber security", constants.IndustryCybersecurity, specific},
+	{"information security", constants.IndustryCybersecurity, specific},
+	{"infosec", constants.IndustryCybersecurity, specific},
+	{"security", constants.IndustryCybersecurity, generic},
+	{"threat detection", constants.IndustryCybersecurity, specific},
+	{"identity management", constants.IndustryCybersecurity, specific},
+	{"encryption", constants.IndustryCybersecurity, specific},
+
+	{"artificial intelligence", constants.IndustryAI, specific},
+	{"ai", constants.IndustryAI, specific},
+	{"machine learning", constants.IndustryAI, specific},
+	{"deep learning", constants.IndustryAI, specific},
+	{"computer vision", constants.IndustryAI, specific},
+	{"nlp", constants.IndustryAI, specific},
+	{"natural language processing", constants.IndustryAI, specific},
+	{"llm", constants.IndustryAI, specific},
+	{"generative ai", constants.IndustryAI, specific},
+
+	{"blockchain", constants.IndustryBlockchain, specific},
+	{"crypto", constants.IndustryBlockchain, specific},
+	{"cryptocurrency", constants.IndustryBlockchain, specific},
+	{"web3", constants.IndustryBlockchain, specific},
+	{"defi", constants.IndustryBlockchain, specific},
+	{"nft", constants.IndustryBlockchain, specific},
+
+	{"cloud", constants.IndustryCloudComputing, specific},
+	{"cloud computing", constants.IndustryCloudComputing, specific},
+	{"hosting", constants.IndustryCloudComputing, specific},
+	{"data center", constants.IndustryCloudComputing, specific},
+	{"infrastructure", constants.IndustryCloudComputing, generic},
+	{"devops", constants.IndustryCloudComputing, specific},
+	{"iaas", constants.IndustryCloudComputing, specific},
+	{"paas", constants.IndustryCloudComputing, specific},
+
+	{"insurance", constants.IndustryInsurance, specific},
+	{"insurtech", constants.IndustryInsurance, specific},
+	{"reinsurance", constants.IndustryInsurance, specific},
+	{"underwriting", constants.IndustryInsurance, specific},
+
+	{"bank", constants.IndustryBanking, specific},
+	{"banking", constants.IndustryBanking, specific},
+	{"credit union", constants.IndustryBanking, specific},
+	{"asset management", constants.IndustryBanking, specific},
+	{"wealth management", constants.IndustryBanking, specific},
+	{"investment management", constants.IndustryBanking, specific},
+	{"financial services", constants.IndustryBanking, specific},
+	{"finance", constants.IndustryBanking, generic},
+	{"lending", constants.IndustryBanking, generic},
+
+	{"food", constants.IndustryFoodBeverage, specific},
+	{"beverage", constants.IndustryFoodBeverage, specific},
+	{"beverages", constants.IndustryFoodBeverage, specific},
+	{"restaurant", constants.IndustryFoodBeverage, specific},
+	{"restaurants", constants.IndustryFoodBeverage, specific},
+	{"foodtech", constants.IndustryFoodBeverage, specific},
+	{"brewery", constants.IndustryFoodBeverage, specific},
+	{"coffee", constants.IndustryFoodBeverage, specific},
+	{"catering", constants.IndustryFoodBeverage, specific},
+
+	{"travel", constants.IndustryTravel, specific},
+	{"tourism", constants.IndustryTravel, specific},
+	{"hospitality", constants.IndustryTravel, specific},
+	{"hotel", constants.IndustryTravel, specific},
+	{"hotels", constants.IndustryTravel, specific},
+	{"airline", constants.IndustryTravel, specific},
+	{"airlines", constants.IndustryTravel, specific},
+
+	{"fashion", constants.IndustryFashion, specific},
+	{"apparel", constants.IndustryFashion, specific},
+	{"clothing", constants.IndustryFashion, specific},
+	{"footwear", constants.IndustryFashion, specific},
+	{"luxury", constants.IndustryFashion, generic},
+	{"textile", constants.IndustryFashion, specific},
+	{"textiles", constants.IndustryFashion, specific},
+}
@@ -0,0 +1,564 @@
+package lists
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/querylang"
+	"github.com/bhati00/Fynelo/backend/internal/company/service"
+	"github.com/bhati00/Fynelo/backend/internal/taxonomy"
+	"github.com/bhati00/Fynelo/backend/pkg/actor"
+	"github.com/gin-gonic/gin"
+)
+
+// TeamHeader names the caller's team, whose shared lists they can see, until
+// requests are authenticated.
+const TeamHeader = "X-Team"
+
+// FromSearchRequest is a company search plus how many of its results to add.
+type FromSearchRequest struct {
+	service.CompanySearchRequest
+	Max int `form:"max"`
+}
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// CreateListHandler godoc
+// @Summary Create a prospect list
+// @Description Creates a list owned by the caller. Shared lists are visible to everyone on the list's team, which defaults to the caller's.
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "User creating the list"
+// @Param X-Team header string false "Caller's team"
+// @Param list body ListRequest true "List"
+// @Success 201 {object} ProspectList
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /lists [post]
+func (h *Handler) CreateListHandler(c *gin.Context) {
+	var req ListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	list, err := h.service.CreateList(c.Request.Context(), viewer(c), req)
+	if err != nil {
+		h.writeError(c, err, "Failed to create list")
+		return
+	}
+
+	c.JSON(http.StatusCreated, list)
+}
+
+// ListListsHandler godoc
+// @Summary List prospect lists
+// @Description Lists the caller's own lists and the lists shared with their team, most recently changed first
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "User"
+// @Param X-Team header string false "Caller's team"
+// @Param limit query int false "Results limit (default: 20, max: 100)"
+// @Param offset query int false "Results offset (default: 0)"
+// @Success 200 {object} ListsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /lists [get]
+func (h *Handler) ListListsHandler(c *gin.Context) {
+	var req ListsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	result, err := h.service.ListLists(c.Request.Context(), viewer(c), req)
+	if err != nil {
+		h.writeError(c, err, "Failed to list lists")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetListHandler godoc
+// @Summary Get a prospect list
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "User"
+// @Param X-Team header string false "Caller's team"
+// @Param id path int true "List ID"
+// @Success 200 {object} ProspectList
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /lists/{id} [get]
+func (h *Handler) GetListHandler(c *gin.Context) {
+	id, ok := listID(c)
+	if !ok {
+		return
+	}
+
+	list, err := h.service.GetList(c.Request.Context(), viewer(c), id)
+	if err != nil {
+		h.writeError(c, err, "Failed to get list")
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// UpdateListHandler godoc
+// @Summary Update a prospect list
+// @Description Replaces a list's name, description, team and sharing. Only the owner can change a list.
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "List owner"
+// @Param X-Team header string false "Caller's team"
+// @Param id path int true "List ID"
+// @Param list body ListRequest true "List"
+// @Success 200 {object} ProspectList
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /lists/{id} [put]
+func (h *Handler) UpdateListHandler(c *gin.Context) {
+	id, ok := listID(c)
+	if !ok {
+		return
+	}
+
+	var req ListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	list, err := h.service.UpdateList(c.Request.Context(), viewer(c), id, req)
+	if err != nil {
+		h.writeError(c, err, "Failed to update list")
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// DeleteListHandler godoc
+// @Summary Delete a prospect list
+// @Description Deletes a list and its memberships. Only the owner can delete a list.
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "List owner"
+// @Param id path int true "List ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /lists/{id} [delete]
+func (h *Handler) DeleteListHandler(c *gin.Context) {
+	id, ok := listID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteList(c.Request.Context(), viewer(c), id); err != nil {
+		h.writeError(c, err, "Failed to delete list")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddCompaniesHandler godoc
+// @Summary Add companies to a list
+// @Description Adds up to 1000 companies by ID. Companies already on the list keep their status, notes and tags; unknown IDs are reported as missing.
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "User"
+// @Param X-Team header string false "Caller's team"
+// @Param id path int true "List ID"
+// @Param companies body CompanyIDsRequest true "Company IDs"
+// @Success 200 {object} AddResult
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /lists/{id}/companies [post]
+func (h *Handler) AddCompaniesHandler(c *gin.Context) {
+	id, ok := listID(c)
+	if !ok {
+		return
+	}
+
+	var req CompanyIDsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	result, err := h.service.AddCompanies(c.Request.Context(), viewer(c), id, req.CompanyIDs)
+	if err != nil {
+		h.writeError(c, err, "Failed to add companies")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// AddFromSearchHandler godoc
+// @Summary Add search results to a list
+// @Description Runs a company search with the same filters as /companies/search and adds up to max of the matches to the list
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "User"
+// @Param X-Team header string false "Caller's team"
+// @Param id path int true "List ID"
+// @Param q query string false "Search query, or an advanced query when q_mode=advanced"
+// @Param q_mode query string false "Query mode: simple (default) or advanced" Enums(simple, advanced)
+// @Param industry query string false "Industry filter"
+// @Param employee_size query string false "Employee size range (e.g., 1-10, 11-50)"
+// @Param location query string false "Location filter (free-text HQ location)"
+// @Param country query string false "Country name or ISO-3166 code"
+// @Param state query string false "State/region name or ISO-3166-2 code"
+// @Param city query string false "City name"
+// @Param near query string false "Radius search center: place name or lat,lon"
+// @Param radius_km query number false "Radius for near in km"
+// @Param funding_stage query string false "Funding stage"
+// @Param founded_min query int false "Founded after year"
+// @Param founded_max query int false "Founded before year"
+// @Param status query string false "Company status"
+// @Param max query int false "Most companies to add (default and max: 1000)"
+// @Success 200 {object} AddResult
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /lists/{id}/companies/from-search [post]
+func (h *Handler) AddFromSearchHandler(c *gin.Context) {
+	id, ok := listID(c)
+	if !ok {
+		return
+	}
+
+	var req FromSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	result, err := h.service.AddFromSearch(c.Request.Context(), viewer(c), id, req.CompanySearchRequest, req.Max)
+	if err != nil {
+		h.writeError(c, err, "Failed to add search results")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RemoveCompaniesHandler godoc
+// @Summary Remove companies from a list
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "User"
+// @Param X-Team header string false "Caller's team"
+// @Param id path int true "List ID"
+// @Param companies body CompanyIDsRequest true "Company IDs"
+// @Success 200 {object} RemoveResult
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /lists/{id}/companies [delete]
+func (h *Handler) RemoveCompaniesHandler(c *gin.Context) {
+	id, ok := listID(c)
+	if !ok {
+		return
+	}
+
+	var req CompanyIDsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	result, err := h.service.RemoveCompanies(c.Request.Context(), viewer(c), id, req.CompanyIDs)
+	if err != nil {
+		h.writeError(c, err, "Failed to remove companies")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RemoveCompanyHandler godoc
+// @Summary Remove a company from a list
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "User"
+// @Param X-Team header string false "Caller's team"
+// @Param id path int true "List ID"
+// @Param company_id path int true "Company ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /lists/{id}/companies/{company_id} [delete]
+func (h *Handler) RemoveCompanyHandler(c *gin.Context) {
+	id, ok := listID(c)
+	if !ok {
+		return
+	}
+	companyID, ok := memberCompanyID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.RemoveCompanies(c.Request.Context(), viewer(c), id, []uint{companyID})
+	if err != nil {
+		h.writeError(c, err, "Failed to remove company")
+		return
+	}
+	if result.Removed == 0 {
+		h.writeError(c, ErrMemberNotFound, "")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListMembersHandler godoc
+// @Summary List the companies on a list
+// @Description Lists a list's companies with their status, notes and tags, in the order they were added
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "User"
+// @Param X-Team header string false "Caller's team"
+// @Param id path int true "List ID"
+// @Param status query string false "new, contacted, qualified or disqualified"
+// @Param tag query string false "Only companies with this tag"
+// @Param limit query int false "Results limit (default: 20, max: 100)"
+// @Param offset query int false "Results offset (default: 0)"
+// @Success 200 {object} MembersResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /lists/{id}/companies [get]
+func (h *Handler) ListMembersHandler(c *gin.Context) {
+	id, ok := listID(c)
+	if !ok {
+		return
+	}
+
+	var req MembersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	result, err := h.service.ListMembers(c.Request.Context(), viewer(c), id, req)
+	if err != nil {
+		h.writeError(c, err, "Failed to list companies")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UpdateMemberHandler godoc
+// @Summary Update a company on a list
+// @Description Sets the status, notes or tags of a company on the list. Fields left out are unchanged; tags replace the existing ones.
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "User"
+// @Param X-Team header string false "Caller's team"
+// @Param id path int true "List ID"
+// @Param company_id path int true "Company ID"
+// @Param member body MemberUpdate true "Changes"
+// @Success 200 {object} ListMember
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /lists/{id}/companies/{company_id} [patch]
+func (h *Handler) UpdateMemberHandler(c *gin.Context) {
+	id, ok := listID(c)
+	if !ok {
+		return
+	}
+	companyID, ok := memberCompanyID(c)
+	if !ok {
+		return
+	}
+
+	var req MemberUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	member, err := h.service.UpdateMember(c.Request.Context(), viewer(c), id, companyID, req)
+	if err != nil {
+		h.writeError(c, err, "Failed to update company on list")
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+// ExportListHandler godoc
+// @Summary Export a list
+// @Description Downloads every company on the list with its status, notes and tags, as CSV (default) or JSON
+// @Tags Lists
+// @Produce json
+// @Produce text/csv
+// @Param X-Actor header string true "User"
+// @Param X-Team header string false "Caller's team"
+// @Param id path int true "List ID"
+// @Param format query string false "csv (default) or json" Enums(csv, json)
+// @Param status query string false "new, contacted, qualified or disqualified"
+// @Param tag query string false "Only companies with this tag"
+// @Success 200 {object} MembersResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /lists/{id}/export [get]
+func (h *Handler) ExportListHandler(c *gin.Context) {
+	id, ok := listID(c)
+	if !ok {
+		return
+	}
+
+	var req MembersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "json" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be 'csv' or 'json'"})
+		return
+	}
+
+	list, members, err := h.service.Export(c.Request.Context(), viewer(c), id, req)
+	if err != nil {
+		h.writeError(c, err, "Failed to export list")
+		return
+	}
+
+	filename := fmt.Sprintf("list-%d-%s.%s", list.ID, time.Now().UTC().Format("20060102"), format)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	if format == "json" {
+		c.JSON(http.StatusOK, MembersResponse{Members: members, Total: list.MemberCount, Limit: len(members)})
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Status(http.StatusOK)
+	w := csv.NewWriter(c.Writer)
+	// The status is already sent, so write errors (usually a client that
+	// went away) can only be logged.
+	if err := w.Write([]string{"company_id", "name", "domain", "website", "industry", "hq_location", "status", "tags", "notes", "added_by", "added_at"}); err != nil {
+		log.Printf("List %d: CSV export failed: %v", list.ID, err)
+		return
+	}
+	for _, m := range members {
+		row := []string{strconv.FormatUint(uint64(m.CompanyID), 10), "", "", "", "", "",
+			string(m.Status), strings.Join(m.Tags, ";"), m.Notes, m.AddedBy, m.CreatedAt.UTC().Format(time.RFC3339)}
+		if company := m.Company; company != nil {
+			row[1] = company.Name
+			row[2] = deref(company.Domain)
+			row[3] = deref(company.Website)
+			if company.IndustryID != nil {
+				row[4] = taxonomy.Default().Name(taxonomy.Industries, *company.IndustryID)
+			}
+			row[5] = deref(company.HQLocation)
+		}
+		for i := range row {
+			row[i] = csvSafe(row[i])
+		}
+		if err := w.Write(row); err != nil {
+			log.Printf("List %d: CSV export failed: %v", list.ID, err)
+			return
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		log.Printf("List %d: CSV export failed: %v", list.ID, err)
+	}
+}
+
+// csvSafe keeps spreadsheets from running a cell as a formula by prefixing
+// values that start with a formula character with an apostrophe.
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func (h *Handler) writeError(c *gin.Context, err error, fallback string) {
+	var syntaxErr *querylang.SyntaxError
+	switch {
+	case errors.As(err, &syntaxErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": syntaxErr.Error(), "position": syntaxErr.Pos})
+	case errors.Is(err, ErrListNotFound), errors.Is(err, ErrMemberNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrNotOwner):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrNoUser), errors.Is(err, ErrNoTeam), errors.Is(err, ErrInvalidStatus),
+		errors.Is(err, ErrInvalidTag), errors.Is(err, ErrTooManyTags), errors.Is(err, ErrTooManyCompanies),
+		service.IsSearchValidationError(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+func viewer(c *gin.Context) Viewer {
+	return Viewer{User: actor.FromContext(c.Request.Context()), Team: strings.TrimSpace(c.GetHeader(TeamHeader))}
+}
+
+func listID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid list ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func memberCompanyID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("company_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,25 @@
+package lists
+
+import (
+	"log"
+
+	"github.com/bhati00/Fynelo/backend/pkg/database"
+)
+
+func Migrate() {
+	database.DB.AutoMigrate(&ProspectList{}, &ListMember{}, &StatusChange{}, &Addition{})
+	if err := backfillAdditions(); err != nil {
+		log.Printf("Lists migration: failed to record additions of existing members: %v", err)
+	}
+}
+
+// backfillAdditions logs an Addition for each member put on a list before
+// additions were logged. Members removed since then are lost.
+func backfillAdditions() error {
+	var logged int64
+	if err := database.DB.Model(&Addition{}).Count(&logged).Error; err != nil || logged > 0 {
+		return err
+	}
+	return database.DB.Exec(`INSERT INTO prospect_list_additions (list_id, company_id, added_by, created_at)
+		SELECT list_id, company_id, added_by, created_at FROM prospect_list_members ORDER BY id`).Error
+}
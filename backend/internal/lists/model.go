@@ -0,0 +1,85 @@
+package lists
+
+import (
+	"time"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"gorm.io/gorm"
+)
+
+type MemberStatus string
+
+const (
+	StatusNew          MemberStatus = "new"
+	StatusContacted    MemberStatus = "contacted"
+	StatusQualified    MemberStatus = "qualified"
+	StatusDisqualified MemberStatus = "disqualified"
+)
+
+// ProspectList is a named set of target companies. Owner is the actor that
+// created it; when Shared, everyone on Team can see and work the list.
+type ProspectList struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	Name        string         `gorm:"type:varchar(255);not null" json:"name"`
+	Description string         `gorm:"type:text" json:"description"`
+	Owner       string         `gorm:"type:varchar(255);not null;index" json:"owner"`
+	Team        string         `gorm:"type:varchar(255);index" json:"team"`
+	Shared      bool           `gorm:"not null" json:"shared"`
+	MemberCount int64          `gorm:"-" json:"member_count"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+func (ProspectList) TableName() string {
+	return "prospect_lists"
+}
+
+// ListMember is a company on a list, with the rep's progress on it.
+type ListMember struct {
+	ID        uint         `gorm:"primaryKey" json:"id"`
+	ListID    uint         `gorm:"not null;uniqueIndex:idx_list_members_list_company" json:"list_id"`
+	CompanyID uint         `gorm:"not null;uniqueIndex:idx_list_members_list_company;index" json:"company_id"`
+	Status    MemberStatus `gorm:"type:varchar(20);not null;index" json:"status"`
+	Notes     string       `gorm:"type:text" json:"notes"`
+	Tags      []string     `gorm:"serializer:json" json:"tags"` // lowercased, sorted
+	AddedBy   string       `gorm:"type:varchar(255)" json:"added_by,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+
+	Company *model.Company `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+}
+
+func (ListMember) TableName() string {
+	return "prospect_list_members"
+}
+
+// StatusChange records a member moving between statuses, so funnel reports
+// survive later changes and removals.
+type StatusChange struct {
+	ID        uint         `gorm:"primaryKey" json:"id"`
+	ListID    uint         `gorm:"not null;index" json:"list_id"`
+	CompanyID uint         `gorm:"not null;index" json:"company_id"`
+	From      MemberStatus `gorm:"column:from_status;type:varchar(20);not null" json:"from"`
+	To        MemberStatus `gorm:"column:to_status;type:varchar(20);not null;index" json:"to"`
+	ChangedBy string       `gorm:"type:varchar(255)" json:"changed_by,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+func (StatusChange) TableName() string {
+	return "prospect_list_status_changes"
+}
+
+// Addition records a company being put on a list. Members are deleted when
+// taken off a list, so reports count additions from here.
+type Addition struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	ListID    uint      `gorm:"not null;index" json:"list_id"`
+	CompanyID uint      `gorm:"not null;index" json:"company_id"`
+	AddedBy   string    `gorm:"type:varchar(255)" json:"added_by,omitempty"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+func (Addition) TableName() string {
+	return "prospect_list_additions"
+}
@@ -0,0 +1,223 @@
+package lists
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MemberFilter narrows a list's members. Zero values match everything; a
+// negative Limit returns all members.
+type MemberFilter struct {
+	Status MemberStatus
+	Tag    string
+	Limit  int
+	Offset int
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) CreateList(ctx context.Context, list *ProspectList) error {
+	return r.db.WithContext(ctx).Create(list).Error
+}
+
+func (r *Repository) GetList(ctx context.Context, id uint) (*ProspectList, error) {
+	var list ProspectList
+	if err := r.db.WithContext(ctx).First(&list, id).Error; err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+func (r *Repository) SaveList(ctx context.Context, list *ProspectList) error {
+	return r.db.WithContext(ctx).Save(list).Error
+}
+
+// DeleteList soft-deletes a list and drops its memberships.
+func (r *Repository) DeleteList(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("list_id = ?", id).Delete(&ListMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&ProspectList{}, id).Error
+	})
+}
+
+// VisibleLists returns the lists user owns plus those shared with team, newest
+// first, and their total.
+func (r *Repository) VisibleLists(ctx context.Context, user, team string, limit, offset int) ([]ProspectList, int64, error) {
+	tx := r.db.WithContext(ctx).Model(&ProspectList{})
+	if team != "" {
+		tx = tx.Where("owner = ? OR (shared = ? AND team = ?)", user, true, team)
+	} else {
+		tx = tx.Where("owner = ?", user)
+	}
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var lists []ProspectList
+	if err := tx.Order("updated_at DESC, id DESC").Limit(limit).Offset(offset).Find(&lists).Error; err != nil {
+		return nil, 0, err
+	}
+	return lists, total, nil
+}
+
+// MemberCounts returns the number of companies on each of the given lists.
+func (r *Repository) MemberCounts(ctx context.Context, listIDs []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64, len(listIDs))
+	if len(listIDs) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		ListID uint
+		Count  int64
+	}
+	if err := r.db.WithContext(ctx).Model(&ListMember{}).
+		Select("list_id, COUNT(*) AS count").
+		Where("list_id IN ?", listIDs).
+		Group("list_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.ListID] = row.Count
+	}
+	return counts, nil
+}
+
+// ExistingCompanyIDs returns those of ids that name a company that has not been deleted.
+func (r *Repository) ExistingCompanyIDs(ctx context.Context, ids []uint) ([]uint, error) {
+	var existing []uint
+	if len(ids) == 0 {
+		return existing, nil
+	}
+	if err := r.db.WithContext(ctx).Model(&model.Company{}).
+		Where("id IN ?", ids).
+		Pluck("id", &existing).Error; err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// AddMembers puts companies on a list, skipping those already on it, logs
+// an Addition for each company added and returns how many were added.
+func (r *Repository) AddMembers(ctx context.Context, members []ListMember) (int64, error) {
+	if len(members) == 0 {
+		return 0, nil
+	}
+	var added int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		companyIDs := make([]uint, len(members))
+		for i, m := range members {
+			companyIDs[i] = m.CompanyID
+		}
+		var onList []uint
+		if err := tx.Model(&ListMember{}).
+			Where("list_id = ? AND company_id IN ?", members[0].ListID, companyIDs).
+			Pluck("company_id", &onList).Error; err != nil {
+			return err
+		}
+		skip := make(map[uint]bool, len(onList))
+		for _, id := range onList {
+			skip[id] = true
+		}
+		var fresh []ListMember
+		var additions []Addition
+		for _, m := range members {
+			if skip[m.CompanyID] {
+				continue
+			}
+			skip[m.CompanyID] = true
+			fresh = append(fresh, m)
+			additions = append(additions, Addition{ListID: m.ListID, CompanyID: m.CompanyID, AddedBy: m.AddedBy})
+		}
+		if len(fresh) == 0 {
+			return nil
+		}
+		result := tx.Omit("Company").
+			Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "list_id"}, {Name: "company_id"}}, DoNothing: true}).
+			CreateInBatches(fresh, 100)
+		if result.Error != nil {
+			return result.Error
+		}
+		added = result.RowsAffected
+		return tx.CreateInBatches(additions, 100).Error
+	})
+	return added, err
+}
+
+// RemoveMembers takes companies off a list and returns how many were removed.
+func (r *Repository) RemoveMembers(ctx context.Context, listID uint, companyIDs []uint) (int64, error) {
+	if len(companyIDs) == 0 {
+		return 0, nil
+	}
+	result := r.db.WithContext(ctx).
+		Where("list_id = ? AND company_id IN ?", listID, companyIDs).
+		Delete(&ListMember{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *Repository) GetMember(ctx context.Context, listID, companyID uint) (*ListMember, error) {
+	var member ListMember
+	if err := r.db.WithContext(ctx).Preload("Company").
+		Where("list_id = ? AND company_id = ?", listID, companyID).
+		First(&member).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// SaveMember stores a member's progress, logging change when its status moved.
+func (r *Repository) SaveMember(ctx context.Context, member *ListMember, change *StatusChange) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Company").Save(member).Error; err != nil {
+			return err
+		}
+		if change == nil {
+			return nil
+		}
+		return tx.Create(change).Error
+	})
+}
+
+// Members returns a list's members in the order they were added, with their
+// companies, and the total matching filter.
+func (r *Repository) Members(ctx context.Context, listID uint, filter MemberFilter) ([]ListMember, int64, error) {
+	tx := r.db.WithContext(ctx).Model(&ListMember{}).Where("list_id = ?", listID)
+	if filter.Status != "" {
+		tx = tx.Where("status = ?", filter.Status)
+	}
+	if filter.Tag != "" {
+		// tags are stored as a JSON array and never contain quotes or backslashes
+		pattern := `%"` + strings.NewReplacer(`%`, `\%`, `_`, `\_`).Replace(filter.Tag) + `"%`
+		tx = tx.Where(`tags LIKE ? ESCAPE '\'`, pattern)
+	}
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var members []ListMember
+	if err := tx.Preload("Company").
+		Order("id ASC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&members).Error; err != nil {
+		return nil, 0, err
+	}
+	return members, total, nil
+}
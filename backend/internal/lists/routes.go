@@ -0,0 +1,22 @@
+package lists
+
+import "github.com/gin-gonic/gin"
+
+func RegisterListRoutes(rg *gin.RouterGroup, h *Handler) {
+	lists := rg.Group("/lists")
+	{
+		lists.POST("", h.CreateListHandler)
+		lists.GET("", h.ListListsHandler)
+		lists.GET("/:id", h.GetListHandler)
+		lists.PUT("/:id", h.UpdateListHandler)
+		lists.DELETE("/:id", h.DeleteListHandler)
+		lists.GET("/:id/export", h.ExportListHandler)
+
+		lists.POST("/:id/companies", h.AddCompaniesHandler)
+		lists.POST("/:id/companies/from-search", h.AddFromSearchHandler)
+		lists.GET("/:id/companies", h.ListMembersHandler)
+		lists.DELETE("/:id/companies", h.RemoveCompaniesHandler)
+		lists.PATCH("/:id/companies/:company_id", h.UpdateMemberHandler)
+		lists.DELETE("/:id/companies/:company_id", h.RemoveCompanyHandler)
+	}
+}
@@ -0,0 +1,425 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/service"
+	"github.com/bhati00/Fynelo/backend/pkg/pagination"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// Most companies one bulk add may name or one search may add
+	maxBulkAdd = 1000
+
+	maxTags      = 20
+	maxTagLength = 50
+)
+
+var (
+	ErrListNotFound     = errors.New("list not found")
+	ErrMemberNotFound   = errors.New("company is not on this list")
+	ErrNoUser           = errors.New("the X-Actor header must name the user")
+	ErrNotOwner         = errors.New("only the list owner can change or delete the list")
+	ErrNoTeam           = errors.New("a list can only be shared with a team")
+	ErrInvalidStatus    = errors.New("status must be one of: new, contacted, qualified, disqualified")
+	ErrInvalidTag       = errors.New("tags may only contain letters, digits, spaces and - _ . : / and be at most 50 characters")
+	ErrTooManyTags      = errors.New("a company can have at most 20 tags on a list")
+	ErrTooManyCompanies = errors.New("at most 1000 companies can be added at once")
+)
+
+var statuses = map[MemberStatus]struct{}{
+	StatusNew: {}, StatusContacted: {}, StatusQualified: {}, StatusDisqualified: {},
+}
+
+// Viewer is who a request acts for. User comes from the X-Actor header and
+// Team from X-Team until requests are authenticated.
+type Viewer struct {
+	User string
+	Team string
+}
+
+// ListRequest creates or replaces a list. Team defaults to the caller's team.
+type ListRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	Team        string `json:"team"`
+	Shared      bool   `json:"shared"`
+}
+
+type ListsRequest struct {
+	Limit  int `form:"limit"`
+	Offset int `form:"offset"`
+}
+
+type ListsResponse struct {
+	Lists  []ProspectList `json:"lists"`
+	Total  int64          `json:"total"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+}
+
+type CompanyIDsRequest struct {
+	CompanyIDs []uint `json:"company_ids" binding:"required,min=1"`
+}
+
+// AddResult reports a bulk add. Matched is only set when adding from a search.
+type AddResult struct {
+	Matched int    `json:"matched,omitempty"`
+	Added   int64  `json:"added"`
+	Skipped int    `json:"skipped"`           // already on the list
+	Missing []uint `json:"missing,omitempty"` // no such company
+}
+
+type RemoveResult struct {
+	Removed int64 `json:"removed"`
+}
+
+type MembersRequest struct {
+	Status string `form:"status"`
+	Tag    string `form:"tag"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
+
+type MembersResponse struct {
+	Members []ListMember `json:"members"`
+	Total   int64        `json:"total"`
+	Limit   int          `json:"limit"`
+	Offset  int          `json:"offset"`
+}
+
+// MemberUpdate changes only the fields that are present.
+type MemberUpdate struct {
+	Status *MemberStatus `json:"status"`
+	Notes  *string       `json:"notes"`
+	Tags   *[]string     `json:"tags"`
+}
+
+type Service struct {
+	repo      *Repository
+	companies service.CompanyService
+}
+
+func NewService(repo *Repository, companies service.CompanyService) *Service {
+	return &Service{repo: repo, companies: companies}
+}
+
+func (s *Service) CreateList(ctx context.Context, v Viewer, req ListRequest) (*ProspectList, error) {
+	if v.User == "" {
+		return nil, ErrNoUser
+	}
+	list := &ProspectList{Owner: v.User}
+	if err := apply(list, v, req); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateList(ctx, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (s *Service) GetList(ctx context.Context, v Viewer, id uint) (*ProspectList, error) {
+	list, err := s.visible(ctx, v, id)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.repo.MemberCounts(ctx, []uint{id})
+	if err != nil {
+		return nil, err
+	}
+	list.MemberCount = counts[id]
+	return list, nil
+}
+
+// ListLists returns the caller's own lists and those shared with their team.
+func (s *Service) ListLists(ctx context.Context, v Viewer, req ListsRequest) (*ListsResponse, error) {
+	if v.User == "" {
+		return nil, ErrNoUser
+	}
+	req.Limit, req.Offset = pagination.Page(req.Limit, req.Offset)
+
+	lists, total, err := s.repo.VisibleLists(ctx, v.User, v.Team, req.Limit, req.Offset)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, len(lists))
+	for i, l := range lists {
+		ids[i] = l.ID
+	}
+	counts, err := s.repo.MemberCounts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range lists {
+		lists[i].MemberCount = counts[lists[i].ID]
+	}
+	return &ListsResponse{Lists: lists, Total: total, Limit: req.Limit, Offset: req.Offset}, nil
+}
+
+func (s *Service) UpdateList(ctx context.Context, v Viewer, id uint, req ListRequest) (*ProspectList, error) {
+	list, err := s.owned(ctx, v, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := apply(list, v, req); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveList(ctx, list); err != nil {
+		return nil, err
+	}
+	return s.GetList(ctx, v, id)
+}
+
+func (s *Service) DeleteList(ctx context.Context, v Viewer, id uint) error {
+	if _, err := s.owned(ctx, v, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteList(ctx, id)
+}
+
+// AddCompanies puts companies on a list. Companies already on it keep their
+// status, notes and tags.
+func (s *Service) AddCompanies(ctx context.Context, v Viewer, id uint, companyIDs []uint) (*AddResult, error) {
+	if len(companyIDs) > maxBulkAdd {
+		return nil, ErrTooManyCompanies
+	}
+	if _, err := s.visible(ctx, v, id); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.ExistingCompanyIDs(ctx, companyIDs)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[uint]bool, len(existing))
+	for _, companyID := range existing {
+		found[companyID] = true
+	}
+
+	result := &AddResult{}
+	var ids []uint
+	seen := map[uint]bool{}
+	for _, companyID := range companyIDs {
+		if seen[companyID] {
+			continue
+		}
+		seen[companyID] = true
+		if !found[companyID] {
+			result.Missing = append(result.Missing, companyID)
+			continue
+		}
+		ids = append(ids, companyID)
+	}
+	return s.add(ctx, v, id, ids, result)
+}
+
+// AddFromSearch puts up to max companies matching a company search on a list.
+// The search's own limit and offset are ignored.
+func (s *Service) AddFromSearch(ctx context.Context, v Viewer, id uint, req service.CompanySearchRequest, max int) (*AddResult, error) {
+	if max <= 0 || max > maxBulkAdd {
+		max = maxBulkAdd
+	}
+	if _, err := s.visible(ctx, v, id); err != nil {
+		return nil, err
+	}
+	ids, err := s.companies.SearchCompanyIDs(ctx, req, max)
+	if err != nil {
+		return nil, err
+	}
+	return s.add(ctx, v, id, ids, &AddResult{Matched: len(ids)})
+}
+
+func (s *Service) add(ctx context.Context, v Viewer, id uint, companyIDs []uint, result *AddResult) (*AddResult, error) {
+	members := make([]ListMember, len(companyIDs))
+	for i, companyID := range companyIDs {
+		members[i] = ListMember{ListID: id, CompanyID: companyID, Status: StatusNew, Tags: []string{}, AddedBy: v.User}
+	}
+	added, err := s.repo.AddMembers(ctx, members)
+	if err != nil {
+		return nil, err
+	}
+	result.Added = added
+	result.Skipped = len(companyIDs) - int(added)
+	return result, nil
+}
+
+func (s *Service) RemoveCompanies(ctx context.Context, v Viewer, id uint, companyIDs []uint) (*RemoveResult, error) {
+	if _, err := s.visible(ctx, v, id); err != nil {
+		return nil, err
+	}
+	removed, err := s.repo.RemoveMembers(ctx, id, companyIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoveResult{Removed: removed}, nil
+}
+
+func (s *Service) ListMembers(ctx context.Context, v Viewer, id uint, req MembersRequest) (*MembersResponse, error) {
+	if _, err := s.visible(ctx, v, id); err != nil {
+		return nil, err
+	}
+	filter, err := memberFilter(req)
+	if err != nil {
+		return nil, err
+	}
+	filter.Limit, filter.Offset = pagination.Page(req.Limit, req.Offset)
+
+	members, total, err := s.repo.Members(ctx, id, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &MembersResponse{Members: members, Total: total, Limit: filter.Limit, Offset: filter.Offset}, nil
+}
+
+func (s *Service) UpdateMember(ctx context.Context, v Viewer, id, companyID uint, req MemberUpdate) (*ListMember, error) {
+	if _, err := s.visible(ctx, v, id); err != nil {
+		return nil, err
+	}
+	member, err := s.repo.GetMember(ctx, id, companyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMemberNotFound
+		}
+		return nil, err
+	}
+
+	var change *StatusChange
+	if req.Status != nil {
+		if _, ok := statuses[*req.Status]; !ok {
+			return nil, ErrInvalidStatus
+		}
+		if *req.Status != member.Status {
+			change = &StatusChange{ListID: id, CompanyID: companyID, From: member.Status, To: *req.Status, ChangedBy: v.User}
+		}
+		member.Status = *req.Status
+	}
+	if req.Notes != nil {
+		member.Notes = strings.TrimSpace(*req.Notes)
+	}
+	if req.Tags != nil {
+		tags, err := normalizeTags(*req.Tags)
+		if err != nil {
+			return nil, err
+		}
+		member.Tags = tags
+	}
+	if err := s.repo.SaveMember(ctx, member, change); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// Export returns a list with all of its members matching req, ignoring paging.
+func (s *Service) Export(ctx context.Context, v Viewer, id uint, req MembersRequest) (*ProspectList, []ListMember, error) {
+	list, err := s.visible(ctx, v, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	filter, err := memberFilter(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	filter.Limit = -1
+
+	members, total, err := s.repo.Members(ctx, id, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	list.MemberCount = total
+	return list, members, nil
+}
+
+// visible loads a list the caller owns or that is shared with their team.
+// Lists the caller cannot see are reported as not found.
+func (s *Service) visible(ctx context.Context, v Viewer, id uint) (*ProspectList, error) {
+	if v.User == "" {
+		return nil, ErrNoUser
+	}
+	list, err := s.repo.GetList(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrListNotFound
+		}
+		return nil, err
+	}
+	if list.Owner == v.User || (list.Shared && list.Team != "" && list.Team == v.Team) {
+		return list, nil
+	}
+	return nil, ErrListNotFound
+}
+
+// owned loads a list only its owner may change; teammates get ErrNotOwner.
+func (s *Service) owned(ctx context.Context, v Viewer, id uint) (*ProspectList, error) {
+	list, err := s.visible(ctx, v, id)
+	if err != nil {
+		return nil, err
+	}
+	if list.Owner != v.User {
+		return nil, ErrNotOwner
+	}
+	return list, nil
+}
+
+func apply(list *ProspectList, v Viewer, req ListRequest) error {
+	list.Name = strings.TrimSpace(req.Name)
+	list.Description = strings.TrimSpace(req.Description)
+	list.Team = strings.TrimSpace(req.Team)
+	if list.Team == "" {
+		list.Team = v.Team
+	}
+	list.Shared = req.Shared
+	if list.Shared && list.Team == "" {
+		return ErrNoTeam
+	}
+	return nil
+}
+
+func memberFilter(req MembersRequest) (MemberFilter, error) {
+	filter := MemberFilter{Status: MemberStatus(req.Status)}
+	if filter.Status != "" {
+		if _, ok := statuses[filter.Status]; !ok {
+			return filter, ErrInvalidStatus
+		}
+	}
+	tags, err := normalizeTags([]string{req.Tag})
+	if err != nil {
+		return filter, err
+	}
+	if len(tags) > 0 {
+		filter.Tag = tags[0]
+	}
+	return filter, nil
+}
+
+// normalizeTags trims, lowercases, dedupes and sorts tags, dropping empty ones.
+func normalizeTags(tags []string) ([]string, error) {
+	seen := map[string]bool{}
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.Join(strings.Fields(tag), " "))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if len(tag) > maxTagLength {
+			return nil, ErrInvalidTag
+		}
+		for _, r := range tag {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune(" -_.:/", r) {
+				return nil, ErrInvalidTag
+			}
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > maxTags {
+		return nil, ErrTooManyTags
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
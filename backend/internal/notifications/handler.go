@@ -0,0 +1,108 @@
+package notifications
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/bhati00/Fynelo/backend/pkg/actor"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// ListNotificationsHandler godoc
+// @Summary List notifications
+// @Description Lists the caller's in-app notifications, newest first, with the number still unread
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "User"
+// @Param unread query bool false "Only unread notifications"
+// @Param limit query int false "Results limit (default: 20, max: 100)"
+// @Param offset query int false "Results offset (default: 0)"
+// @Success 200 {object} ListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /notifications [get]
+func (h *Handler) ListNotificationsHandler(c *gin.Context) {
+	var req ListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	result, err := h.service.List(c.Request.Context(), actor.FromContext(c.Request.Context()), req)
+	if err != nil {
+		h.writeError(c, err, "Failed to list notifications")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// MarkReadHandler godoc
+// @Summary Mark a notification as read
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "User"
+// @Param id path int true "Notification ID"
+// @Success 200 {object} Notification
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /notifications/{id}/read [post]
+func (h *Handler) MarkReadHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification ID"})
+		return
+	}
+
+	n, err := h.service.MarkRead(c.Request.Context(), actor.FromContext(c.Request.Context()), uint(id))
+	if err != nil {
+		h.writeError(c, err, "Failed to mark notification as read")
+		return
+	}
+
+	c.JSON(http.StatusOK, n)
+}
+
+// MarkAllReadHandler godoc
+// @Summary Mark all notifications as read
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "User"
+// @Success 200 {object} MarkReadResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /notifications/read-all [post]
+func (h *Handler) MarkAllReadHandler(c *gin.Context) {
+	result, err := h.service.MarkAllRead(c.Request.Context(), actor.FromContext(c.Request.Context()))
+	if err != nil {
+		h.writeError(c, err, "Failed to mark notifications as read")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *Handler) writeError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Notification not found"})
+	case errors.Is(err, ErrNoUser):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -0,0 +1,24 @@
+package notifications
+
+import "time"
+
+// Notification kinds
+const (
+	KindSavedSearchMatches = "saved_search.new_matches"
+)
+
+// Notification is an in-app message for one user. Link points at the API
+// resource it is about.
+type Notification struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	Recipient string     `gorm:"type:varchar(255);not null;index:idx_notifications_recipient_read" json:"recipient"`
+	Kind      string     `gorm:"type:varchar(50);not null" json:"kind"`
+	Title     string     `gorm:"type:varchar(500);not null" json:"title"`
+	Link      string     `gorm:"type:varchar(500)" json:"link,omitempty"`
+	ReadAt    *time.Time `gorm:"index:idx_notifications_recipient_read" json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
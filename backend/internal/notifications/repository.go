@@ -0,0 +1,66 @@
+package notifications
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(ctx context.Context, n *Notification) error {
+	return r.db.WithContext(ctx).Create(n).Error
+}
+
+// List returns a recipient's notifications, newest first, and their total.
+func (r *Repository) List(ctx context.Context, recipient string, unreadOnly bool, limit, offset int) ([]Notification, int64, error) {
+	tx := r.db.WithContext(ctx).Model(&Notification{}).Where("recipient = ?", recipient)
+	if unreadOnly {
+		tx = tx.Where("read_at IS NULL")
+	}
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var list []Notification
+	if err := tx.Order("id DESC").Limit(limit).Offset(offset).Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+func (r *Repository) UnreadCount(ctx context.Context, recipient string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&Notification{}).
+		Where("recipient = ? AND read_at IS NULL", recipient).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *Repository) Get(ctx context.Context, recipient string, id uint) (*Notification, error) {
+	var n Notification
+	if err := r.db.WithContext(ctx).Where("recipient = ?", recipient).First(&n, id).Error; err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// MarkRead marks a recipient's unread notifications as read, all of them when
+// ids is empty, and returns how many changed.
+func (r *Repository) MarkRead(ctx context.Context, recipient string, ids []uint, at time.Time) (int64, error) {
+	tx := r.db.WithContext(ctx).Model(&Notification{}).Where("recipient = ? AND read_at IS NULL", recipient)
+	if len(ids) > 0 {
+		tx = tx.Where("id IN ?", ids)
+	}
+	result := tx.Update("read_at", at)
+	return result.RowsAffected, result.Error
+}
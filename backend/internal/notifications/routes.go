@@ -0,0 +1,12 @@
+package notifications
+
+import "github.com/gin-gonic/gin"
+
+func RegisterNotificationRoutes(rg *gin.RouterGroup, h *Handler) {
+	notifications := rg.Group("/notifications")
+	{
+		notifications.GET("", h.ListNotificationsHandler)
+		notifications.POST("/read-all", h.MarkAllReadHandler)
+		notifications.POST("/:id/read", h.MarkReadHandler)
+	}
+}
@@ -0,0 +1,86 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bhati00/Fynelo/backend/pkg/pagination"
+)
+
+var ErrNoUser = errors.New("the X-Actor header must name the user")
+
+type ListRequest struct {
+	Unread bool `form:"unread"` // only unread notifications
+	Limit  int  `form:"limit"`
+	Offset int  `form:"offset"`
+}
+
+type ListResponse struct {
+	Notifications []Notification `json:"notifications"`
+	Total         int64          `json:"total"`
+	Unread        int64          `json:"unread"`
+	Limit         int            `json:"limit"`
+	Offset        int            `json:"offset"`
+}
+
+type MarkReadResponse struct {
+	Marked int64 `json:"marked"`
+}
+
+type Service struct {
+	repo *Repository
+}
+
+func NewService(repo *Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Notify stores a notification for its recipient.
+func (s *Service) Notify(ctx context.Context, n *Notification) error {
+	return s.repo.Create(ctx, n)
+}
+
+// List returns the user's notifications, newest first.
+func (s *Service) List(ctx context.Context, user string, req ListRequest) (*ListResponse, error) {
+	if user == "" {
+		return nil, ErrNoUser
+	}
+	req.Limit, req.Offset = pagination.Page(req.Limit, req.Offset)
+
+	list, total, err := s.repo.List(ctx, user, req.Unread, req.Limit, req.Offset)
+	if err != nil {
+		return nil, err
+	}
+	unread, err := s.repo.UnreadCount(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	return &ListResponse{Notifications: list, Total: total, Unread: unread, Limit: req.Limit, Offset: req.Offset}, nil
+}
+
+// MarkRead marks one of the user's notifications as read. Notifications of
+// other users are reported as not found.
+func (s *Service) MarkRead(ctx context.Context, user string, id uint) (*Notification, error) {
+	if user == "" {
+		return nil, ErrNoUser
+	}
+	if _, err := s.repo.Get(ctx, user, id); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.MarkRead(ctx, user, []uint{id}, time.Now()); err != nil {
+		return nil, err
+	}
+	return s.repo.Get(ctx, user, id)
+}
+
+func (s *Service) MarkAllRead(ctx context.Context, user string) (*MarkReadResponse, error) {
+	if user == "" {
+		return nil, ErrNoUser
+	}
+	marked, err := s.repo.MarkRead(ctx, user, nil, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &MarkReadResponse{Marked: marked}, nil
+}
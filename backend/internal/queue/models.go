@@ -25,7 +25,12 @@ const (
 type SearchFilters struct {
 	Industry     string `json:"industry,omitempty"`
 	EmployeeSize string `json:"employee_size,omitempty"`
+	EmployeesMin *int   `json:"employees_min,omitempty"`
+	EmployeesMax *int   `json:"employees_max,omitempty"`
 	Location     string `json:"location,omitempty"`
+	Country      string `json:"country,omitempty"`
+	State        string `json:"state,omitempty"`
+	City         string `json:"city,omitempty"`
 	FundingStage string `json:"funding_stage,omitempty"`
 	FoundedMin   *int   `json:"founded_min,omitempty"`
 	FoundedMax   *int   `json:"founded_max,omitempty"`
@@ -36,7 +41,9 @@ type SearchJob struct {
 	ID          string        `json:"id"`
 	UserID      uint          `json:"user_id,omitempty"`
 	Query       string        `json:"query"`
+	QueryMode   string        `json:"query_mode,omitempty"` // "advanced" when Query uses the search query language
 	Filters     SearchFilters `json:"filters"`
+	ICPID       uint          `json:"icp_id,omitempty"` // ICP profile whose run queued the job
 	Status      JobStatus     `json:"status"`
 	Priority    JobPriority   `json:"priority"`
 	CreatedAt   time.Time     `json:"created_at"`
@@ -5,8 +5,16 @@ import (
 	"github.com/bhati00/Fynelo/backend/internal/company"
 	"github.com/bhati00/Fynelo/backend/internal/company/repositories"
 	"github.com/bhati00/Fynelo/backend/internal/company/service"
+	"github.com/bhati00/Fynelo/backend/internal/contact"
+	"github.com/bhati00/Fynelo/backend/internal/emailfinder"
 	"github.com/bhati00/Fynelo/backend/internal/icp"
+	"github.com/bhati00/Fynelo/backend/internal/lists"
+	"github.com/bhati00/Fynelo/backend/internal/notifications"
 	"github.com/bhati00/Fynelo/backend/internal/queue"
+	"github.com/bhati00/Fynelo/backend/internal/searches"
+	"github.com/bhati00/Fynelo/backend/internal/signals"
+	"github.com/bhati00/Fynelo/backend/internal/taxonomy"
+	"github.com/bhati00/Fynelo/backend/internal/webhook"
 	"github.com/bhati00/Fynelo/backend/pkg/database"
 	"github.com/gin-gonic/gin"
 )
@@ -20,19 +28,65 @@ func SetupRoutes(r *gin.Engine) {
 	queueService := queue.NewQueueService()
 	queueHandler := queue.NewHandler(queueService)
 
-	// ICP builder
-	icpRepo := icp.NewRepository(db)         // Initialize the repository with the database connection
-	icpService := icp.NewService(icpRepo)    // Initialize the service with the database connection
-	icpHandler := icp.NewHandler(icpService) // Create a new handler with the service
-
 	// Company management
 	companyRepo := repositories.NewCompanyRepository(db)
-	companyService := service.NewCompanyService(companyRepo, queueService)
-	companyHandler := company.NewHandler(companyService)
+	revenueRepo := repositories.NewRevenueRepository(db)
+	fundingRepo := repositories.NewFundingRepository(db)
+	enrichmentService := service.NewEnrichmentService(
+		companyRepo,
+		revenueRepo,
+		fundingRepo,
+		repositories.NewTechnologyRepository(db),
+		repositories.NewLocationRepository(db),
+		repositories.NewProvenanceRepository(db),
+	)
+	companyService := service.NewCompanyService(companyRepo, enrichmentService, queueService)
+	dedupeService := service.NewDedupeService(repositories.NewDuplicateRepository(db))
+	historyService := service.NewHistoryService(repositories.NewHistoryRepository(db))
+	industryService := service.NewIndustryService(repositories.NewIndustryRepository(db), enrichmentService)
+	financialsService := service.NewFinancialsService(companyRepo, revenueRepo, fundingRepo)
+	investorService := service.NewInvestorService(repositories.NewInvestorRepository(db))
+	technologyService := service.NewTechnologyService(repositories.NewTechnologyRepository(db), companyRepo)
+	companyHandler := company.NewHandler(companyService, dedupeService, enrichmentService, historyService, industryService, financialsService, investorService, technologyService)
+
+	// ICP builder (runs use the company search and queue)
+	icpRepo := icp.NewRepository(db)                      // Initialize the repository with the database connection
+	icpService := icp.NewService(icpRepo, companyService) // Initialize the service with the database connection
+	icpHandler := icp.NewHandler(icpService)              // Create a new handler with the service
+
+	// Contacts
+	contactHandler := contact.NewHandler(contact.NewService(contact.NewRepository(db), icpRepo))
+	emailFinderHandler := emailfinder.NewHandler(emailfinder.NewService(emailfinder.NewRepository(db), nil))
+
+	// Prospect lists
+	listHandler := lists.NewHandler(lists.NewService(lists.NewRepository(db), companyService))
+
+	// Saved searches (re-run by the worker) and in-app notifications
+	notificationService := notifications.NewService(notifications.NewRepository(db))
+	notificationHandler := notifications.NewHandler(notificationService)
+	savedSearchHandler := searches.NewHandler(searches.NewService(searches.NewRepository(db), companyService, notificationService))
+
+	// Buying signals
+	signalService := signals.NewService(signals.NewRepository(db), icpRepo)
+	signalHandler := signals.NewHandler(signalService)
+
+	// Taxonomies (industries, company sizes, business types, buyer roles)
+	taxonomyHandler := taxonomy.NewHandler(taxonomy.NewService(taxonomy.NewRepository(db)))
+
+	// Webhooks (deliveries are sent by the worker)
+	webhookHandler := webhook.NewHandler(webhook.NewService(webhook.NewRepository(db), nil))
 
 	// Register feature routes
 	icp.RegisterICPRoutes(api, icpHandler)
 	company.RegisterCompanyRoutes(api, companyHandler)
+	contact.RegisterContactRoutes(api, contactHandler)
+	emailfinder.RegisterEmailFinderRoutes(api, emailFinderHandler)
+	lists.RegisterListRoutes(api, listHandler)
+	searches.RegisterSavedSearchRoutes(api, savedSearchHandler)
+	notifications.RegisterNotificationRoutes(api, notificationHandler)
 	queue.RegisterQueueRoutes(api, queueHandler)
+	signals.RegisterSignalRoutes(api, signalHandler)
+	webhook.RegisterWebhookRoutes(api, webhookHandler)
+	taxonomy.RegisterTaxonomyRoutes(api, taxonomyHandler)
 
 }
@@ -0,0 +1,286 @@
+package searches
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/querylang"
+	"github.com/bhati00/Fynelo/backend/internal/company/service"
+	"github.com/bhati00/Fynelo/backend/pkg/actor"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// CreateSavedSearchHandler godoc
+// @Summary Save a company search
+// @Description Saves a company search for the caller. The worker re-runs enabled searches hourly and notifies the owner of companies that did not match before; the first run records a baseline.
+// @Tags Saved searches
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "User"
+// @Param search body SavedSearchRequest true "Saved search"
+// @Success 201 {object} SavedSearch
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /saved-searches [post]
+func (h *Handler) CreateSavedSearchHandler(c *gin.Context) {
+	var req SavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	saved, err := h.service.Create(c.Request.Context(), actor.FromContext(c.Request.Context()), req)
+	if err != nil {
+		h.writeError(c, err, "Failed to save search")
+		return
+	}
+
+	c.JSON(http.StatusCreated, saved)
+}
+
+// ListSavedSearchesHandler godoc
+// @Summary List saved searches
+// @Description Lists the caller's saved searches, newest first
+// @Tags Saved searches
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "User"
+// @Param limit query int false "Results limit (default: 20, max: 100)"
+// @Param offset query int false "Results offset (default: 0)"
+// @Success 200 {object} ListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /saved-searches [get]
+func (h *Handler) ListSavedSearchesHandler(c *gin.Context) {
+	var req ListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	result, err := h.service.List(c.Request.Context(), actor.FromContext(c.Request.Context()), req)
+	if err != nil {
+		h.writeError(c, err, "Failed to list saved searches")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetSavedSearchHandler godoc
+// @Summary Get a saved search
+// @Tags Saved searches
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "User"
+// @Param id path int true "Saved search ID"
+// @Success 200 {object} SavedSearch
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /saved-searches/{id} [get]
+func (h *Handler) GetSavedSearchHandler(c *gin.Context) {
+	id, ok := searchID(c)
+	if !ok {
+		return
+	}
+
+	saved, err := h.service.Get(c.Request.Context(), actor.FromContext(c.Request.Context()), id)
+	if err != nil {
+		h.writeError(c, err, "Failed to get saved search")
+		return
+	}
+
+	c.JSON(http.StatusOK, saved)
+}
+
+// UpdateSavedSearchHandler godoc
+// @Summary Update a saved search
+// @Description Replaces a saved search's name, filters and enabled flag. Companies seen before stay seen.
+// @Tags Saved searches
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "User"
+// @Param id path int true "Saved search ID"
+// @Param search body SavedSearchRequest true "Saved search"
+// @Success 200 {object} SavedSearch
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /saved-searches/{id} [put]
+func (h *Handler) UpdateSavedSearchHandler(c *gin.Context) {
+	id, ok := searchID(c)
+	if !ok {
+		return
+	}
+
+	var req SavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	saved, err := h.service.Update(c.Request.Context(), actor.FromContext(c.Request.Context()), id, req)
+	if err != nil {
+		h.writeError(c, err, "Failed to update saved search")
+		return
+	}
+
+	c.JSON(http.StatusOK, saved)
+}
+
+// DeleteSavedSearchHandler godoc
+// @Summary Delete a saved search
+// @Tags Saved searches
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "User"
+// @Param id path int true "Saved search ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /saved-searches/{id} [delete]
+func (h *Handler) DeleteSavedSearchHandler(c *gin.Context) {
+	id, ok := searchID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), actor.FromContext(c.Request.Context()), id); err != nil {
+		h.writeError(c, err, "Failed to delete saved search")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RunSavedSearchHandler godoc
+// @Summary Run a saved search now
+// @Description Evaluates the search immediately and returns the companies that are new since earlier runs
+// @Tags Saved searches
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "User"
+// @Param id path int true "Saved search ID"
+// @Success 200 {object} RunDetail
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /saved-searches/{id}/run [post]
+func (h *Handler) RunSavedSearchHandler(c *gin.Context) {
+	id, ok := searchID(c)
+	if !ok {
+		return
+	}
+
+	run, err := h.service.RunNow(c.Request.Context(), actor.FromContext(c.Request.Context()), id)
+	if err != nil {
+		h.writeError(c, err, "Failed to run saved search")
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+// ListRunsHandler godoc
+// @Summary List a saved search's runs
+// @Description Lists past evaluations of the search, newest first, with the IDs of the companies new on each
+// @Tags Saved searches
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "User"
+// @Param id path int true "Saved search ID"
+// @Param limit query int false "Results limit (default: 20, max: 100)"
+// @Param offset query int false "Results offset (default: 0)"
+// @Success 200 {object} RunsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /saved-searches/{id}/runs [get]
+func (h *Handler) ListRunsHandler(c *gin.Context) {
+	id, ok := searchID(c)
+	if !ok {
+		return
+	}
+
+	var req ListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	result, err := h.service.Runs(c.Request.Context(), actor.FromContext(c.Request.Context()), id, req)
+	if err != nil {
+		h.writeError(c, err, "Failed to list runs")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetRunHandler godoc
+// @Summary Get a saved search run
+// @Description Returns one evaluation of the search with the companies that were new on it
+// @Tags Saved searches
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "User"
+// @Param id path int true "Saved search ID"
+// @Param run_id path int true "Run ID"
+// @Success 200 {object} RunDetail
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /saved-searches/{id}/runs/{run_id} [get]
+func (h *Handler) GetRunHandler(c *gin.Context) {
+	id, ok := searchID(c)
+	if !ok {
+		return
+	}
+	runID, err := strconv.ParseUint(c.Param("run_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid run ID"})
+		return
+	}
+
+	run, err := h.service.GetRun(c.Request.Context(), actor.FromContext(c.Request.Context()), id, uint(runID))
+	if err != nil {
+		h.writeError(c, err, "Failed to get run")
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+func (h *Handler) writeError(c *gin.Context, err error, fallback string) {
+	var syntaxErr *querylang.SyntaxError
+	switch {
+	case errors.As(err, &syntaxErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": syntaxErr.Error(), "position": syntaxErr.Pos})
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrRunNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrNoUser), service.IsSearchValidationError(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+func searchID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid saved search ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,58 @@
+package searches
+
+import (
+	"time"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/service"
+	"gorm.io/gorm"
+)
+
+// SavedSearch is a company search a user wants to follow. The worker re-runs
+// it and notifies the owner of companies that did not match before.
+type SavedSearch struct {
+	ID         uint                         `gorm:"primaryKey" json:"id"`
+	Name       string                       `gorm:"type:varchar(255);not null" json:"name"`
+	Owner      string                       `gorm:"type:varchar(255);not null;index" json:"owner"`
+	Search     service.CompanySearchRequest `gorm:"serializer:json" json:"search"`
+	Enabled    bool                         `gorm:"not null;index" json:"enabled"` // re-run by the worker
+	MatchCount int                          `json:"match_count"`                   // matches on the last run
+	LastRunAt  *time.Time                   `gorm:"index" json:"last_run_at"`
+	CreatedAt  time.Time                    `json:"created_at"`
+	UpdatedAt  time.Time                    `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt               `gorm:"index" json:"-"`
+}
+
+func (SavedSearch) TableName() string {
+	return "saved_searches"
+}
+
+// SearchRun is one evaluation of a saved search. NewCompanyIDs are the matches
+// never seen on an earlier run; the first run is a baseline and reports none.
+type SearchRun struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	SavedSearchID uint      `gorm:"not null;index" json:"saved_search_id"`
+	Matched       int       `json:"matched"`
+	Truncated     bool      `json:"truncated"` // more companies matched than are tracked
+	Baseline      bool      `json:"baseline"`
+	NewCount      int       `json:"new_count"`
+	NewCompanyIDs []uint    `gorm:"serializer:json" json:"new_company_ids"`
+	CreatedAt     time.Time `gorm:"index" json:"created_at"`
+}
+
+func (SearchRun) TableName() string {
+	return "saved_search_runs"
+}
+
+// SeenMatch records that a company has matched a saved search, so it is only
+// reported as new once even if it drops out and matches again.
+type SeenMatch struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	SavedSearchID uint      `gorm:"not null;uniqueIndex:idx_saved_search_matches_search_company" json:"saved_search_id"`
+	CompanyID     uint      `gorm:"not null;uniqueIndex:idx_saved_search_matches_search_company" json:"company_id"`
+	RunID         uint      `gorm:"not null" json:"run_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (SeenMatch) TableName() string {
+	return "saved_search_matches"
+}
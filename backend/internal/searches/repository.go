@@ -0,0 +1,145 @@
+package searches
+
+import (
+	"context"
+	"time"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(ctx context.Context, s *SavedSearch) error {
+	return r.db.WithContext(ctx).Create(s).Error
+}
+
+func (r *Repository) Get(ctx context.Context, id uint) (*SavedSearch, error) {
+	var s SavedSearch
+	if err := r.db.WithContext(ctx).First(&s, id).Error; err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *Repository) Save(ctx context.Context, s *SavedSearch) error {
+	return r.db.WithContext(ctx).Save(s).Error
+}
+
+func (r *Repository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&SavedSearch{}, id).Error
+}
+
+// ListByOwner returns a user's saved searches, newest first, and their total.
+func (r *Repository) ListByOwner(ctx context.Context, owner string, limit, offset int) ([]SavedSearch, int64, error) {
+	tx := r.db.WithContext(ctx).Model(&SavedSearch{}).Where("owner = ?", owner)
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var list []SavedSearch
+	if err := tx.Order("id DESC").Limit(limit).Offset(offset).Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+// Due returns up to limit enabled searches not run since before, never-run
+// and least recently run first.
+func (r *Repository) Due(ctx context.Context, before time.Time, limit int) ([]SavedSearch, error) {
+	var list []SavedSearch
+	if err := r.db.WithContext(ctx).
+		Where("enabled = ?", true).
+		Where("last_run_at IS NULL OR last_run_at <= ?", before).
+		Order("last_run_at IS NOT NULL, last_run_at ASC, id ASC").
+		Limit(limit).
+		Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// SeenCompanyIDs returns every company that has matched the search before.
+func (r *Repository) SeenCompanyIDs(ctx context.Context, searchID uint) ([]uint, error) {
+	var ids []uint
+	if err := r.db.WithContext(ctx).Model(&SeenMatch{}).
+		Where("saved_search_id = ?", searchID).
+		Pluck("company_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// SaveRun stores a run, marks companyIDs as seen and updates the search's last
+// run, all in one transaction.
+func (r *Repository) SaveRun(ctx context.Context, s *SavedSearch, run *SearchRun, companyIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(run).Error; err != nil {
+			return err
+		}
+
+		seen := make([]SeenMatch, len(companyIDs))
+		for i, companyID := range companyIDs {
+			seen[i] = SeenMatch{SavedSearchID: s.ID, CompanyID: companyID, RunID: run.ID}
+		}
+		if len(seen) > 0 {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(seen, 100).Error; err != nil {
+				return err
+			}
+		}
+
+		s.MatchCount = run.Matched
+		s.LastRunAt = &run.CreatedAt
+		// runs are not edits, so leave updated_at alone
+		return tx.Model(s).UpdateColumns(map[string]interface{}{
+			"match_count": s.MatchCount,
+			"last_run_at": s.LastRunAt,
+		}).Error
+	})
+}
+
+// Runs returns a search's runs, newest first, and their total.
+func (r *Repository) Runs(ctx context.Context, searchID uint, limit, offset int) ([]SearchRun, int64, error) {
+	tx := r.db.WithContext(ctx).Model(&SearchRun{}).Where("saved_search_id = ?", searchID)
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var runs []SearchRun
+	if err := tx.Order("id DESC").Limit(limit).Offset(offset).Find(&runs).Error; err != nil {
+		return nil, 0, err
+	}
+	return runs, total, nil
+}
+
+func (r *Repository) GetRun(ctx context.Context, searchID, runID uint) (*SearchRun, error) {
+	var run SearchRun
+	if err := r.db.WithContext(ctx).Where("saved_search_id = ?", searchID).First(&run, runID).Error; err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// Companies loads companies by ID, leaving out deleted ones.
+func (r *Repository) Companies(ctx context.Context, ids []uint) ([]model.Company, error) {
+	companies := []model.Company{}
+	if len(ids) == 0 {
+		return companies, nil
+	}
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Order("id ASC").Find(&companies).Error; err != nil {
+		return nil, err
+	}
+	return companies, nil
+}
@@ -0,0 +1,17 @@
+package searches
+
+import "github.com/gin-gonic/gin"
+
+func RegisterSavedSearchRoutes(rg *gin.RouterGroup, h *Handler) {
+	searches := rg.Group("/saved-searches")
+	{
+		searches.POST("", h.CreateSavedSearchHandler)
+		searches.GET("", h.ListSavedSearchesHandler)
+		searches.GET("/:id", h.GetSavedSearchHandler)
+		searches.PUT("/:id", h.UpdateSavedSearchHandler)
+		searches.DELETE("/:id", h.DeleteSavedSearchHandler)
+		searches.POST("/:id/run", h.RunSavedSearchHandler)
+		searches.GET("/:id/runs", h.ListRunsHandler)
+		searches.GET("/:id/runs/:run_id", h.GetRunHandler)
+	}
+}
@@ -0,0 +1,280 @@
+package searches
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/company/service"
+	"github.com/bhati00/Fynelo/backend/internal/notifications"
+	"github.com/bhati00/Fynelo/backend/pkg/pagination"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// RefreshAfter is how long the worker waits before re-running a search
+	RefreshAfter = time.Hour
+
+	// Searches evaluated per worker pass
+	dueBatchSize = 20
+
+	// Most matches tracked per run; broader searches are truncated
+	maxTrackedMatches = 5000
+)
+
+var (
+	ErrNotFound    = errors.New("saved search not found")
+	ErrRunNotFound = errors.New("run not found")
+	ErrNoUser      = errors.New("the X-Actor header must name the user")
+)
+
+// SavedSearchRequest creates or replaces a saved search. Search takes the same
+// filters as /companies/search; paging and facets are ignored.
+type SavedSearchRequest struct {
+	Name    string                       `json:"name" binding:"required"`
+	Search  service.CompanySearchRequest `json:"search"`
+	Enabled *bool                        `json:"enabled"` // default true
+}
+
+type ListRequest struct {
+	Limit  int `form:"limit"`
+	Offset int `form:"offset"`
+}
+
+type ListResponse struct {
+	SavedSearches []SavedSearch `json:"saved_searches"`
+	Total         int64         `json:"total"`
+	Limit         int           `json:"limit"`
+	Offset        int           `json:"offset"`
+}
+
+type RunsResponse struct {
+	Runs   []SearchRun `json:"runs"`
+	Total  int64       `json:"total"`
+	Limit  int         `json:"limit"`
+	Offset int         `json:"offset"`
+}
+
+// RunDetail is a run with the companies that were new on it.
+type RunDetail struct {
+	SearchRun
+	NewCompanies []model.Company `json:"new_companies"`
+}
+
+type Service struct {
+	repo      *Repository
+	companies service.CompanyService
+	notifier  *notifications.Service
+}
+
+func NewService(repo *Repository, companies service.CompanyService, notifier *notifications.Service) *Service {
+	return &Service{repo: repo, companies: companies, notifier: notifier}
+}
+
+func (s *Service) Create(ctx context.Context, user string, req SavedSearchRequest) (*SavedSearch, error) {
+	if user == "" {
+		return nil, ErrNoUser
+	}
+	saved := &SavedSearch{Owner: user}
+	if err := s.apply(saved, req); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, saved); err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+func (s *Service) Get(ctx context.Context, user string, id uint) (*SavedSearch, error) {
+	if user == "" {
+		return nil, ErrNoUser
+	}
+	saved, err := s.repo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if saved.Owner != user {
+		return nil, ErrNotFound
+	}
+	return saved, nil
+}
+
+func (s *Service) List(ctx context.Context, user string, req ListRequest) (*ListResponse, error) {
+	if user == "" {
+		return nil, ErrNoUser
+	}
+	req.Limit, req.Offset = pagination.Page(req.Limit, req.Offset)
+
+	list, total, err := s.repo.ListByOwner(ctx, user, req.Limit, req.Offset)
+	if err != nil {
+		return nil, err
+	}
+	return &ListResponse{SavedSearches: list, Total: total, Limit: req.Limit, Offset: req.Offset}, nil
+}
+
+// Update replaces a saved search. Companies already seen stay seen, so changing
+// the filters only reports companies that never matched before.
+func (s *Service) Update(ctx context.Context, user string, id uint, req SavedSearchRequest) (*SavedSearch, error) {
+	saved, err := s.Get(ctx, user, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(saved, req); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, saved); err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+func (s *Service) Delete(ctx context.Context, user string, id uint) error {
+	if _, err := s.Get(ctx, user, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// RunNow evaluates a saved search immediately, whether or not it is enabled.
+func (s *Service) RunNow(ctx context.Context, user string, id uint) (*RunDetail, error) {
+	saved, err := s.Get(ctx, user, id)
+	if err != nil {
+		return nil, err
+	}
+	run, err := s.run(ctx, saved)
+	if err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, run)
+}
+
+// RunDue evaluates enabled searches that have not run within RefreshAfter and
+// returns how many ran. A failing search is logged and retried on a later pass.
+func (s *Service) RunDue(ctx context.Context) (int, error) {
+	due, err := s.repo.Due(ctx, time.Now().Add(-RefreshAfter), dueBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	ran := 0
+	for i := range due {
+		if ctx.Err() != nil {
+			return ran, ctx.Err()
+		}
+		if _, err := s.run(ctx, &due[i]); err != nil {
+			log.Printf("Saved search %d: %v", due[i].ID, err)
+			continue
+		}
+		ran++
+	}
+	return ran, nil
+}
+
+func (s *Service) Runs(ctx context.Context, user string, id uint, req ListRequest) (*RunsResponse, error) {
+	if _, err := s.Get(ctx, user, id); err != nil {
+		return nil, err
+	}
+	req.Limit, req.Offset = pagination.Page(req.Limit, req.Offset)
+
+	runs, total, err := s.repo.Runs(ctx, id, req.Limit, req.Offset)
+	if err != nil {
+		return nil, err
+	}
+	return &RunsResponse{Runs: runs, Total: total, Limit: req.Limit, Offset: req.Offset}, nil
+}
+
+func (s *Service) GetRun(ctx context.Context, user string, id, runID uint) (*RunDetail, error) {
+	if _, err := s.Get(ctx, user, id); err != nil {
+		return nil, err
+	}
+	run, err := s.repo.GetRun(ctx, id, runID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRunNotFound
+		}
+		return nil, err
+	}
+	return s.detail(ctx, run)
+}
+
+// run evaluates a search, records the companies it has not matched before and
+// notifies the owner about them. The first run only records a baseline.
+func (s *Service) run(ctx context.Context, saved *SavedSearch) (*SearchRun, error) {
+	ids, err := s.companies.SearchCompanyIDs(ctx, saved.Search, maxTrackedMatches+1)
+	if err != nil {
+		return nil, err
+	}
+	run := &SearchRun{SavedSearchID: saved.ID, Baseline: saved.LastRunAt == nil, NewCompanyIDs: []uint{}}
+	if len(ids) > maxTrackedMatches {
+		ids, run.Truncated = ids[:maxTrackedMatches], true
+	}
+	run.Matched = len(ids)
+
+	seenIDs, err := s.repo.SeenCompanyIDs(ctx, saved.ID)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[uint]bool, len(seenIDs))
+	for _, id := range seenIDs {
+		seen[id] = true
+	}
+	var unseen []uint
+	for _, id := range ids {
+		if !seen[id] {
+			unseen = append(unseen, id)
+		}
+	}
+	if !run.Baseline {
+		run.NewCompanyIDs = append(run.NewCompanyIDs, unseen...)
+		run.NewCount = len(unseen)
+	}
+	run.CreatedAt = time.Now()
+
+	if err := s.repo.SaveRun(ctx, saved, run, unseen); err != nil {
+		return nil, err
+	}
+
+	if run.NewCount > 0 {
+		noun := "companies"
+		if run.NewCount == 1 {
+			noun = "company"
+		}
+		if err := s.notifier.Notify(ctx, &notifications.Notification{
+			Recipient: saved.Owner,
+			Kind:      notifications.KindSavedSearchMatches,
+			Title:     fmt.Sprintf("%d new %s matching %q", run.NewCount, noun, saved.Name),
+			Link:      fmt.Sprintf("/api/saved-searches/%d/runs/%d", saved.ID, run.ID),
+		}); err != nil {
+			// the run is recorded; the diff is still available from the API
+			log.Printf("Saved search %d: failed to notify %s: %v", saved.ID, saved.Owner, err)
+		}
+	}
+	return run, nil
+}
+
+func (s *Service) detail(ctx context.Context, run *SearchRun) (*RunDetail, error) {
+	companies, err := s.repo.Companies(ctx, run.NewCompanyIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &RunDetail{SearchRun: *run, NewCompanies: companies}, nil
+}
+
+func (s *Service) apply(saved *SavedSearch, req SavedSearchRequest) error {
+	search := req.Search
+	search.Limit, search.Offset, search.Facets = 0, 0, false
+	if err := s.companies.ValidateSearch(search); err != nil {
+		return err
+	}
+	saved.Name = strings.TrimSpace(req.Name)
+	saved.Search = search
+	saved.Enabled = req.Enabled == nil || *req.Enabled
+	return nil
+}
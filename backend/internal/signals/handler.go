@@ -0,0 +1,82 @@
+package signals
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/bhati00/Fynelo/backend/internal/icp"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// ListSignalsHandler godoc
+// @Summary Buying signal feed
+// @Description Lists detected buying signals (funding raised, headcount growth, technology added, location opened), newest first
+// @Tags Signals
+// @Accept json
+// @Produce json
+// @Param icp_id query int false "Only companies matching this ICP profile"
+// @Param type query string false "Comma-separated signal types (funding_raised, headcount_growth, technology_added, location_opened)"
+// @Param company_id query int false "Only this company"
+// @Param since query string false "Signals at or after this time (RFC3339 or YYYY-MM-DD)"
+// @Param limit query int false "Results limit (default: 20, max: 100)"
+// @Param offset query int false "Results offset (default: 0)"
+// @Success 200 {object} FeedResponse
+// @Failure 400 {object} map[string]interface{} "Invalid parameters, an ICP without company criteria, or ICP criteria that no longer resolve, listed per field"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /signals [get]
+func (h *Handler) ListSignalsHandler(c *gin.Context) {
+	var req FeedRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	feed, err := h.service.Feed(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "ICP not found"})
+			return
+		}
+		if errors.Is(err, ErrUnknownSignalType) || errors.Is(err, ErrInvalidSince) || errors.Is(err, icp.ErrNoCriteria) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var stale *icp.StaleCriteriaError
+		if errors.As(err, &stale) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ICP criteria no longer resolve", "fields": stale.Fields})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch signals"})
+		return
+	}
+
+	c.JSON(http.StatusOK, feed)
+}
+
+// DetectSignalsHandler godoc
+// @Summary Detect signals now
+// @Description Processes company changes recorded since the last run (this also runs periodically in the worker)
+// @Tags Signals
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string]int
+// @Failure 500 {object} map[string]string
+// @Router /signals/detect [post]
+func (h *Handler) DetectSignalsHandler(c *gin.Context) {
+	detected, err := h.service.Detect(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to detect signals"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"detected": detected})
+}
@@ -0,0 +1,46 @@
+package signals
+
+import (
+	"time"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+)
+
+type SignalType string
+
+const (
+	SignalFundingRaised   SignalType = "funding_raised"
+	SignalHeadcountGrowth SignalType = "headcount_growth"
+	SignalTechnologyAdded SignalType = "technology_added"
+	SignalLocationOpened  SignalType = "location_opened"
+)
+
+// Signal is a buying signal detected from a change to a company, e.g. a new
+// funding round. Each company change produces at most one signal.
+type Signal struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	CompanyID  uint       `gorm:"not null;index" json:"company_id"`
+	Type       SignalType `gorm:"type:varchar(30);not null;index" json:"type"`
+	Title      string     `gorm:"not null" json:"title"`
+	Details    string     `gorm:"type:text" json:"details"` // JSON with type-specific fields
+	ChangeID   uint       `gorm:"not null;uniqueIndex" json:"change_id"`
+	OccurredAt time.Time  `gorm:"not null;index" json:"occurred_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+
+	Company model.Company `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+}
+
+// DetectorCursor remembers the last company change the detector processed.
+type DetectorCursor struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	LastChangeID uint      `json:"last_change_id"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (Signal) TableName() string {
+	return "signals"
+}
+
+func (DetectorCursor) TableName() string {
+	return "signal_detector_cursors"
+}
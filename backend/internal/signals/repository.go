@@ -0,0 +1,117 @@
+package signals
+
+import (
+	"context"
+	"time"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/company/querylang"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FeedFilter narrows the signal feed. Zero values match everything.
+type FeedFilter struct {
+	Types      []SignalType
+	CompanyID  uint
+	Expression querylang.Node // only companies matching it, e.g. an ICP profile
+	Since      *time.Time
+	Limit      int
+	Offset     int
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// List returns signals newest first with their companies, plus the total
+// number of matching signals.
+func (r *Repository) List(ctx context.Context, filter FeedFilter) ([]Signal, int64, error) {
+	tx := r.db.WithContext(ctx).Model(&Signal{})
+	if len(filter.Types) > 0 {
+		tx = tx.Where("signals.type IN ?", filter.Types)
+	}
+	if filter.CompanyID != 0 {
+		tx = tx.Where("signals.company_id = ?", filter.CompanyID)
+	}
+	if filter.Since != nil {
+		tx = tx.Where("signals.occurred_at >= ?", *filter.Since)
+	}
+	if filter.Expression != nil {
+		sql, args := querylang.Compile(filter.Expression)
+		companies := r.db.Model(&model.Company{}).Select("companies.id").Where(sql, args...)
+		tx = tx.Where("signals.company_id IN (?)", companies)
+	}
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var list []Signal
+	if err := tx.Preload("Company").
+		Order("signals.occurred_at DESC, signals.id DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+// Cursor returns the detector position, creating it on first use.
+func (r *Repository) Cursor(ctx context.Context) (*DetectorCursor, error) {
+	var cursor DetectorCursor
+	if err := r.db.WithContext(ctx).FirstOrCreate(&cursor, DetectorCursor{ID: 1}).Error; err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+// ChangesAfter returns up to limit company changes with an ID above afterID, oldest first.
+func (r *Repository) ChangesAfter(ctx context.Context, afterID uint, limit int) ([]model.CompanyChange, error) {
+	var changes []model.CompanyChange
+	if err := r.db.WithContext(ctx).
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&changes).Error; err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// Companies loads companies by ID, including deleted ones.
+func (r *Repository) Companies(ctx context.Context, ids []uint) (map[uint]model.Company, error) {
+	var list []model.Company
+	if err := r.db.WithContext(ctx).Unscoped().Where("id IN ?", ids).Find(&list).Error; err != nil {
+		return nil, err
+	}
+	companies := make(map[uint]model.Company, len(list))
+	for _, c := range list {
+		companies[c.ID] = c
+	}
+	return companies, nil
+}
+
+// SaveBatch stores detected signals and advances the cursor in one transaction.
+// Signals for changes that were already processed are skipped.
+func (r *Repository) SaveBatch(ctx context.Context, signals []Signal, lastChangeID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(signals) > 0 {
+			if err := tx.Omit("Company").Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "change_id"}},
+				DoNothing: true,
+			}).Create(&signals).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&DetectorCursor{}).Where("id = ?", 1).
+			Update("last_change_id", lastChangeID).Error
+	})
+}
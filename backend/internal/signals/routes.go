@@ -0,0 +1,11 @@
+package signals
+
+import "github.com/gin-gonic/gin"
+
+func RegisterSignalRoutes(rg *gin.RouterGroup, h *Handler) {
+	signals := rg.Group("/signals")
+	{
+		signals.GET("", h.ListSignalsHandler)
+		signals.POST("/detect", h.DetectSignalsHandler)
+	}
+}
@@ -0,0 +1,252 @@
+package signals
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/icp"
+	"github.com/bhati00/Fynelo/backend/internal/taxonomy"
+	"github.com/bhati00/Fynelo/backend/pkg/pagination"
+)
+
+// Child rows recorded within this window of a company's creation are its
+// initial load (e.g. the technologies of an import), not signals.
+const initialLoadWindow = 10 * time.Minute
+
+// Number of company changes processed per detector batch
+const detectBatchSize = 500
+
+var (
+	ErrUnknownSignalType = errors.New("type must be one of: funding_raised, headcount_growth, technology_added, location_opened")
+	ErrInvalidSince      = errors.New("since must be RFC3339 or YYYY-MM-DD")
+)
+
+var signalTypes = map[SignalType]struct{}{
+	SignalFundingRaised:   {},
+	SignalHeadcountGrowth: {},
+	SignalTechnologyAdded: {},
+	SignalLocationOpened:  {},
+}
+
+var roundLabels = map[model.FundingRoundType]string{
+	model.RoundSeed:    "a seed round",
+	model.RoundSeriesA: "a Series A",
+	model.RoundSeriesB: "a Series B",
+	model.RoundSeriesC: "a Series C",
+	model.RoundSeriesD: "a Series D",
+	model.RoundIPO:     "an IPO",
+}
+
+type FeedRequest struct {
+	ICPID     uint   `form:"icp_id"`     // only companies matching this ICP profile
+	Type      string `form:"type"`       // comma-separated signal types
+	CompanyID uint   `form:"company_id"` // only this company
+	Since     string `form:"since"`      // RFC3339 or YYYY-MM-DD
+	Limit     int    `form:"limit"`
+	Offset    int    `form:"offset"`
+}
+
+type FeedResponse struct {
+	Signals []Signal `json:"signals"`
+	Total   int64    `json:"total"`
+	Limit   int      `json:"limit"`
+	Offset  int      `json:"offset"`
+}
+
+type Service struct {
+	repo    *Repository
+	icpRepo *icp.Repository
+}
+
+func NewService(repo *Repository, icpRepo *icp.Repository) *Service {
+	return &Service{repo: repo, icpRepo: icpRepo}
+}
+
+// Feed returns detected signals, newest first.
+func (s *Service) Feed(ctx context.Context, req FeedRequest) (*FeedResponse, error) {
+	req.Limit, req.Offset = pagination.Page(req.Limit, req.Offset)
+
+	filter := FeedFilter{CompanyID: req.CompanyID, Limit: req.Limit, Offset: req.Offset}
+	for _, t := range strings.Split(req.Type, ",") {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		if _, ok := signalTypes[SignalType(t)]; !ok {
+			return nil, ErrUnknownSignalType
+		}
+		filter.Types = append(filter.Types, SignalType(t))
+	}
+	if req.Since != "" {
+		since, err := time.Parse(time.RFC3339, req.Since)
+		if err != nil {
+			if since, err = time.Parse("2006-01-02", req.Since); err != nil {
+				return nil, ErrInvalidSince
+			}
+		}
+		filter.Since = &since
+	}
+	if req.ICPID != 0 {
+		profile, err := s.icpRepo.GetICPByID(req.ICPID)
+		if err != nil {
+			return nil, err
+		}
+		if filter.Expression, err = profile.CompanyExpression(); err != nil {
+			return nil, err
+		}
+	}
+
+	list, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &FeedResponse{Signals: list, Total: total, Limit: req.Limit, Offset: req.Offset}, nil
+}
+
+// Detect turns company changes recorded since the last run into signals and
+// returns how many were created. It is safe to run concurrently with writes
+// and to re-run: each change is considered once.
+func (s *Service) Detect(ctx context.Context) (int, error) {
+	cursor, err := s.repo.Cursor(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	detected := 0
+	last := cursor.LastChangeID
+	for {
+		changes, err := s.repo.ChangesAfter(ctx, last, detectBatchSize)
+		if err != nil {
+			return detected, err
+		}
+		if len(changes) == 0 {
+			return detected, nil
+		}
+
+		ids := make([]uint, 0, len(changes))
+		for _, c := range changes {
+			ids = append(ids, c.CompanyID)
+		}
+		companies, err := s.repo.Companies(ctx, ids)
+		if err != nil {
+			return detected, err
+		}
+
+		var batch []Signal
+		for _, change := range changes {
+			company, ok := companies[change.CompanyID]
+			if !ok {
+				continue
+			}
+			if signal := detect(change, company); signal != nil {
+				batch = append(batch, *signal)
+			}
+		}
+
+		last = changes[len(changes)-1].ID
+		if err := s.repo.SaveBatch(ctx, batch, last); err != nil {
+			return detected, err
+		}
+		detected += len(batch)
+	}
+}
+
+// detect classifies a single change, returning nil when it is not a signal.
+func detect(change model.CompanyChange, company model.Company) *Signal {
+	if change.EntityType != model.EntityCompany && change.Operation == model.ChangeCreate &&
+		change.ChangedAt.Sub(company.CreatedAt) < initialLoadWindow {
+		return nil
+	}
+
+	signal := &Signal{CompanyID: change.CompanyID, ChangeID: change.ID, OccurredAt: change.ChangedAt}
+	var details map[string]interface{}
+
+	switch {
+	case change.EntityType == model.EntityFundingRound && change.Operation == model.ChangeCreate:
+		row := decodeRow(change.NewValue)
+		roundType := model.FundingRoundType(stringValue(row["round_type"]))
+		label, ok := roundLabels[roundType]
+		if !ok {
+			return nil // acquisitions are not raises
+		}
+		signal.Type = SignalFundingRaised
+		signal.Title = fmt.Sprintf("%s raised %s", company.Name, label)
+		if amount, ok := row["amount"].(float64); ok {
+			signal.Title += fmt.Sprintf(" (%s %s)", stringValue(row["currency"]), strconv.FormatFloat(amount, 'f', -1, 64))
+		}
+		details = map[string]interface{}{"round_type": roundType, "amount": row["amount"], "currency": row["currency"], "date": row["date"]}
+
+	case change.EntityType == model.EntityCompany && change.Operation == model.ChangeUpdate &&
+		change.Field == "employee_size_id":
+		from, to := intValue(change.OldValue), intValue(change.NewValue)
+		if from == 0 || to <= from {
+			return nil
+		}
+		signal.Type = SignalHeadcountGrowth
+		signal.Title = fmt.Sprintf("%s grew from %s to %s employees", company.Name,
+			taxonomy.Default().Name(taxonomy.CompanySizes, from), taxonomy.Default().Name(taxonomy.CompanySizes, to))
+		details = map[string]interface{}{"from_size_id": from, "to_size_id": to}
+
+	case change.EntityType == model.EntityTechnology && change.Operation == model.ChangeCreate:
+		name := stringValue(decodeRow(change.NewValue)["technology_name"])
+		signal.Type = SignalTechnologyAdded
+		signal.Title = fmt.Sprintf("%s added %s", company.Name, name)
+		details = map[string]interface{}{"technology": name}
+
+	case change.EntityType == model.EntityLocation && change.Operation == model.ChangeCreate:
+		row := decodeRow(change.NewValue)
+		place := joinNonEmpty(stringValue(row["city"]), stringValue(row["state"]), stringValue(row["country"]))
+		signal.Type = SignalLocationOpened
+		signal.Title = fmt.Sprintf("%s opened a location", company.Name)
+		if place != "" {
+			signal.Title += " in " + place
+		}
+		details = map[string]interface{}{
+			"city": row["city"], "state": row["state"], "country": row["country"], "country_code": row["country_code"],
+		}
+
+	default:
+		return nil
+	}
+
+	data, _ := json.Marshal(details)
+	signal.Details = string(data)
+	return signal
+}
+
+func decodeRow(value *string) map[string]interface{} {
+	row := map[string]interface{}{}
+	if value != nil {
+		json.Unmarshal([]byte(*value), &row)
+	}
+	return row
+}
+
+func stringValue(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+func intValue(value *string) int {
+	if value == nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(*value)
+	return n
+}
+
+func joinNonEmpty(parts ...string) string {
+	var out []string
+	for _, p := range parts {
+		if p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, ", ")
+}
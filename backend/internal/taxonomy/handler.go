@@ -0,0 +1,185 @@
+package taxonomy
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// ListTaxonomiesHandler godoc
+// @Summary List all taxonomies
+// @Description Returns the industries, company sizes, business types and buyer roles companies, contacts and ICPs are classified with, each in display order. Parent IDs place a term under another one of the same taxonomy.
+// @Tags Taxonomies
+// @Accept json
+// @Produce json
+// @Success 200 {object} TaxonomiesResponse
+// @Router /taxonomies [get]
+func (h *Handler) ListTaxonomiesHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, h.service.Taxonomies())
+}
+
+// ListTermsHandler godoc
+// @Summary List one taxonomy
+// @Description Returns the terms of a taxonomy in display order
+// @Tags Taxonomies
+// @Accept json
+// @Produce json
+// @Param kind path string true "Taxonomy" Enums(industries, company_sizes, business_types, buyer_roles)
+// @Success 200 {array} Term
+// @Failure 400 {object} map[string]string
+// @Router /taxonomies/{kind} [get]
+func (h *Handler) ListTermsHandler(c *gin.Context) {
+	kind, ok := h.kind(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, h.service.Terms(kind))
+}
+
+// CreateTermHandler godoc
+// @Summary Add a term to a taxonomy
+// @Description Adds a term under the given ID or the next unused one. Names and aliases must be unique within the taxonomy, ignoring case.
+// @Tags Taxonomies
+// @Accept json
+// @Produce json
+// @Param kind path string true "Taxonomy" Enums(industries, company_sizes, business_types, buyer_roles)
+// @Param term body TermRequest true "Term"
+// @Success 201 {object} Term
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /taxonomies/{kind} [post]
+func (h *Handler) CreateTermHandler(c *gin.Context) {
+	kind, ok := h.kind(c)
+	if !ok {
+		return
+	}
+
+	var req TermRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	term, err := h.service.CreateTerm(c.Request.Context(), kind, req)
+	if err != nil {
+		h.writeError(c, err, "Failed to create term")
+		return
+	}
+
+	c.JSON(http.StatusCreated, term)
+}
+
+// UpdateTermHandler godoc
+// @Summary Update a taxonomy term
+// @Description Replaces a term's name, aliases and parent, and its position when given. The ID cannot change.
+// @Tags Taxonomies
+// @Accept json
+// @Produce json
+// @Param kind path string true "Taxonomy" Enums(industries, company_sizes, business_types, buyer_roles)
+// @Param id path int true "Term ID"
+// @Param term body TermRequest true "Term"
+// @Success 200 {object} Term
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /taxonomies/{kind}/{id} [put]
+func (h *Handler) UpdateTermHandler(c *gin.Context) {
+	kind, ok := h.kind(c)
+	if !ok {
+		return
+	}
+	id, ok := termID(c)
+	if !ok {
+		return
+	}
+
+	var req TermRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	term, err := h.service.UpdateTerm(c.Request.Context(), kind, id, req)
+	if err != nil {
+		h.writeError(c, err, "Failed to update term")
+		return
+	}
+
+	c.JSON(http.StatusOK, term)
+}
+
+// DeleteTermHandler godoc
+// @Summary Delete a taxonomy term
+// @Description Hides a term added through the API. Builtin terms and terms with children cannot be deleted, and the ID is never reused.
+// @Tags Taxonomies
+// @Accept json
+// @Produce json
+// @Param kind path string true "Taxonomy" Enums(industries, company_sizes, business_types, buyer_roles)
+// @Param id path int true "Term ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /taxonomies/{kind}/{id} [delete]
+func (h *Handler) DeleteTermHandler(c *gin.Context) {
+	kind, ok := h.kind(c)
+	if !ok {
+		return
+	}
+	id, ok := termID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteTerm(c.Request.Context(), kind, id); err != nil {
+		h.writeError(c, err, "Failed to delete term")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) writeError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, ErrTermNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrNameTaken), errors.Is(err, ErrIDTaken), errors.Is(err, ErrBuiltin), errors.Is(err, ErrHasChildren):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrInvalidName), errors.Is(err, ErrInvalidAliases), errors.Is(err, ErrInvalidParent):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+func (h *Handler) kind(c *gin.Context) (Kind, bool) {
+	kind, err := ParseKind(c.Param("kind"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return "", false
+	}
+	return kind, true
+}
+
+func termID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid term ID"})
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,17 @@
+package taxonomy
+
+import (
+	"log"
+
+	"github.com/bhati00/Fynelo/backend/pkg/database"
+)
+
+func Migrate() {
+	database.DB.AutoMigrate(&Term{})
+	if err := seed(database.DB); err != nil {
+		log.Printf("Taxonomy migration: failed to seed builtin terms: %v", err)
+	}
+	if err := Reload(database.DB); err != nil {
+		log.Printf("Taxonomy migration: failed to load terms, using the builtin ones: %v", err)
+	}
+}
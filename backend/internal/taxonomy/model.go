@@ -0,0 +1,46 @@
+// Package taxonomy holds the industries, company sizes, business types and
+// buyer roles that companies, contacts and ICPs are classified with. Terms
+// live in the taxonomy_terms table, seeded with the IDs of the constants
+// package so stored references keep their meaning, and are read through an
+// in-memory registry reloaded whenever they change.
+package taxonomy
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Kind names a taxonomy; it is also its key in API paths and responses.
+type Kind string
+
+const (
+	Industries    Kind = "industries"
+	CompanySizes  Kind = "company_sizes"
+	BusinessTypes Kind = "business_types"
+	BuyerRoles    Kind = "buyer_roles"
+)
+
+// Kinds lists the taxonomies in the order they are served.
+var Kinds = []Kind{Industries, CompanySizes, BusinessTypes, BuyerRoles}
+
+// Term is one value of a taxonomy. ID is what companies, contacts and ICPs
+// store and is never reused, which is why deleting a term only hides it;
+// Aliases are alternative names accepted on input, and ParentID places the
+// term under another of the same kind.
+type Term struct {
+	Kind      Kind           `gorm:"primaryKey;type:varchar(20)" json:"kind"`
+	ID        int            `gorm:"primaryKey;autoIncrement:false" json:"id"`
+	Name      string         `gorm:"type:varchar(100);not null" json:"name"`
+	ParentID  *int           `json:"parent_id,omitempty"`
+	Aliases   []string       `gorm:"serializer:json" json:"aliases"` // lowercased
+	Position  int            `gorm:"not null" json:"position"`       // display order
+	Builtin   bool           `gorm:"not null" json:"builtin"`        // seeded; cannot be deleted
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+func (Term) TableName() string {
+	return "taxonomy_terms"
+}
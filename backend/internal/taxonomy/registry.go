@@ -0,0 +1,119 @@
+package taxonomy
+
+import (
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+)
+
+// Registry is an immutable snapshot of the taxonomies, indexed by ID and by
+// lowercased name and alias.
+type Registry struct {
+	terms map[Kind][]Term
+	byID  map[Kind]map[int]Term
+	index map[Kind]map[string]int
+}
+
+var current atomic.Pointer[Registry]
+
+// Default returns the snapshot last loaded from the database, or one built
+// from the builtin terms before the first load.
+func Default() *Registry {
+	if r := current.Load(); r != nil {
+		return r
+	}
+	r := newRegistry(seedTerms())
+	current.CompareAndSwap(nil, r)
+	return current.Load()
+}
+
+// Reload replaces the default snapshot with the terms stored in db.
+func Reload(db *gorm.DB) error {
+	var terms []Term
+	if err := db.Find(&terms).Error; err != nil {
+		return err
+	}
+	current.Store(newRegistry(terms))
+	return nil
+}
+
+func newRegistry(terms []Term) *Registry {
+	r := &Registry{
+		terms: map[Kind][]Term{},
+		byID:  map[Kind]map[int]Term{},
+		index: map[Kind]map[string]int{},
+	}
+	for _, kind := range Kinds {
+		r.byID[kind] = map[int]Term{}
+		r.index[kind] = map[string]int{}
+	}
+	sort.SliceStable(terms, func(i, j int) bool {
+		if terms[i].Position != terms[j].Position {
+			return terms[i].Position < terms[j].Position
+		}
+		return terms[i].ID < terms[j].ID
+	})
+	for _, t := range terms {
+		if _, ok := r.byID[t.Kind]; !ok {
+			continue
+		}
+		r.terms[t.Kind] = append(r.terms[t.Kind], t)
+		r.byID[t.Kind][t.ID] = t
+		r.index[t.Kind][normalize(t.Name)] = t.ID
+	}
+	// aliases never shadow a term's name
+	for _, t := range terms {
+		for _, alias := range t.Aliases {
+			if _, taken := r.index[t.Kind][normalize(alias)]; !taken {
+				r.index[t.Kind][normalize(alias)] = t.ID
+			}
+		}
+	}
+	return r
+}
+
+// Terms returns the terms of a taxonomy in display order.
+func (r *Registry) Terms(kind Kind) []Term {
+	return append([]Term{}, r.terms[kind]...)
+}
+
+// Term returns the term with the given ID.
+func (r *Registry) Term(kind Kind, id int) (Term, bool) {
+	t, ok := r.byID[kind][id]
+	return t, ok
+}
+
+// Valid reports whether id is a term of the taxonomy.
+func (r *Registry) Valid(kind Kind, id int) bool {
+	_, ok := r.byID[kind][id]
+	return ok
+}
+
+// Name returns the name of a term, or "Unknown".
+func (r *Registry) Name(kind Kind, id int) string {
+	if t, ok := r.byID[kind][id]; ok {
+		return t.Name
+	}
+	return "Unknown"
+}
+
+// Names maps the IDs of a taxonomy to their names.
+func (r *Registry) Names(kind Kind) map[int]string {
+	names := make(map[int]string, len(r.byID[kind]))
+	for id, t := range r.byID[kind] {
+		names[id] = t.Name
+	}
+	return names
+}
+
+// Lookup resolves a name or alias, ignoring case and surrounding space.
+func (r *Registry) Lookup(kind Kind, name string) (int, bool) {
+	id, ok := r.index[kind][normalize(name)]
+	return id, ok
+}
+
+func normalize(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
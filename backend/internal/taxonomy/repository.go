@@ -0,0 +1,68 @@
+package taxonomy
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Terms returns the stored terms of a taxonomy.
+func (r *Repository) Terms(ctx context.Context, kind Kind) ([]Term, error) {
+	var terms []Term
+	err := r.db.WithContext(ctx).Where("kind = ?", kind).Order("position, id").Find(&terms).Error
+	return terms, err
+}
+
+func (r *Repository) Get(ctx context.Context, kind Kind, id int) (*Term, error) {
+	var term Term
+	if err := r.db.WithContext(ctx).Where("kind = ? AND id = ?", kind, id).First(&term).Error; err != nil {
+		return nil, err
+	}
+	return &term, nil
+}
+
+// IDTaken reports whether id is or was used by a term of the taxonomy.
+func (r *Repository) IDTaken(ctx context.Context, kind Kind, id int) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Unscoped().Model(&Term{}).Where("kind = ? AND id = ?", kind, id).Count(&count).Error
+	return count > 0, err
+}
+
+// NextID returns the ID after the highest one ever used by the taxonomy.
+func (r *Repository) NextID(ctx context.Context, kind Kind) (int, error) {
+	var max int
+	err := r.db.WithContext(ctx).Unscoped().Model(&Term{}).Where("kind = ?", kind).Select("COALESCE(MAX(id), 0)").Scan(&max).Error
+	return max + 1, err
+}
+
+func (r *Repository) Create(ctx context.Context, term *Term) error {
+	return r.db.WithContext(ctx).Create(term).Error
+}
+
+func (r *Repository) Save(ctx context.Context, term *Term) error {
+	return r.db.WithContext(ctx).Save(term).Error
+}
+
+func (r *Repository) Delete(ctx context.Context, kind Kind, id int) error {
+	return r.db.WithContext(ctx).Where("kind = ? AND id = ?", kind, id).Delete(&Term{}).Error
+}
+
+// Reload refreshes the default registry from the stored terms.
+func (r *Repository) Reload() error {
+	return Reload(r.db)
+}
+
+// seed inserts the builtin terms that are not stored yet, leaving edited
+// ones alone.
+func seed(db *gorm.DB) error {
+	return db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(seedTerms(), 100).Error
+}
@@ -0,0 +1,14 @@
+package taxonomy
+
+import "github.com/gin-gonic/gin"
+
+func RegisterTaxonomyRoutes(rg *gin.RouterGroup, h *Handler) {
+	taxonomies := rg.Group("/taxonomies")
+	{
+		taxonomies.GET("", h.ListTaxonomiesHandler)
+		taxonomies.GET("/:kind", h.ListTermsHandler)
+		taxonomies.POST("/:kind", h.CreateTermHandler)
+		taxonomies.PUT("/:kind/:id", h.UpdateTermHandler)
+		taxonomies.DELETE("/:kind/:id", h.DeleteTermHandler)
+	}
+}
@@ -0,0 +1,83 @@
+package taxonomy
+
+import "github.com/bhati00/Fynelo/backend/internal/constants"
+
+// builtinTerms are the terms the taxonomy_terms table is seeded with, under
+// the IDs declared in constants. Terms added later get their own IDs.
+var builtinTerms = []Term{
+	{Kind: BusinessTypes, ID: constants.BusinessTypeB2BSaaS, Name: "B2B SaaS", Aliases: []string{"saas"}},
+	{Kind: BusinessTypes, ID: constants.BusinessTypeECommerce, Name: "E-commerce", Aliases: []string{"ecommerce"}},
+	{Kind: BusinessTypes, ID: constants.BusinessTypeConsulting, Name: "Consulting"},
+	{Kind: BusinessTypes, ID: constants.BusinessTypeManufacturing, Name: "Manufacturing"},
+	{Kind: BusinessTypes, ID: constants.BusinessTypeHealthcare, Name: "Healthcare"},
+	{Kind: BusinessTypes, ID: constants.BusinessTypeEducation, Name: "Education"},
+	{Kind: BusinessTypes, ID: constants.BusinessTypeFinancial, Name: "Financial Services", Aliases: []string{"financial", "finance"}},
+	{Kind: BusinessTypes, ID: constants.BusinessTypeRealEstate, Name: "Real Estate", Aliases: []string{"realestate"}},
+	{Kind: BusinessTypes, ID: constants.BusinessTypeOther, Name: "Other"},
+
+	{Kind: Industries, ID: constants.IndustryTechnology, Name: "Technology"},
+	{Kind: Industries, ID: constants.IndustryFintech, Name: "Fintech"},
+	{Kind: Industries, ID: constants.IndustryHealthcare, Name: "Healthcare"},
+	{Kind: Industries, ID: constants.IndustryEducation, Name: "Education"},
+	{Kind: Industries, ID: constants.IndustryECommerce, Name: "E-commerce", Aliases: []string{"ecommerce"}, ParentID: parent(constants.IndustryRetail)},
+	{Kind: Industries, ID: constants.IndustryManufacturing, Name: "Manufacturing"},
+	{Kind: Industries, ID: constants.IndustryRealEstate, Name: "Real Estate", Aliases: []string{"realestate"}},
+	{Kind: Industries, ID: constants.IndustryConsulting, Name: "Consulting"},
+	{Kind: Industries, ID: constants.IndustryMarketing, Name: "Marketing"},
+	{Kind: Industries, ID: constants.IndustryRetail, Name: "Retail"},
+	{Kind: Industries, ID: constants.IndustryLogistics, Name: "Logistics"},
+	{Kind: Industries, ID: constants.IndustryEnergy, Name: "Energy"},
+	{Kind: Industries, ID: constants.IndustryMedia, Name: "Media"},
+	{Kind: Industries, ID: constants.IndustryGaming, Name: "Gaming", ParentID: parent(constants.IndustryMedia)},
+	{Kind: Industries, ID: constants.IndustryAutomotive, Name: "Automotive"},
+	{Kind: Industries, ID: constants.IndustryAgriculture, Name: "Agriculture"},
+	{Kind: Industries, ID: constants.IndustryAerospace, Name: "Aerospace"},
+	{Kind: Industries, ID: constants.IndustryTelecom, Name: "Telecom"},
+	{Kind: Industries, ID: constants.IndustryBiotechnology, Name: "Biotechnology", ParentID: parent(constants.IndustryHealthcare)},
+	{Kind: Industries, ID: constants.IndustryCybersecurity, Name: "Cybersecurity", ParentID: parent(constants.IndustryTechnology)},
+	{Kind: Industries, ID: constants.IndustryAI, Name: "Artificial Intelligence", Aliases: []string{"ai"}, ParentID: parent(constants.IndustryTechnology)},
+	{Kind: Industries, ID: constants.IndustryBlockchain, Name: "Blockchain", ParentID: parent(constants.IndustryTechnology)},
+	{Kind: Industries, ID: constants.IndustryCloudComputing, Name: "Cloud Computing", Aliases: []string{"cloud"}, ParentID: parent(constants.IndustryTechnology)},
+	{Kind: Industries, ID: constants.IndustryInsurance, Name: "Insurance"},
+	{Kind: Industries, ID: constants.IndustryBanking, Name: "Banking"},
+	{Kind: Industries, ID: constants.IndustryFoodBeverage, Name: "Food & Beverage", Aliases: []string{"food"}},
+	{Kind: Industries, ID: constants.IndustryTravel, Name: "Travel"},
+	{Kind: Industries, ID: constants.IndustryFashion, Name: "Fashion"},
+	{Kind: Industries, ID: constants.IndustryOther, Name: "Other"},
+
+	{Kind: CompanySizes, ID: constants.CompanySize1To10, Name: "1-10"},
+	{Kind: CompanySizes, ID: constants.CompanySize11To50, Name: "11-50"},
+	{Kind: CompanySizes, ID: constants.CompanySize51To200, Name: "51-200"},
+	{Kind: CompanySizes, ID: constants.CompanySize201To500, Name: "201-500"},
+	{Kind: CompanySizes, ID: constants.CompanySize501To1000, Name: "501-1000"},
+	{Kind: CompanySizes, ID: constants.CompanySize1000Plus, Name: "1000+"},
+
+	{Kind: BuyerRoles, ID: constants.BuyerRoleCEOFounder, Name: "CEO/Founder", Aliases: []string{"ceo", "founder", "co-founder"}},
+	{Kind: BuyerRoles, ID: constants.BuyerRoleCTOVPEngineering, Name: "CTO/VP Engineering", Aliases: []string{"cto", "vp engineering"}},
+	{Kind: BuyerRoles, ID: constants.BuyerRoleMarketingDirector, Name: "Marketing Director", Aliases: []string{"cmo"}},
+	{Kind: BuyerRoles, ID: constants.BuyerRoleSalesDirector, Name: "Sales Director", Aliases: []string{"vp sales"}},
+	{Kind: BuyerRoles, ID: constants.BuyerRoleOperationsManager, Name: "Operations Manager", Aliases: []string{"coo"}},
+	{Kind: BuyerRoles, ID: constants.BuyerRoleHRDirector, Name: "HR Director", Aliases: []string{"chro"}},
+	{Kind: BuyerRoles, ID: constants.BuyerRoleFinanceDirector, Name: "Finance Director", Aliases: []string{"cfo"}},
+	{Kind: BuyerRoles, ID: constants.BuyerRoleProductManager, Name: "Product Manager"},
+	{Kind: BuyerRoles, ID: constants.BuyerRoleITManager, Name: "IT Manager", Aliases: []string{"cio"}},
+	{Kind: BuyerRoles, ID: constants.BuyerRoleOther, Name: "Other"},
+}
+
+// seedTerms returns the builtin terms ready to insert, positioned by ID.
+func seedTerms() []Term {
+	terms := make([]Term, len(builtinTerms))
+	for i, t := range builtinTerms {
+		t.Position = t.ID
+		t.Builtin = true
+		if t.Aliases == nil {
+			t.Aliases = []string{}
+		}
+		terms[i] = t
+	}
+	return terms
+}
+
+func parent(id int) *int {
+	return &id
+}
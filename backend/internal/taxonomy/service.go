@@ -0,0 +1,227 @@
+package taxonomy
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+const (
+	maxNameLength = 100
+	maxAliases    = 20
+)
+
+var (
+	ErrUnknownKind    = errors.New("taxonomy must be one of: industries, company_sizes, business_types, buyer_roles")
+	ErrTermNotFound   = errors.New("term not found")
+	ErrInvalidName    = errors.New("name is required and must be at most 100 characters")
+	ErrInvalidAliases = errors.New("a term can have at most 20 aliases of at most 100 characters each")
+	ErrNameTaken      = errors.New("name or alias is already used by another term of this taxonomy")
+	ErrInvalidParent  = errors.New("parent must be another term of the same taxonomy and not one of its descendants")
+	ErrIDTaken        = errors.New("id must be positive and not used by another term of this taxonomy, including deleted ones")
+	ErrBuiltin        = errors.New("builtin terms cannot be deleted")
+	ErrHasChildren    = errors.New("terms with children cannot be deleted; move or delete the children first")
+)
+
+// TermRequest creates or replaces a term. ID is only read on create and
+// defaults to the next unused ID; Position defaults to after the last term
+// on create and to the current position on update.
+type TermRequest struct {
+	ID       *int     `json:"id"`
+	Name     string   `json:"name" binding:"required"`
+	ParentID *int     `json:"parent_id"`
+	Aliases  []string `json:"aliases"`
+	Position *int     `json:"position"`
+}
+
+// TaxonomiesResponse holds every taxonomy, each in display order.
+type TaxonomiesResponse struct {
+	Industries    []Term `json:"industries"`
+	CompanySizes  []Term `json:"company_sizes"`
+	BusinessTypes []Term `json:"business_types"`
+	BuyerRoles    []Term `json:"buyer_roles"`
+}
+
+type Service struct {
+	repo *Repository
+}
+
+func NewService(repo *Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// ParseKind validates a taxonomy name from a request.
+func ParseKind(value string) (Kind, error) {
+	for _, kind := range Kinds {
+		if string(kind) == value {
+			return kind, nil
+		}
+	}
+	return "", ErrUnknownKind
+}
+
+func (s *Service) Taxonomies() TaxonomiesResponse {
+	r := Default()
+	return TaxonomiesResponse{
+		Industries:    r.Terms(Industries),
+		CompanySizes:  r.Terms(CompanySizes),
+		BusinessTypes: r.Terms(BusinessTypes),
+		BuyerRoles:    r.Terms(BuyerRoles),
+	}
+}
+
+func (s *Service) Terms(kind Kind) []Term {
+	return Default().Terms(kind)
+}
+
+func (s *Service) CreateTerm(ctx context.Context, kind Kind, req TermRequest) (*Term, error) {
+	terms, err := s.repo.Terms(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	term := &Term{Kind: kind}
+	if req.ID != nil {
+		taken, err := s.repo.IDTaken(ctx, kind, *req.ID)
+		if err != nil {
+			return nil, err
+		}
+		if *req.ID <= 0 || taken {
+			return nil, ErrIDTaken
+		}
+		term.ID = *req.ID
+	} else if term.ID, err = s.repo.NextID(ctx, kind); err != nil {
+		return nil, err
+	}
+	for _, t := range terms {
+		if t.Position >= term.Position {
+			term.Position = t.Position + 1
+		}
+	}
+
+	if err := apply(term, req, terms); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, term); err != nil {
+		return nil, err
+	}
+	return term, s.repo.Reload()
+}
+
+func (s *Service) UpdateTerm(ctx context.Context, kind Kind, id int, req TermRequest) (*Term, error) {
+	term, err := s.get(ctx, kind, id)
+	if err != nil {
+		return nil, err
+	}
+	terms, err := s.repo.Terms(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := apply(term, req, terms); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, term); err != nil {
+		return nil, err
+	}
+	return term, s.repo.Reload()
+}
+
+// DeleteTerm hides a term added through the API. Companies, contacts and
+// ICPs that reference it keep its ID.
+func (s *Service) DeleteTerm(ctx context.Context, kind Kind, id int) error {
+	term, err := s.get(ctx, kind, id)
+	if err != nil {
+		return err
+	}
+	if term.Builtin {
+		return ErrBuiltin
+	}
+	terms, err := s.repo.Terms(ctx, kind)
+	if err != nil {
+		return err
+	}
+	for _, t := range terms {
+		if t.ParentID != nil && *t.ParentID == id {
+			return ErrHasChildren
+		}
+	}
+
+	if err := s.repo.Delete(ctx, kind, id); err != nil {
+		return err
+	}
+	return s.repo.Reload()
+}
+
+func (s *Service) get(ctx context.Context, kind Kind, id int) (*Term, error) {
+	term, err := s.repo.Get(ctx, kind, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrTermNotFound
+	}
+	return term, err
+}
+
+// apply validates req against the other terms of the taxonomy and copies it
+// onto term.
+func apply(term *Term, req TermRequest, terms []Term) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return ErrInvalidName
+	}
+
+	aliases := []string{}
+	seen := map[string]bool{normalize(name): true}
+	for _, alias := range req.Aliases {
+		alias = normalize(alias)
+		if alias == "" || seen[alias] {
+			continue
+		}
+		if utf8.RuneCountInString(alias) > maxNameLength {
+			return ErrInvalidAliases
+		}
+		seen[alias] = true
+		aliases = append(aliases, alias)
+	}
+	if len(aliases) > maxAliases {
+		return ErrInvalidAliases
+	}
+
+	parents := map[int]*int{}
+	for _, t := range terms {
+		if t.ID == term.ID {
+			continue
+		}
+		parents[t.ID] = t.ParentID
+		if seen[normalize(t.Name)] {
+			return ErrNameTaken
+		}
+		for _, alias := range t.Aliases {
+			if seen[normalize(alias)] {
+				return ErrNameTaken
+			}
+		}
+	}
+
+	if req.ParentID != nil {
+		// walk up from the parent; term itself is not in parents, so reaching
+		// it, which would close a cycle, fails like an unknown parent
+		steps := 0
+		for id := req.ParentID; id != nil; id = parents[*id] {
+			if _, ok := parents[*id]; !ok || steps > len(parents) {
+				return ErrInvalidParent
+			}
+			steps++
+		}
+	}
+
+	term.Name = name
+	term.Aliases = aliases
+	term.ParentID = req.ParentID
+	if req.Position != nil {
+		term.Position = *req.Position
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+// Package techcatalog normalizes the technology names companies report to a
+// catalog of canonical names with their aliases, category and vendor, so
+// "k8s", "Kubernetes" and "kubernetes" are one technology and companies can
+// be searched by what kind of tools they use. The catalog is bundled with the
+// binary; names it does not know are kept as reported.
+package techcatalog
+
+import (
+	"embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+//go:embed data/technologies.csv
+var dataFS embed.FS
+
+// Category is a kind of technology. Code is what is stored on company
+// technologies and accepted in filters, along with Name.
+type Category struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// Categories in the order they are listed
+var categories = []Category{
+	{Code: "crm", Name: "CRM"},
+	{Code: "marketing", Name: "Marketing Automation"},
+	{Code: "analytics", Name: "Analytics"},
+	{Code: "payments", Name: "Payments"},
+	{Code: "ecommerce", Name: "E-commerce"},
+	{Code: "support", Name: "Customer Support"},
+	{Code: "communication", Name: "Communication"},
+	{Code: "email", Name: "Email"},
+	{Code: "cloud", Name: "Cloud"},
+	{Code: "cdn", Name: "CDN"},
+	{Code: "database", Name: "Database"},
+	{Code: "data_warehouse", Name: "Data Warehouse"},
+	{Code: "devops", Name: "DevOps"},
+	{Code: "monitoring", Name: "Monitoring"},
+	{Code: "security", Name: "Security"},
+	{Code: "cms", Name: "CMS"},
+	{Code: "frontend", Name: "Frontend"},
+	{Code: "backend", Name: "Backend"},
+	{Code: "language", Name: "Programming Language"},
+}
+
+// Technology is a catalog entry.
+type Technology struct {
+	Name     string   `json:"name"`
+	Category string   `json:"category"`
+	Vendor   string   `json:"vendor,omitempty"`
+	Aliases  []string `json:"aliases"`
+}
+
+// Catalog indexes technologies by name and alias, and categories by code
+// and name.
+type Catalog struct {
+	technologies  []Technology
+	index         map[string]int    // key of name or alias -> index into technologies
+	categoryIndex map[string]string // key of code or name -> code
+}
+
+var (
+	defaultCatalog *Catalog
+	loadOnce       sync.Once
+)
+
+// Default returns the catalog built from the bundled data file.
+func Default() *Catalog {
+	loadOnce.Do(func() {
+		c, err := load()
+		if err != nil {
+			// the data is embedded at build time, so this is a programming error
+			panic(fmt.Sprintf("techcatalog: invalid bundled catalog: %v", err))
+		}
+		defaultCatalog = c
+	})
+	return defaultCatalog
+}
+
+func load() (*Catalog, error) {
+	c := &Catalog{index: map[string]int{}, categoryIndex: map[string]string{}}
+	for _, cat := range categories {
+		c.categoryIndex[Key(cat.Code)] = cat.Code
+		c.categoryIndex[Key(cat.Name)] = cat.Code
+	}
+
+	f, err := dataFS.Open("data/technologies.csv")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	if _, err := r.Read(); err != nil { // header
+		return nil, err
+	}
+	for line := 2; ; line++ {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rec) != 4 {
+			return nil, fmt.Errorf("line %d: want name,category,vendor,aliases", line)
+		}
+		category, ok := c.categoryIndex[Key(rec[1])]
+		if !ok {
+			return nil, fmt.Errorf("line %d: unknown category %q", line, rec[1])
+		}
+		t := Technology{Name: strings.TrimSpace(rec[0]), Category: category, Vendor: strings.TrimSpace(rec[2]), Aliases: []string{}}
+		if rec[3] != "" {
+			for _, alias := range strings.Split(rec[3], "|") {
+				t.Aliases = append(t.Aliases, strings.TrimSpace(alias))
+			}
+		}
+		for _, name := range append([]string{t.Name}, t.Aliases...) {
+			key := Key(name)
+			if key == "" {
+				return nil, fmt.Errorf("line %d: empty name or alias", line)
+			}
+			if i, taken := c.index[key]; taken && i != len(c.technologies) {
+				return nil, fmt.Errorf("line %d: %q is already a name or alias of %s", line, name, c.technologies[i].Name)
+			}
+			c.index[key] = len(c.technologies)
+		}
+		c.technologies = append(c.technologies, t)
+	}
+	return c, nil
+}
+
+// Key is the form names are matched in: lowercased, keeping only letters,
+// digits, '+' and '#', so "Node.js", "node js" and "NodeJS" match.
+func Key(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return unicode.ToLower(r)
+		case r == '+' || r == '#':
+			return r
+		}
+		return -1
+	}, name)
+}
+
+// Lookup resolves a technology name or alias.
+func (c *Catalog) Lookup(name string) (Technology, bool) {
+	i, ok := c.index[Key(name)]
+	if !ok {
+		return Technology{}, false
+	}
+	return c.technologies[i], true
+}
+
+// Canonical returns the catalog name of a technology, or the name as
+// reported with its spacing tidied when the catalog does not know it.
+func (c *Catalog) Canonical(name string) string {
+	if t, ok := c.Lookup(name); ok {
+		return t.Name
+	}
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// Category resolves a category code or name.
+func (c *Catalog) Category(name string) (Category, bool) {
+	code, ok := c.categoryIndex[Key(name)]
+	if !ok {
+		return Category{}, false
+	}
+	for _, cat := range categories {
+		if cat.Code == code {
+			return cat, true
+		}
+	}
+	return Category{}, false
+}
+
+// Categories lists the categories.
+func (c *Catalog) Categories() []Category {
+	return append([]Category{}, categories...)
+}
+
+// Technologies lists the technologies of a category code, or all of them
+// when category is empty, alphabetically.
+func (c *Catalog) Technologies(category string) []Technology {
+	out := []Technology{}
+	for _, t := range c.technologies {
+		if category == "" || t.Category == category {
+			out = append(out, t)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return strings.ToLower(out[i].Name) < strings.ToLower(out[j].Name) })
+	return out
+}
@@ -0,0 +1,250 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// CreateEndpointHandler godoc
+// @Summary Create a webhook endpoint
+// @Description Subscribes a URL to events (job.completed, job.failed, company.created, company.updated, signal.detected). Deliveries are POSTed as JSON with X-Fynelo-Event, X-Fynelo-Delivery, X-Fynelo-Timestamp and X-Fynelo-Signature headers; the signature is "sha256=" + hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the secret. The secret is generated when omitted and only returned by this call.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param endpoint body EndpointRequest true "Endpoint"
+// @Success 201 {object} Endpoint
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks [post]
+func (h *Handler) CreateEndpointHandler(c *gin.Context) {
+	var req EndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	endpoint, err := h.service.CreateEndpoint(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, ErrInvalidURL) || errors.Is(err, ErrUnknownEvent) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create webhook endpoint"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, endpoint)
+}
+
+// ListEndpointsHandler godoc
+// @Summary List webhook endpoints
+// @Description Lists all webhook endpoints (secrets are not returned)
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Success 200 {array} Endpoint
+// @Failure 500 {object} map[string]string
+// @Router /webhooks [get]
+func (h *Handler) ListEndpointsHandler(c *gin.Context) {
+	list, err := h.service.ListEndpoints(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch webhook endpoints"})
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GetEndpointHandler godoc
+// @Summary Get a webhook endpoint
+// @Description Fetches a webhook endpoint by ID (the secret is not returned)
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Endpoint ID"
+// @Success 200 {object} Endpoint
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /webhooks/{id} [get]
+func (h *Handler) GetEndpointHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	endpoint, err := h.service.GetEndpoint(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Webhook endpoint not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch webhook endpoint"})
+		return
+	}
+
+	c.JSON(http.StatusOK, endpoint)
+}
+
+// UpdateEndpointHandler godoc
+// @Summary Update a webhook endpoint
+// @Description Replaces an endpoint's URL, events, description and active flag. The secret is rotated only when a new one is given.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Endpoint ID"
+// @Param endpoint body EndpointRequest true "Endpoint"
+// @Success 200 {object} Endpoint
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks/{id} [put]
+func (h *Handler) UpdateEndpointHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	var req EndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	endpoint, err := h.service.UpdateEndpoint(c.Request.Context(), uint(id), req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Webhook endpoint not found"})
+			return
+		}
+		if errors.Is(err, ErrInvalidURL) || errors.Is(err, ErrUnknownEvent) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update webhook endpoint"})
+		return
+	}
+
+	c.JSON(http.StatusOK, endpoint)
+}
+
+// DeleteEndpointHandler godoc
+// @Summary Delete a webhook endpoint
+// @Description Deletes a webhook endpoint; its pending deliveries are marked failed
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Endpoint ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks/{id} [delete]
+func (h *Handler) DeleteEndpointHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	if err := h.service.DeleteEndpoint(c.Request.Context(), uint(id)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Webhook endpoint not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete webhook endpoint"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeliveriesHandler godoc
+// @Summary Webhook delivery log
+// @Description Lists an endpoint's deliveries newest first, with attempts, response status and errors
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Endpoint ID"
+// @Param status query string false "pending, succeeded or failed"
+// @Param event_type query string false "Event type"
+// @Param limit query int false "Results limit (default: 20, max: 100)"
+// @Param offset query int false "Results offset (default: 0)"
+// @Success 200 {object} DeliveryListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks/{id}/deliveries [get]
+func (h *Handler) ListDeliveriesHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	var req DeliveryListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	deliveries, err := h.service.ListDeliveries(c.Request.Context(), uint(id), req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Webhook endpoint not found"})
+			return
+		}
+		if errors.Is(err, ErrInvalidStatus) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deliveries"})
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// TestEndpointHandler godoc
+// @Summary Test-fire a webhook endpoint
+// @Description Sends a signed webhook.test event to the endpoint immediately and returns the logged delivery
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Endpoint ID"
+// @Success 200 {object} Delivery
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks/{id}/test [post]
+func (h *Handler) TestEndpointHandler(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	delivery, err := h.service.TestFire(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Webhook endpoint not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send test delivery"})
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
@@ -0,0 +1,9 @@
+package webhook
+
+import (
+	"github.com/bhati00/Fynelo/backend/pkg/database"
+)
+
+func Migrate() {
+	database.DB.AutoMigrate(&Endpoint{}, &Delivery{}, &RelayCursor{})
+}
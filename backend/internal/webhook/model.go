@@ -0,0 +1,89 @@
+package webhook
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type EventType string
+
+const (
+	EventJobCompleted   EventType = "job.completed"
+	EventJobFailed      EventType = "job.failed"
+	EventCompanyCreated EventType = "company.created"
+	EventCompanyUpdated EventType = "company.updated"
+	EventSignalDetected EventType = "signal.detected"
+	EventTest           EventType = "webhook.test" // sent by the test-fire endpoint only
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryFailed    DeliveryStatus = "failed" // attempts exhausted, or endpoint gone
+)
+
+// Endpoint is a URL subscribed to a set of event types. Deliveries are signed
+// with its secret.
+type Endpoint struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	URL         string         `gorm:"type:varchar(2048);not null" json:"url"`
+	Secret      string         `gorm:"type:varchar(255);not null" json:"secret,omitempty"` // only returned when the endpoint is created
+	Events      []EventType    `gorm:"type:text;serializer:json" json:"events"`
+	Description string         `gorm:"type:varchar(255)" json:"description"`
+	Active      bool           `gorm:"not null" json:"active"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// Subscribes reports whether the endpoint wants events of type t.
+func (e *Endpoint) Subscribes(t EventType) bool {
+	for _, event := range e.Events {
+		if event == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Delivery is one event sent, or to be sent, to one endpoint. It doubles as the
+// delivery log.
+type Delivery struct {
+	ID             uint           `gorm:"primaryKey" json:"id"`
+	EndpointID     uint           `gorm:"not null;index" json:"endpoint_id"`
+	EventID        string         `gorm:"type:varchar(40);not null;index" json:"event_id"`
+	EventType      EventType      `gorm:"type:varchar(40);not null" json:"event_type"`
+	Payload        string         `gorm:"type:text;not null" json:"payload"` // JSON body sent to the endpoint
+	Status         DeliveryStatus `gorm:"type:varchar(20);not null;index:idx_webhook_deliveries_due,priority:1" json:"status"`
+	Attempts       int            `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  *time.Time     `gorm:"index:idx_webhook_deliveries_due,priority:2" json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time     `json:"last_attempt_at,omitempty"`
+	ResponseStatus int            `json:"response_status,omitempty"`
+	ResponseBody   string         `gorm:"type:text" json:"response_body,omitempty"` // truncated
+	Error          string         `gorm:"type:text" json:"error,omitempty"`
+	DeliveredAt    *time.Time     `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// RelayCursor remembers the last row of a source table turned into events.
+type RelayCursor struct {
+	Name      string    `gorm:"primaryKey;type:varchar(40)" json:"name"`
+	LastID    uint      `json:"last_id"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (Endpoint) TableName() string {
+	return "webhook_endpoints"
+}
+
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+func (RelayCursor) TableName() string {
+	return "webhook_relay_cursors"
+}
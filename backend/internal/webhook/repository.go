@@ -0,0 +1,230 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/signals"
+
+	"gorm.io/gorm"
+)
+
+// Relay sources, also the names of their cursors
+const (
+	sourceCompanyChanges = "company_changes"
+	sourceSignals        = "signals"
+)
+
+// DeliveryFilter narrows an endpoint's delivery log. Zero values match everything.
+type DeliveryFilter struct {
+	Status    DeliveryStatus
+	EventType EventType
+	Limit     int
+	Offset    int
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) CreateEndpoint(ctx context.Context, endpoint *Endpoint) error {
+	return r.db.WithContext(ctx).Create(endpoint).Error
+}
+
+func (r *Repository) GetEndpoint(ctx context.Context, id uint) (*Endpoint, error) {
+	var endpoint Endpoint
+	if err := r.db.WithContext(ctx).First(&endpoint, id).Error; err != nil {
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+func (r *Repository) ListEndpoints(ctx context.Context) ([]Endpoint, error) {
+	var list []Endpoint
+	if err := r.db.WithContext(ctx).Order("id ASC").Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *Repository) UpdateEndpoint(ctx context.Context, endpoint *Endpoint) error {
+	return r.db.WithContext(ctx).Save(endpoint).Error
+}
+
+func (r *Repository) DeleteEndpoint(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&Endpoint{}, id).Error
+}
+
+// ActiveEndpoints returns the endpoints that receive events.
+func (r *Repository) ActiveEndpoints(ctx context.Context) ([]Endpoint, error) {
+	var list []Endpoint
+	if err := r.db.WithContext(ctx).Where("active = ?", true).Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// EndpointsByID loads endpoints including deleted ones, so deliveries queued
+// for them can be closed.
+func (r *Repository) EndpointsByID(ctx context.Context, ids []uint) (map[uint]Endpoint, error) {
+	var list []Endpoint
+	if err := r.db.WithContext(ctx).Unscoped().Where("id IN ?", ids).Find(&list).Error; err != nil {
+		return nil, err
+	}
+	endpoints := make(map[uint]Endpoint, len(list))
+	for _, e := range list {
+		endpoints[e.ID] = e
+	}
+	return endpoints, nil
+}
+
+func (r *Repository) CreateDeliveries(ctx context.Context, deliveries []Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).CreateInBatches(&deliveries, 200).Error
+}
+
+func (r *Repository) SaveDelivery(ctx context.Context, delivery *Delivery) error {
+	return r.db.WithContext(ctx).Save(delivery).Error
+}
+
+func (r *Repository) GetDelivery(ctx context.Context, endpointID, id uint) (*Delivery, error) {
+	var delivery Delivery
+	if err := r.db.WithContext(ctx).
+		Where("endpoint_id = ?", endpointID).
+		First(&delivery, id).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// ListDeliveries returns an endpoint's deliveries newest first, plus the total
+// number of matching deliveries.
+func (r *Repository) ListDeliveries(ctx context.Context, endpointID uint, filter DeliveryFilter) ([]Delivery, int64, error) {
+	tx := r.db.WithContext(ctx).Model(&Delivery{}).Where("endpoint_id = ?", endpointID)
+	if filter.Status != "" {
+		tx = tx.Where("status = ?", filter.Status)
+	}
+	if filter.EventType != "" {
+		tx = tx.Where("event_type = ?", filter.EventType)
+	}
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var list []Delivery
+	if err := tx.Order("id DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+// DueDeliveries returns up to limit pending deliveries whose next attempt is due, oldest first.
+func (r *Repository) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]Delivery, error) {
+	var list []Delivery
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", DeliveryPending, now).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(limit).
+		Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Claim pushes a due delivery's next attempt to until so that no other
+// dispatcher picks it up meanwhile. It reports false when another dispatcher
+// claimed it first.
+func (r *Repository) Claim(ctx context.Context, delivery *Delivery, now, until time.Time) (bool, error) {
+	res := r.db.WithContext(ctx).Model(&Delivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", delivery.ID, DeliveryPending, now).
+		Update("next_attempt_at", until)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	delivery.NextAttemptAt = &until
+	return res.RowsAffected == 1, nil
+}
+
+// Cursor returns the position of a relay source. A new cursor starts at the
+// newest row so that history is not replayed to subscribers.
+func (r *Repository) Cursor(ctx context.Context, name string) (*RelayCursor, error) {
+	var cursor RelayCursor
+	err := r.db.WithContext(ctx).First(&cursor, "name = ?", name).Error
+	if err == nil {
+		return &cursor, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	cursor.Name = name
+	if err := r.db.WithContext(ctx).Table(name).Select("COALESCE(MAX(id), 0)").Scan(&cursor.LastID).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.WithContext(ctx).Create(&cursor).Error; err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+// CompanyChangesAfter returns up to limit company changes with an ID above afterID, oldest first.
+func (r *Repository) CompanyChangesAfter(ctx context.Context, afterID uint, limit int) ([]model.CompanyChange, error) {
+	var changes []model.CompanyChange
+	if err := r.db.WithContext(ctx).
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&changes).Error; err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// SignalsAfter returns up to limit signals with an ID above afterID, oldest first.
+func (r *Repository) SignalsAfter(ctx context.Context, afterID uint, limit int) ([]signals.Signal, error) {
+	var list []signals.Signal
+	if err := r.db.WithContext(ctx).
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Companies loads companies by ID, including deleted ones.
+func (r *Repository) Companies(ctx context.Context, ids []uint) (map[uint]model.Company, error) {
+	var list []model.Company
+	if err := r.db.WithContext(ctx).Unscoped().Where("id IN ?", ids).Find(&list).Error; err != nil {
+		return nil, err
+	}
+	companies := make(map[uint]model.Company, len(list))
+	for _, c := range list {
+		companies[c.ID] = c
+	}
+	return companies, nil
+}
+
+// SaveRelayBatch queues the deliveries of relayed events and advances the
+// source cursor in one transaction.
+func (r *Repository) SaveRelayBatch(ctx context.Context, name string, deliveries []Delivery, lastID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(deliveries) > 0 {
+			if err := tx.CreateInBatches(&deliveries, 200).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&RelayCursor{}).Where("name = ?", name).Update("last_id", lastID).Error
+	})
+}
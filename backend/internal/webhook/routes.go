@@ -0,0 +1,16 @@
+package webhook
+
+import "github.com/gin-gonic/gin"
+
+func RegisterWebhookRoutes(rg *gin.RouterGroup, h *Handler) {
+	webhooks := rg.Group("/webhooks")
+	{
+		webhooks.POST("", h.CreateEndpointHandler)
+		webhooks.GET("", h.ListEndpointsHandler)
+		webhooks.GET("/:id", h.GetEndpointHandler)
+		webhooks.PUT("/:id", h.UpdateEndpointHandler)
+		webhooks.DELETE("/:id", h.DeleteEndpointHandler)
+		webhooks.GET("/:id/deliveries", h.ListDeliveriesHandler)
+		webhooks.POST("/:id/test", h.TestEndpointHandler)
+	}
+}
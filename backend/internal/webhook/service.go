@@ -0,0 +1,638 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/bhati00/Fynelo/backend/internal/company/model"
+	"github.com/bhati00/Fynelo/backend/internal/signals"
+	"github.com/bhati00/Fynelo/backend/pkg/pagination"
+)
+
+// Headers sent with every delivery. The signature is "sha256=" followed by the
+// hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the endpoint secret.
+const (
+	HeaderEvent     = "X-Fynelo-Event"
+	HeaderDelivery  = "X-Fynelo-Delivery"
+	HeaderTimestamp = "X-Fynelo-Timestamp"
+	HeaderSignature = "X-Fynelo-Signature"
+)
+
+const (
+	// Attempts per delivery before it is marked failed
+	maxAttempts = 8
+
+	// Retry backoff: 30s, 1m, 2m, ... capped at maxRetryDelay
+	baseRetryDelay = 30 * time.Second
+	maxRetryDelay  = 6 * time.Hour
+
+	// How long a claimed delivery is hidden from other dispatchers
+	claimTimeout = 2 * time.Minute
+
+	deliveryTimeout  = 10 * time.Second
+	maxResponseBody  = 1024
+	dispatchBatch    = 100
+	relayBatchSize   = 500
+	secretPrefix     = "whsec_"
+	secretRandomSize = 24
+)
+
+var (
+	ErrInvalidURL    = errors.New("url must be an absolute http or https URL")
+	ErrUnknownEvent  = errors.New("events must be among: job.completed, job.failed, company.created, company.updated, signal.detected")
+	ErrInvalidStatus = errors.New("status must be one of: pending, succeeded, failed")
+)
+
+// Event types endpoints can subscribe to
+var eventTypes = map[EventType]struct{}{
+	EventJobCompleted:   {},
+	EventJobFailed:      {},
+	EventCompanyCreated: {},
+	EventCompanyUpdated: {},
+	EventSignalDetected: {},
+}
+
+var deliveryStatuses = map[DeliveryStatus]struct{}{
+	DeliveryPending:   {},
+	DeliverySucceeded: {},
+	DeliveryFailed:    {},
+}
+
+// Doer sends HTTP requests. *http.Client implements it; tests can substitute a
+// client pointed at a local stand-in.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type EndpointRequest struct {
+	URL         string      `json:"url" binding:"required"`
+	Secret      string      `json:"secret"` // generated when empty on create, kept when empty on update
+	Events      []EventType `json:"events" binding:"required,min=1"`
+	Description string      `json:"description"`
+	Active      *bool       `json:"active"` // defaults to true
+}
+
+type DeliveryListRequest struct {
+	Status    string `form:"status"`
+	EventType string `form:"event_type"`
+	Limit     int    `form:"limit"`
+	Offset    int    `form:"offset"`
+}
+
+type DeliveryListResponse struct {
+	Deliveries []Delivery `json:"deliveries"`
+	Total      int64      `json:"total"`
+	Limit      int        `json:"limit"`
+	Offset     int        `json:"offset"`
+}
+
+// Event is the JSON body of every delivery.
+type Event struct {
+	ID        string      `json:"id"`
+	Type      EventType   `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+type Service struct {
+	repo   *Repository
+	client Doer
+}
+
+// NewService creates the webhook service. A nil client uses an *http.Client
+// with a short timeout.
+func NewService(repo *Repository, client Doer) *Service {
+	if client == nil {
+		client = &http.Client{Timeout: deliveryTimeout}
+	}
+	return &Service{repo: repo, client: client}
+}
+
+func (s *Service) CreateEndpoint(ctx context.Context, req EndpointRequest) (*Endpoint, error) {
+	endpoint := &Endpoint{Secret: req.Secret, Active: true}
+	if err := applyRequest(endpoint, req); err != nil {
+		return nil, err
+	}
+	if endpoint.Secret == "" {
+		secret, err := newSecret()
+		if err != nil {
+			return nil, err
+		}
+		endpoint.Secret = secret
+	}
+	if err := s.repo.CreateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+func (s *Service) GetEndpoint(ctx context.Context, id uint) (*Endpoint, error) {
+	endpoint, err := s.repo.GetEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Secret = ""
+	return endpoint, nil
+}
+
+func (s *Service) ListEndpoints(ctx context.Context) ([]Endpoint, error) {
+	list, err := s.repo.ListEndpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range list {
+		list[i].Secret = ""
+	}
+	return list, nil
+}
+
+func (s *Service) UpdateEndpoint(ctx context.Context, id uint, req EndpointRequest) (*Endpoint, error) {
+	endpoint, err := s.repo.GetEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyRequest(endpoint, req); err != nil {
+		return nil, err
+	}
+	if req.Secret != "" {
+		endpoint.Secret = req.Secret
+	}
+	if err := s.repo.UpdateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+	endpoint.Secret = ""
+	return endpoint, nil
+}
+
+// DeleteEndpoint removes an endpoint. Its pending deliveries are closed as
+// failed by the next dispatch.
+func (s *Service) DeleteEndpoint(ctx context.Context, id uint) error {
+	if _, err := s.repo.GetEndpoint(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteEndpoint(ctx, id)
+}
+
+// ListDeliveries returns an endpoint's delivery log, newest first.
+func (s *Service) ListDeliveries(ctx context.Context, endpointID uint, req DeliveryListRequest) (*DeliveryListResponse, error) {
+	if _, err := s.repo.GetEndpoint(ctx, endpointID); err != nil {
+		return nil, err
+	}
+	if req.Status != "" {
+		if _, ok := deliveryStatuses[DeliveryStatus(req.Status)]; !ok {
+			return nil, ErrInvalidStatus
+		}
+	}
+	req.Limit, req.Offset = pagination.Page(req.Limit, req.Offset)
+
+	list, total, err := s.repo.ListDeliveries(ctx, endpointID, DeliveryFilter{
+		Status:    DeliveryStatus(req.Status),
+		EventType: EventType(req.EventType),
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &DeliveryListResponse{Deliveries: list, Total: total, Limit: req.Limit, Offset: req.Offset}, nil
+}
+
+// Publish queues an event for every active endpoint subscribed to its type.
+// Deliveries are sent by Dispatch.
+func (s *Service) Publish(ctx context.Context, eventType EventType, data interface{}) error {
+	endpoints, err := s.repo.ActiveEndpoints(ctx)
+	if err != nil {
+		return err
+	}
+	deliveries, err := newDeliveries(endpoints, eventType, time.Now(), data)
+	if err != nil {
+		return err
+	}
+	return s.repo.CreateDeliveries(ctx, deliveries)
+}
+
+// TestFire sends a webhook.test event to an endpoint right away, whatever its
+// subscriptions, and returns the logged delivery. Test deliveries are not retried.
+func (s *Service) TestFire(ctx context.Context, id uint) (*Delivery, error) {
+	endpoint, err := s.repo.GetEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := newDeliveries([]Endpoint{*endpoint}, EventTest, time.Now(), map[string]interface{}{
+		"endpoint_id": endpoint.ID,
+		"message":     "This is a test delivery from Fynelo.",
+	})
+	if err != nil {
+		return nil, err
+	}
+	delivery := &deliveries[0]
+	delivery.NextAttemptAt = nil // keep it away from the dispatcher
+	if err := s.repo.SaveDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	s.attempt(ctx, endpoint, delivery, false)
+	if err := s.repo.SaveDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// Dispatch sends every delivery that is due and returns how many were attempted.
+// Failed attempts are rescheduled with exponential backoff until maxAttempts.
+func (s *Service) Dispatch(ctx context.Context) (int, error) {
+	attempted := 0
+	for {
+		due, err := s.repo.DueDeliveries(ctx, time.Now(), dispatchBatch)
+		if err != nil {
+			return attempted, err
+		}
+		if len(due) == 0 {
+			return attempted, nil
+		}
+
+		ids := make([]uint, 0, len(due))
+		for _, d := range due {
+			ids = append(ids, d.EndpointID)
+		}
+		endpoints, err := s.repo.EndpointsByID(ctx, ids)
+		if err != nil {
+			return attempted, err
+		}
+
+		for i := range due {
+			delivery := &due[i]
+			now := time.Now()
+			claimed, err := s.repo.Claim(ctx, delivery, now, now.Add(claimTimeout))
+			if err != nil {
+				return attempted, err
+			}
+			if !claimed {
+				continue
+			}
+
+			endpoint, ok := endpoints[delivery.EndpointID]
+			switch {
+			case !ok || endpoint.DeletedAt.Valid:
+				abandon(delivery, "endpoint was deleted")
+			case !endpoint.Active:
+				abandon(delivery, "endpoint is disabled")
+			default:
+				s.attempt(ctx, &endpoint, delivery, true)
+				attempted++
+			}
+			if err := s.repo.SaveDelivery(ctx, delivery); err != nil {
+				return attempted, err
+			}
+		}
+		if len(due) < dispatchBatch {
+			return attempted, nil
+		}
+	}
+}
+
+// attempt sends a delivery once and records the outcome on it. With retry,
+// a failure schedules the next attempt instead of failing the delivery.
+func (s *Service) attempt(ctx context.Context, endpoint *Endpoint, delivery *Delivery, retry bool) {
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+
+	status, body, err := s.send(ctx, endpoint, delivery, now)
+	delivery.ResponseStatus = status
+	delivery.ResponseBody = body
+	if err == nil && status >= 200 && status < 300 {
+		delivery.Status = DeliverySucceeded
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+		delivery.Error = ""
+		return
+	}
+
+	if err != nil {
+		delivery.Error = err.Error()
+	} else {
+		delivery.Error = fmt.Sprintf("endpoint responded with status %d", status)
+	}
+	if !retry || delivery.Attempts >= maxAttempts {
+		delivery.Status = DeliveryFailed
+		delivery.NextAttemptAt = nil
+		return
+	}
+	next := now.Add(retryDelay(delivery.Attempts))
+	delivery.NextAttemptAt = &next
+}
+
+func (s *Service) send(ctx context.Context, endpoint *Endpoint, delivery *Delivery, now time.Time) (int, string, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+	timestamp := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Fynelo-Webhooks/1.0")
+	req.Header.Set(HeaderEvent, string(delivery.EventType))
+	req.Header.Set(HeaderDelivery, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(endpoint.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	return resp.StatusCode, string(excerpt), nil
+}
+
+// Sign returns the signature header value for a delivery body sent at timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Relay turns company changes and detected signals recorded since the last run
+// into company.created, company.updated and signal.detected events, and returns
+// how many events were queued.
+func (s *Service) Relay(ctx context.Context) (int, error) {
+	endpoints, err := s.repo.ActiveEndpoints(ctx)
+	if err != nil {
+		return 0, err
+	}
+	changes, err := s.relayCompanyChanges(ctx, endpoints)
+	if err != nil {
+		return changes, err
+	}
+	detected, err := s.relaySignals(ctx, endpoints)
+	return changes + detected, err
+}
+
+func (s *Service) relayCompanyChanges(ctx context.Context, endpoints []Endpoint) (int, error) {
+	cursor, err := s.repo.Cursor(ctx, sourceCompanyChanges)
+	if err != nil {
+		return 0, err
+	}
+
+	queued := 0
+	last := cursor.LastID
+	for {
+		changes, err := s.repo.CompanyChangesAfter(ctx, last, relayBatchSize)
+		if err != nil {
+			return queued, err
+		}
+		if len(changes) == 0 {
+			return queued, nil
+		}
+
+		var ids []uint
+		for _, c := range changes {
+			if c.EntityType == model.EntityCompany {
+				ids = append(ids, c.CompanyID)
+			}
+		}
+		companies, err := s.repo.Companies(ctx, ids)
+		if err != nil {
+			return queued, err
+		}
+
+		var deliveries []Delivery
+		for _, event := range companyEvents(changes, companies) {
+			batch, err := newDeliveries(endpoints, event.Type, event.CreatedAt, event.Data)
+			if err != nil {
+				return queued, err
+			}
+			if len(batch) > 0 {
+				queued++
+			}
+			deliveries = append(deliveries, batch...)
+		}
+
+		last = changes[len(changes)-1].ID
+		if err := s.repo.SaveRelayBatch(ctx, sourceCompanyChanges, deliveries, last); err != nil {
+			return queued, err
+		}
+	}
+}
+
+func (s *Service) relaySignals(ctx context.Context, endpoints []Endpoint) (int, error) {
+	cursor, err := s.repo.Cursor(ctx, sourceSignals)
+	if err != nil {
+		return 0, err
+	}
+
+	queued := 0
+	last := cursor.LastID
+	for {
+		list, err := s.repo.SignalsAfter(ctx, last, relayBatchSize)
+		if err != nil {
+			return queued, err
+		}
+		if len(list) == 0 {
+			return queued, nil
+		}
+
+		var deliveries []Delivery
+		for _, signal := range list {
+			batch, err := newDeliveries(endpoints, EventSignalDetected, signal.CreatedAt, signalData(signal))
+			if err != nil {
+				return queued, err
+			}
+			if len(batch) > 0 {
+				queued++
+			}
+			deliveries = append(deliveries, batch...)
+		}
+
+		last = list[len(list)-1].ID
+		if err := s.repo.SaveRelayBatch(ctx, sourceSignals, deliveries, last); err != nil {
+			return queued, err
+		}
+	}
+}
+
+// companyEvents builds company.created and company.updated events from changes
+// to company attributes. Field changes written together become one update.
+// Changes to child rows (revenues, technologies, ...) are not relayed.
+func companyEvents(changes []model.CompanyChange, companies map[uint]model.Company) []Event {
+	type updateKey struct {
+		companyID uint
+		at        time.Time
+	}
+	var events []Event
+	updates := map[updateKey]map[string]interface{}{}
+
+	for _, change := range changes {
+		if change.EntityType != model.EntityCompany {
+			continue
+		}
+		switch change.Operation {
+		case model.ChangeCreate:
+			company := map[string]json.RawMessage{}
+			if change.NewValue != nil {
+				json.Unmarshal([]byte(*change.NewValue), &company)
+			}
+			company["id"], _ = json.Marshal(change.CompanyID)
+			events = append(events, Event{Type: EventCompanyCreated, CreatedAt: change.ChangedAt, Data: map[string]interface{}{
+				"company": company,
+				"actor":   change.Actor,
+			}})
+
+		case model.ChangeUpdate:
+			key := updateKey{change.CompanyID, change.ChangedAt}
+			fields, ok := updates[key]
+			if !ok {
+				fields = map[string]interface{}{}
+				updates[key] = fields
+				company := companies[change.CompanyID]
+				events = append(events, Event{Type: EventCompanyUpdated, CreatedAt: change.ChangedAt, Data: map[string]interface{}{
+					"company_id": change.CompanyID,
+					"name":       company.Name,
+					"domain":     company.Domain,
+					"actor":      change.Actor,
+					"changes":    fields,
+				}})
+			}
+			fields[change.Field] = map[string]interface{}{
+				"old": rawValue(change.OldValue),
+				"new": rawValue(change.NewValue),
+			}
+		}
+	}
+	return events
+}
+
+func signalData(signal signals.Signal) map[string]interface{} {
+	details := json.RawMessage("null")
+	if signal.Details != "" {
+		details = json.RawMessage(signal.Details)
+	}
+	return map[string]interface{}{
+		"signal_id":   signal.ID,
+		"company_id":  signal.CompanyID,
+		"type":        signal.Type,
+		"title":       signal.Title,
+		"details":     details,
+		"occurred_at": signal.OccurredAt,
+	}
+}
+
+// newDeliveries builds one pending delivery of the event per subscribed endpoint.
+func newDeliveries(endpoints []Endpoint, eventType EventType, at time.Time, data interface{}) ([]Delivery, error) {
+	var subscribed []Endpoint
+	for _, e := range endpoints {
+		if eventType == EventTest || e.Subscribes(eventType) {
+			subscribed = append(subscribed, e)
+		}
+	}
+	if len(subscribed) == 0 {
+		return nil, nil
+	}
+
+	id, err := randomHex(12)
+	if err != nil {
+		return nil, err
+	}
+	event := Event{ID: "evt_" + id, Type: eventType, CreatedAt: at.UTC(), Data: data}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	deliveries := make([]Delivery, 0, len(subscribed))
+	for _, e := range subscribed {
+		deliveries = append(deliveries, Delivery{
+			EndpointID:    e.ID,
+			EventID:       event.ID,
+			EventType:     eventType,
+			Payload:       string(payload),
+			Status:        DeliveryPending,
+			NextAttemptAt: &now,
+		})
+	}
+	return deliveries, nil
+}
+
+// abandon fails a delivery that can no longer be sent.
+func abandon(delivery *Delivery, reason string) {
+	delivery.Status = DeliveryFailed
+	delivery.NextAttemptAt = nil
+	delivery.Error = reason
+}
+
+func applyRequest(endpoint *Endpoint, req EndpointRequest) error {
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidURL
+	}
+	seen := map[EventType]struct{}{}
+	events := make([]EventType, 0, len(req.Events))
+	for _, e := range req.Events {
+		if _, ok := eventTypes[e]; !ok {
+			return ErrUnknownEvent
+		}
+		if _, dup := seen[e]; dup {
+			continue
+		}
+		seen[e] = struct{}{}
+		events = append(events, e)
+	}
+
+	endpoint.URL = req.URL
+	endpoint.Events = events
+	endpoint.Description = req.Description
+	if req.Active != nil {
+		endpoint.Active = *req.Active
+	}
+	return nil
+}
+
+func retryDelay(attempts int) time.Duration {
+	delay := baseRetryDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
+
+func rawValue(value *string) json.RawMessage {
+	if value == nil {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(*value)
+}
+
+func newSecret() (string, error) {
+	s, err := randomHex(secretRandomSize)
+	if err != nil {
+		return "", err
+	}
+	return secretPrefix + s, nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,347 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// receiver is a local stand-in for a subscriber's webhook URL. It answers
+// with the queued statuses in turn, then 200, and keeps what it received.
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []received
+}
+
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.requests = append(rc.requests, received{header: r.Header.Clone(), body: body})
+	status := http.StatusOK
+	if len(rc.statuses) > 0 {
+		status, rc.statuses = rc.statuses[0], rc.statuses[1:]
+	}
+	w.WriteHeader(status)
+	io.WriteString(w, "status "+strconv.Itoa(status))
+}
+
+func (rc *receiver) received() []received {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return append([]received{}, rc.requests...)
+}
+
+func newTestService(t *testing.T, statuses ...int) (*Service, *Repository, *Endpoint, *receiver) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&Endpoint{}, &Delivery{}, &RelayCursor{}); err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	t.Cleanup(func() { sqlDB.Close() })
+
+	rc := &receiver{statuses: statuses}
+	srv := httptest.NewServer(rc)
+	t.Cleanup(srv.Close)
+
+	repo := NewRepository(db)
+	svc := NewService(repo, srv.Client())
+	endpoint, err := svc.CreateEndpoint(context.Background(), EndpointRequest{
+		URL:    srv.URL + "/hooks",
+		Secret: "whsec_test",
+		Events: []EventType{EventJobCompleted},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return svc, repo, endpoint, rc
+}
+
+func onlyDelivery(t *testing.T, repo *Repository, endpointID uint) Delivery {
+	t.Helper()
+	list, total, err := repo.ListDeliveries(context.Background(), endpointID, DeliveryFilter{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 {
+		t.Fatalf("got %d deliveries, want 1", total)
+	}
+	return list[0]
+}
+
+// makeDue moves a delivery's next attempt into the past, as if its backoff
+// had elapsed.
+func makeDue(t *testing.T, repo *Repository, id uint) {
+	t.Helper()
+	if err := repo.db.Model(&Delivery{}).Where("id = ?", id).
+		Update("next_attempt_at", time.Now().Add(-time.Second)).Error; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"id":"evt_1"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("1700000000." + string(body)))
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if got := Sign("secret", 1700000000, body); got != want {
+		t.Errorf("Sign = %s, want %s", got, want)
+	}
+	if Sign("other", 1700000000, body) == want {
+		t.Error("signature does not depend on the secret")
+	}
+	if Sign("secret", 1700000001, body) == want {
+		t.Error("signature does not depend on the timestamp")
+	}
+}
+
+func TestDispatchSendsSignedEvent(t *testing.T) {
+	svc, repo, endpoint, rc := newTestService(t)
+	ctx := context.Background()
+
+	if err := svc.Publish(ctx, EventJobCompleted, map[string]string{"job_id": "job-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.Publish(ctx, EventCompanyCreated, map[string]string{"name": "not subscribed"}); err != nil {
+		t.Fatal(err)
+	}
+	attempted, err := svc.Dispatch(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempted != 1 {
+		t.Fatalf("attempted %d deliveries, want 1", attempted)
+	}
+
+	reqs := rc.received()
+	if len(reqs) != 1 {
+		t.Fatalf("receiver got %d requests, want 1", len(reqs))
+	}
+	req := reqs[0]
+	if got := req.header.Get(HeaderEvent); got != string(EventJobCompleted) {
+		t.Errorf("%s = %q", HeaderEvent, got)
+	}
+	timestamp, err := strconv.ParseInt(req.header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("%s: %v", HeaderTimestamp, err)
+	}
+	if got, want := req.header.Get(HeaderSignature), Sign("whsec_test", timestamp, req.body); got != want {
+		t.Errorf("%s = %q, want %q", HeaderSignature, got, want)
+	}
+
+	var event Event
+	if err := json.Unmarshal(req.body, &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.Type != EventJobCompleted || event.ID == "" {
+		t.Errorf("event = %+v", event)
+	}
+
+	delivery := onlyDelivery(t, repo, endpoint.ID)
+	if req.header.Get(HeaderDelivery) != strconv.FormatUint(uint64(delivery.ID), 10) {
+		t.Errorf("%s = %q, want %d", HeaderDelivery, req.header.Get(HeaderDelivery), delivery.ID)
+	}
+	if delivery.Status != DeliverySucceeded || delivery.Attempts != 1 || delivery.DeliveredAt == nil {
+		t.Errorf("delivery = %+v, want succeeded after 1 attempt", delivery)
+	}
+	if delivery.ResponseStatus != http.StatusOK || delivery.ResponseBody != "status 200" {
+		t.Errorf("logged response %d %q", delivery.ResponseStatus, delivery.ResponseBody)
+	}
+}
+
+func TestDispatchRetriesWithBackoff(t *testing.T) {
+	svc, repo, endpoint, rc := newTestService(t, http.StatusInternalServerError, http.StatusServiceUnavailable)
+	ctx := context.Background()
+
+	if err := svc.Publish(ctx, EventJobCompleted, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for attempt, status := range []int{http.StatusInternalServerError, http.StatusServiceUnavailable} {
+		before := time.Now()
+		if _, err := svc.Dispatch(ctx); err != nil {
+			t.Fatal(err)
+		}
+		delivery := onlyDelivery(t, repo, endpoint.ID)
+		if delivery.Status != DeliveryPending || delivery.Attempts != attempt+1 {
+			t.Fatalf("after attempt %d: status %s, attempts %d", attempt+1, delivery.Status, delivery.Attempts)
+		}
+		if delivery.ResponseStatus != status || delivery.Error == "" {
+			t.Errorf("after attempt %d: logged status %d, error %q", attempt+1, delivery.ResponseStatus, delivery.Error)
+		}
+		wait := retryDelay(delivery.Attempts)
+		if delivery.NextAttemptAt == nil || delivery.NextAttemptAt.Before(before.Add(wait)) || delivery.NextAttemptAt.After(time.Now().Add(wait)) {
+			t.Errorf("after attempt %d: next attempt at %v, want in %v", attempt+1, delivery.NextAttemptAt, wait)
+		}
+
+		// not due again until the backoff has elapsed
+		if attempted, err := svc.Dispatch(ctx); err != nil || attempted != 0 {
+			t.Fatalf("dispatch before backoff attempted %d (err %v)", attempted, err)
+		}
+		makeDue(t, repo, delivery.ID)
+	}
+
+	if _, err := svc.Dispatch(ctx); err != nil {
+		t.Fatal(err)
+	}
+	delivery := onlyDelivery(t, repo, endpoint.ID)
+	if delivery.Status != DeliverySucceeded || delivery.Attempts != 3 || delivery.Error != "" || delivery.NextAttemptAt != nil {
+		t.Errorf("delivery = %+v, want succeeded on attempt 3", delivery)
+	}
+	if n := len(rc.received()); n != 3 {
+		t.Errorf("receiver got %d requests, want 3", n)
+	}
+}
+
+func TestDispatchFailsAfterMaxAttempts(t *testing.T) {
+	statuses := make([]int, maxAttempts+1)
+	for i := range statuses {
+		statuses[i] = http.StatusBadGateway
+	}
+	svc, repo, endpoint, rc := newTestService(t, statuses...)
+	ctx := context.Background()
+
+	if err := svc.Publish(ctx, EventJobCompleted, nil); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < maxAttempts; i++ {
+		if i > 0 {
+			makeDue(t, repo, onlyDelivery(t, repo, endpoint.ID).ID)
+		}
+		if _, err := svc.Dispatch(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if attempted, err := svc.Dispatch(ctx); err != nil || attempted != 0 {
+		t.Fatalf("failed delivery was attempted again: %d (err %v)", attempted, err)
+	}
+
+	delivery := onlyDelivery(t, repo, endpoint.ID)
+	if delivery.Status != DeliveryFailed || delivery.Attempts != maxAttempts || delivery.NextAttemptAt != nil {
+		t.Errorf("delivery = %+v, want failed after %d attempts", delivery, maxAttempts)
+	}
+	if n := len(rc.received()); n != maxAttempts {
+		t.Errorf("receiver got %d requests, want %d", n, maxAttempts)
+	}
+}
+
+func TestDispatchAbandonsDisabledEndpoint(t *testing.T) {
+	svc, repo, endpoint, rc := newTestService(t)
+	ctx := context.Background()
+
+	if err := svc.Publish(ctx, EventJobCompleted, nil); err != nil {
+		t.Fatal(err)
+	}
+	inactive := false
+	if _, err := svc.UpdateEndpoint(ctx, endpoint.ID, EndpointRequest{
+		URL: endpoint.URL, Events: endpoint.Events, Active: &inactive,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Dispatch(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	delivery := onlyDelivery(t, repo, endpoint.ID)
+	if delivery.Status != DeliveryFailed || delivery.Attempts != 0 || delivery.Error != "endpoint is disabled" {
+		t.Errorf("delivery = %+v, want failed without an attempt", delivery)
+	}
+	if n := len(rc.received()); n != 0 {
+		t.Errorf("receiver got %d requests, want 0", n)
+	}
+}
+
+func TestTestFireIsNotRetried(t *testing.T) {
+	svc, repo, endpoint, _ := newTestService(t, http.StatusInternalServerError)
+	ctx := context.Background()
+
+	delivery, err := svc.TestFire(ctx, endpoint.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delivery.EventType != EventTest || delivery.Status != DeliveryFailed || delivery.Attempts != 1 {
+		t.Errorf("test delivery = %+v, want one failed attempt", delivery)
+	}
+	if attempted, err := svc.Dispatch(ctx); err != nil || attempted != 0 {
+		t.Errorf("dispatch retried the test delivery: %d (err %v)", attempted, err)
+	}
+	if got := onlyDelivery(t, repo, endpoint.ID); got.ID != delivery.ID {
+		t.Errorf("logged delivery %d, want %d", got.ID, delivery.ID)
+	}
+}
+
+func TestListDeliveriesFiltersLog(t *testing.T) {
+	svc, _, endpoint, _ := newTestService(t, http.StatusInternalServerError)
+	ctx := context.Background()
+
+	if _, err := svc.TestFire(ctx, endpoint.ID); err != nil { // fails
+		t.Fatal(err)
+	}
+	if err := svc.Publish(ctx, EventJobCompleted, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Dispatch(ctx); err != nil { // succeeds
+		t.Fatal(err)
+	}
+
+	all, err := svc.ListDeliveries(ctx, endpoint.ID, DeliveryListRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if all.Total != 2 || all.Limit != 20 || all.Deliveries[0].EventType != EventJobCompleted {
+		t.Errorf("log = %+v, want 2 deliveries newest first", all)
+	}
+
+	failed, err := svc.ListDeliveries(ctx, endpoint.ID, DeliveryListRequest{Status: string(DeliveryFailed)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failed.Total != 1 || failed.Deliveries[0].EventType != EventTest {
+		t.Errorf("failed deliveries = %+v, want the test delivery", failed.Deliveries)
+	}
+
+	if _, err := svc.ListDeliveries(ctx, endpoint.ID, DeliveryListRequest{Status: "bogus"}); err != ErrInvalidStatus {
+		t.Errorf("bad status: err = %v, want ErrInvalidStatus", err)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	for _, tc := range []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{7, 32 * time.Minute},
+		{20, maxRetryDelay},
+	} {
+		if got := retryDelay(tc.attempts); got != tc.want {
+			t.Errorf("retryDelay(%d) = %v, want %v", tc.attempts, got, tc.want)
+		}
+	}
+}
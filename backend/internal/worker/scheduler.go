@@ -0,0 +1,43 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// PeriodicTask is maintenance work the worker runs on a fixed interval
+// alongside queue processing, e.g. the duplicate company scan.
+type PeriodicTask struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// AddPeriodicTask registers a task. Tasks must be added before Start.
+func (w *Worker) AddPeriodicTask(task PeriodicTask) {
+	w.tasks = append(w.tasks, task)
+}
+
+// runPeriodic runs a task every Interval until the worker stops.
+func (w *Worker) runPeriodic(ctx context.Context, task PeriodicTask) {
+	ticker := time.NewTicker(task.Interval)
+	defer ticker.Stop()
+
+	log.Printf("Periodic task %s scheduled every %v", task.Name, task.Interval)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.stopChan:
+			return
+		case <-ticker.C:
+			start := time.Now()
+			if err := task.Run(ctx); err != nil {
+				log.Printf("Periodic task %s failed: %v", task.Name, err)
+				continue
+			}
+			log.Printf("Periodic task %s completed in %v", task.Name, time.Since(start))
+		}
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"log"
 	"time"
 
+	"github.com/bhati00/Fynelo/backend/internal/icp"
 	"github.com/bhati00/Fynelo/backend/internal/queue"
+	"github.com/bhati00/Fynelo/backend/internal/webhook"
 	"github.com/go-redis/redis/v8"
 	"gorm.io/gorm"
 )
@@ -20,18 +22,30 @@ const (
 	// Backoff configuration
 	BaseBackoff = 1 * time.Second
 	MaxBackoff = 10 * time.Second
+
+	// Periodic task intervals
+	DuplicateScanInterval = 6 * time.Hour
+	SignalDetectInterval = 5 * time.Minute
+	SavedSearchInterval = 5 * time.Minute
+	WebhookDispatchInterval = 15 * time.Second
+	TaxonomyRefreshInterval = 5 * time.Minute
 )
 
 type Worker struct {
 	queueService queue.QueueService
 	db           *gorm.DB
+	webhooks     *webhook.Service
+	icpRuns      *icp.Repository
 	stopChan     chan struct{}
+	tasks        []PeriodicTask
 }
 
 func NewWorker(redisClient *redis.Client, db *gorm.DB) *Worker {
 	return &Worker{
 		queueService: queue.NewQueueService(),
 		db:           db,
+		webhooks:     webhook.NewService(webhook.NewRepository(db), nil),
+		icpRuns:      icp.NewRepository(db),
 		stopChan:     make(chan struct{}),
 	}
 }
@@ -39,6 +53,10 @@ func NewWorker(redisClient *redis.Client, db *gorm.DB) *Worker {
 // Start begins the worker loop
 func (w *Worker) Start(ctx context.Context) error {
 	log.Println("Worker started, waiting for jobs...")
+
+	for _, task := range w.tasks {
+		go w.runPeriodic(ctx, task)
+	}
 	
 	for {
 		select {
@@ -101,6 +119,8 @@ func (w *Worker) processJob(job *queue.SearchJob) {
 			if updateErr := w.queueService.UpdateJobStatus(job.ID, queue.StatusCompleted, resultCount, ""); updateErr != nil {
 				log.Printf("Failed to update job %s status to completed: %v", job.ID, updateErr)
 			}
+			w.publishJobEvent(job, webhook.EventJobCompleted, resultCount, "")
+			w.finishICPRun(job, queue.StatusCompleted, resultCount, "")
 			return
 		}
 
@@ -128,6 +148,36 @@ func (w *Worker) processJob(job *queue.SearchJob) {
 	if updateErr := w.queueService.UpdateJobStatus(job.ID, queue.StatusFailed, 0, lastError.Error()); updateErr != nil {
 		log.Printf("Failed to update job %s status to failed: %v", job.ID, updateErr)
 	}
+	w.publishJobEvent(job, webhook.EventJobFailed, 0, lastError.Error())
+	w.finishICPRun(job, queue.StatusFailed, 0, lastError.Error())
+}
+
+// publishJobEvent queues a webhook event for a finished job
+func (w *Worker) publishJobEvent(job *queue.SearchJob, event webhook.EventType, resultCount int, errorMsg string) {
+	data := map[string]interface{}{
+		"job_id":       job.ID,
+		"user_id":      job.UserID,
+		"query":        job.Query,
+		"query_mode":   job.QueryMode,
+		"filters":      job.Filters,
+		"icp_id":       job.ICPID,
+		"result_count": resultCount,
+		"error":        errorMsg,
+		"finished_at":  time.Now().UTC(),
+	}
+	if err := w.webhooks.Publish(context.Background(), event, data); err != nil {
+		log.Printf("Failed to publish %s webhook for job %s: %v", event, job.ID, err)
+	}
+}
+
+// finishICPRun records the outcome of a job queued by an ICP run on that run
+func (w *Worker) finishICPRun(job *queue.SearchJob, status queue.JobStatus, resultCount int, errorMsg string) {
+	if job.ICPID == 0 {
+		return
+	}
+	if err := w.icpRuns.FinishJob(context.Background(), job.ID, string(status), resultCount, errorMsg, time.Now()); err != nil {
+		log.Printf("Failed to record job %s on ICP %d: %v", job.ID, job.ICPID, err)
+	}
 }
 
 // processSearchJob is the actual job processing logic (Phase 5 placeholder)
@@ -0,0 +1,39 @@
+// Package actor carries the user or job responsible for a change through a
+// request context, so writes deep in the stack can be attributed.
+package actor
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Header lets API clients name themselves until requests are authenticated.
+const Header = "X-Actor"
+
+type contextKey struct{}
+
+// WithActor returns a context attributing changes to name, e.g. "jane@acme.com"
+// or "job:duplicate-scan".
+func WithActor(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, contextKey{}, name)
+}
+
+// FromContext returns the actor set on ctx, or "" when there is none.
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	name, _ := ctx.Value(contextKey{}).(string)
+	return name
+}
+
+// Middleware attributes each request to the actor named in the X-Actor header.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if name := c.GetHeader(Header); name != "" {
+			c.Request = c.Request.WithContext(WithActor(c.Request.Context(), name))
+		}
+		c.Next()
+	}
+}
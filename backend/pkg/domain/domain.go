@@ -0,0 +1,57 @@
+// Package domain canonicalizes company websites into a stable domain key.
+package domain
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+var ErrInvalidDomain = errors.New("invalid domain")
+
+// Canonicalize reduces a website or bare domain to its canonical form:
+// lowercase ASCII (punycode) host without scheme, "www.", port, path or
+// trailing dot. "https://www.Müller.de/about" becomes "xn--mller-kva.de".
+func Canonicalize(website string) (string, error) {
+	raw := strings.TrimSpace(website)
+	if raw == "" {
+		return "", ErrInvalidDomain
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", ErrInvalidDomain
+	}
+
+	host := strings.TrimSuffix(u.Hostname(), ".")
+	host, err = idna.Lookup.ToASCII(host)
+	if err != nil {
+		return "", ErrInvalidDomain
+	}
+	host = strings.TrimPrefix(strings.ToLower(host), "www.")
+
+	if !strings.Contains(host, ".") || strings.HasPrefix(host, ".") || strings.Contains(host, "..") {
+		return "", ErrInvalidDomain
+	}
+	return host, nil
+}
+
+// Label returns the registrable label of a canonical domain, e.g. "acme" for
+// "app.acme.co.uk". Useful for comparing a domain with a company name.
+func Label(host string) string {
+	parts := strings.Split(host, ".")
+	if len(parts) == 1 {
+		return host
+	}
+	i := len(parts) - 2
+	// second-level public suffixes such as co.uk or com.au
+	if i > 0 && len(parts[i]) <= 3 && len(parts[len(parts)-1]) == 2 {
+		i--
+	}
+	return parts[i]
+}
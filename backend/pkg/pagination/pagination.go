@@ -0,0 +1,36 @@
+// Package pagination bounds the limit and offset of paged listings.
+package pagination
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Page bounds a requested limit and offset: a missing or invalid limit
+// becomes DefaultLimit, larger ones MaxLimit, and negative offsets 0.
+func Page(limit, offset int) (int, int) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+// FromQuery reads the limit and offset query parameters, bounded by Page.
+// Values that are not numbers are ignored.
+func FromQuery(c *gin.Context) (int, int) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	return Page(limit, offset)
+}
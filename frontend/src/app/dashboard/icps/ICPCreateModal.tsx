@@ -4,20 +4,11 @@ import { Button } from "@/components/ui/button";
 import { Input } from "@/components/ui/input";
 import { Label } from "@/components/ui/label";
 import { Textarea } from "@/components/ui/textarea";
-import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from "@/components/ui/select";
 import { Progress } from "@/components/ui/progress";
 import { ChevronLeft, ChevronRight, Check } from "lucide-react";
 import { icpService } from "@/services/icpService";
 import { toast } from 'sonner';
-import { 
-  BUSINESS_TYPE_OPTIONS, 
-  COMPANY_SIZE_OPTIONS, 
-  BUYER_ROLE_OPTIONS,
-  type BusinessTypeValue,
-  type CompanySizeValue,
-  type BuyerRoleValue
-} from "@/constants/icpConstants";
-type Option = { value: number; label: string };
+import TermPicker, { useTaxonomies } from "./TermPicker";
 
 interface ICPCreateModalProps {
   open: boolean;
@@ -26,33 +17,38 @@ interface ICPCreateModalProps {
 }
 
 interface FormData {
-  businessType: BusinessTypeValue | "";
-  industry: string;
-  companySize: CompanySizeValue | "";
-  buyerRoles: BuyerRoleValue | "";
+  name: string;
+  businessTypes: number[];
+  industries: number[];
+  companySizes: number[];
+  buyerRoles: number[];
   problemStatement?: string;
 }
 
+const emptyForm: FormData = {
+  name: "",
+  businessTypes: [],
+  industries: [],
+  companySizes: [],
+  buyerRoles: [],
+  problemStatement: "",
+};
+
 export default function ICPCreateModal({ open, onOpenChange, onCreated }: ICPCreateModalProps) {
   const steps = [
-    { id: "businessType", label: "Business Type", description: "What type of business do you run?" },
-    { id: "industry", label: "Industry", description: "What industry are you in?" },
-    { id: "companySize", label: "Company Size", description: "How many employees do you have?" },
-    { id: "buyerRoles", label: "Target Buyer Role", description: "Who typically makes purchasing decisions?" },
+    { id: "businessTypes", label: "Business Types", description: "What types of business do you sell to?" },
+    { id: "industries", label: "Industries", description: "Which industries are your customers in?" },
+    { id: "companySizes", label: "Company Sizes", description: "How many employees do your customers have?" },
+    { id: "buyerRoles", label: "Target Buyer Roles", description: "Who typically makes purchasing decisions?" },
     { id: "problemStatement", label: "Problem Statement", description: "What problem does your product solve? (Optional)" },
   ];
 
   const [currentStep, setCurrentStep] = useState(0);
-  const [formData, setFormData] = useState<FormData>({
-    businessType: 1 as BusinessTypeValue,
-    industry: "",
-    companySize: 1 as CompanySizeValue,
-    buyerRoles: 1 as BuyerRoleValue,
-    problemStatement: "",
-  });
+  const [formData, setFormData] = useState<FormData>(emptyForm);
   const [isSubmitting, setIsSubmitting] = useState(false);
+  const taxonomies = useTaxonomies();
 
-  const updateFormData = (field: keyof FormData, value: string | number) => {
+  const updateFormData = (field: keyof FormData, value: string | number[]) => {
     setFormData(prev => ({ ...prev, [field]: value }));
   };
 
@@ -74,26 +70,19 @@ export default function ICPCreateModal({ open, onOpenChange, onCreated }: ICPCre
     }
   };
 
-  const isCurrentStepValid = () => {
-    const stepId = steps[currentStep].id as keyof FormData;
-    if (stepId === "problemStatement") return true; // Optional field
-    
-    const value = formData[stepId];
-    if (stepId === "industry") {
-      return typeof value === "string" && value.trim() !== "";
-    }
-    return value !== "";
-  };
+  // A step with nothing selected places no constraint, so only the name is required
+  const isCurrentStepValid = () => formData.name.trim() !== "";
 
   const handleSubmit = async () => {
     setIsSubmitting(true);
     
     try {
       const response = await icpService.createICP({
-        business_type: formData.businessType as BusinessTypeValue,
-        industry: formData.industry,
-        company_size: formData.companySize as CompanySizeValue,
-        buyer_roles: formData.buyerRoles as BuyerRoleValue,
+        name: formData.name.trim(),
+        business_types: formData.businessTypes,
+        industries: formData.industries,
+        company_sizes: formData.companySizes,
+        buyer_roles: formData.buyerRoles,
         problem_statement: formData.problemStatement || undefined,
       });
 
@@ -114,13 +103,7 @@ export default function ICPCreateModal({ open, onOpenChange, onCreated }: ICPCre
     // Reset form after a delay to prevent UI flash
     setTimeout(() => {
       setCurrentStep(0);
-      setFormData({
-        businessType: "",
-        industry: "",
-        companySize: "",
-        buyerRoles: "",
-        problemStatement: "",
-      });
+      setFormData(emptyForm);
     }, 150);
   };
 
@@ -128,68 +111,56 @@ export default function ICPCreateModal({ open, onOpenChange, onCreated }: ICPCre
     const step = steps[currentStep];
     
     switch (step.id) {
-      case "businessType":
+      case "businessTypes":
         return (
           <div className="space-y-3">
             <div>
-              <Label className="text-sm font-medium">Business Type</Label>
+              <Label className="text-sm font-medium">Name</Label>
+              <Input
+                placeholder="e.g., Mid-market SaaS in Europe"
+                value={formData.name}
+                onChange={(e) => updateFormData("name", e.target.value)}
+              />
+            </div>
+            <div>
+              <Label className="text-sm font-medium">{step.label}</Label>
               <p className="text-sm text-muted-foreground mb-3">{step.description}</p>
             </div>
-            <Select 
-              value={formData.businessType.toString()} 
-              onValueChange={(value) => updateFormData("businessType", parseInt(value))}
-            >
-              <SelectTrigger>
-                <SelectValue placeholder="Select business type" />
-              </SelectTrigger>
-              <SelectContent>
-                {BUSINESS_TYPE_OPTIONS.map((option : Option) => (
-                  <SelectItem key={option.value} value={option.value.toString()}>
-                    {option.label}
-                  </SelectItem>
-                ))}
-              </SelectContent>
-            </Select>
+            <TermPicker
+              terms={taxonomies.business_types}
+              value={formData.businessTypes}
+              onChange={(value) => updateFormData("businessTypes", value)}
+            />
           </div>
         );
 
-      case "industry":
+      case "industries":
         return (
           <div className="space-y-3">
             <div>
-              <Label className="text-sm font-medium">Industry</Label>
+              <Label className="text-sm font-medium">{step.label}</Label>
               <p className="text-sm text-muted-foreground mb-3">{step.description}</p>
             </div>
-            <Input
-              placeholder="e.g., Technology, Healthcare, Finance..."
-              value={formData.industry}
-              onChange={(e) => updateFormData("industry", e.target.value)}
+            <TermPicker
+              terms={taxonomies.industries}
+              value={formData.industries}
+              onChange={(value) => updateFormData("industries", value)}
             />
           </div>
         );
 
-      case "companySize":
+      case "companySizes":
         return (
           <div className="space-y-3">
             <div>
-              <Label className="text-sm font-medium">Company Size</Label>
+              <Label className="text-sm font-medium">{step.label}</Label>
               <p className="text-sm text-muted-foreground mb-3">{step.description}</p>
             </div>
-            <Select 
-              value={formData.companySize.toString()} 
-              onValueChange={(value) => updateFormData("companySize", parseInt(value))}
-            >
-              <SelectTrigger>
-                <SelectValue placeholder="Select company size" />
-              </SelectTrigger>
-              <SelectContent>
-                {COMPANY_SIZE_OPTIONS.map((option : Option) => (
-                  <SelectItem key={option.value} value={option.value.toString()}>
-                    {option.label}
-                  </SelectItem>
-                ))}
-              </SelectContent>
-            </Select>
+            <TermPicker
+              terms={taxonomies.company_sizes}
+              value={formData.companySizes}
+              onChange={(value) => updateFormData("companySizes", value)}
+            />
           </div>
         );
 
@@ -197,24 +168,14 @@ export default function ICPCreateModal({ open, onOpenChange, onCreated }: ICPCre
         return (
           <div className="space-y-3">
             <div>
-              <Label className="text-sm font-medium">Target Buyer Role</Label>
+              <Label className="text-sm font-medium">{step.label}</Label>
               <p className="text-sm text-muted-foreground mb-3">{step.description}</p>
             </div>
-            <Select 
-              value={formData.buyerRoles.toString()} 
-              onValueChange={(value) => updateFormData("buyerRoles", parseInt(value))}
-            >
-              <SelectTrigger>
-                <SelectValue placeholder="Select buyer role" />
-              </SelectTrigger>
-              <SelectContent>
-                {BUYER_ROLE_OPTIONS.map((option : Option) => (
-                  <SelectItem key={option.value} value={option.value.toString()}>
-                    {option.label}
-                  </SelectItem>
-                ))}
-              </SelectContent>
-            </Select>
+            <TermPicker
+              terms={taxonomies.buyer_roles}
+              value={formData.buyerRoles}
+              onChange={(value) => updateFormData("buyerRoles", value)}
+            />
           </div>
         );
 
@@ -4,20 +4,12 @@ import { Button } from "@/components/ui/button";
 import { Input } from "@/components/ui/input";
 import { Label } from "@/components/ui/label";
 import { Textarea } from "@/components/ui/textarea";
-import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from "@/components/ui/select";
 import { Badge } from "@/components/ui/badge";
 import { Check, X } from "lucide-react";
 import { icpService } from "@/services/icpService";
 import { toast } from 'sonner';
-import { 
-  BUSINESS_TYPE_OPTIONS, 
-  COMPANY_SIZE_OPTIONS, 
-  BUYER_ROLE_OPTIONS,
-  type BusinessTypeValue,
-  type CompanySizeValue,
-  type BuyerRoleValue
-} from "@/constants/icpConstants";
 import { ICPProfile } from "@/models/icp";
+import TermPicker, { useTaxonomies } from "./TermPicker";
 
 interface ICPEditModalProps {
   open: boolean;
@@ -28,21 +20,24 @@ interface ICPEditModalProps {
 
 export default function ICPEditModal({ open, onOpenChange, icpData, onUpdated }: ICPEditModalProps) {
   const [formData, setFormData] = useState({
-    businessType: "",
-    industry: "",
-    companySize: "",
-    buyerRoles: "",
+    name: "",
+    businessTypes: [] as number[],
+    industries: [] as number[],
+    companySizes: [] as number[],
+    buyerRoles: [] as number[],
     problemStatement: "",
   });
   const [isSubmitting, setIsSubmitting] = useState(false);
+  const taxonomies = useTaxonomies();
 
   useEffect(() => {
     if (icpData) {
       setFormData({
-        businessType: (icpData.business_type ?? "").toString(),
-        industry: icpData.industry ?? "",
-        companySize: (icpData.company_size ?? "").toString(),
-        buyerRoles: (icpData.buyer_roles ?? "").toString(),
+        name: icpData.name ?? "",
+        businessTypes: icpData.business_types ?? [],
+        industries: icpData.industries ?? [],
+        companySizes: icpData.company_sizes ?? [],
+        buyerRoles: icpData.buyer_roles ?? [],
         problemStatement: icpData.problem_statement || "",
       });
     }
@@ -52,7 +47,7 @@ export default function ICPEditModal({ open, onOpenChange, icpData, onUpdated }:
     return null;
   }
 
-  const updateFormData = (field: string, value: string) => {
+  const updateFormData = (field: string, value: string | number[]) => {
     setFormData(prev => ({ ...prev, [field]: value }));
   };
 
@@ -60,12 +55,15 @@ export default function ICPEditModal({ open, onOpenChange, icpData, onUpdated }:
     setIsSubmitting(true);
     
     try {
+      // An update replaces every criterion, so the ones not edited here are sent back as they are
       const response = await icpService.updateICP(icpData.id ?? "", {
-        business_type: parseInt(formData.businessType) as BusinessTypeValue,
-        industry: formData.industry,
-        company_size: parseInt(formData.companySize) as CompanySizeValue,
-        buyer_roles: parseInt(formData.buyerRoles) as BuyerRoleValue,
-        problem_statement: formData.problemStatement || undefined,
+        ...icpData,
+        name: formData.name.trim(),
+        business_types: formData.businessTypes,
+        industries: formData.industries,
+        company_sizes: formData.companySizes,
+        buyer_roles: formData.buyerRoles,
+        problem_statement: formData.problemStatement,
       });
 
       if (response) {
@@ -92,76 +90,56 @@ export default function ICPEditModal({ open, onOpenChange, icpData, onUpdated }:
         </DialogHeader>
 
         <div className="grid gap-6 py-4">
-          {/* Business Type */}
+          {/* Name */}
           <div className="space-y-2">
-            <Label htmlFor="businessType">Business Type</Label>
-            <Select 
-              value={formData.businessType}
-              onValueChange={(value) => updateFormData("businessType", value)}
-            >
-              <SelectTrigger className="w-full">
-                <SelectValue placeholder="Select business type" />
-              </SelectTrigger>
-              <SelectContent>
-                {BUSINESS_TYPE_OPTIONS.map((option) => (
-                  <SelectItem key={option.value} value={option.value.toString()}>
-                    {option.label}
-                  </SelectItem>
-                ))}
-              </SelectContent>
-            </Select>
+            <Label htmlFor="name">Name</Label>
+            <Input
+              id="name"
+              value={formData.name}
+              onChange={(e) => updateFormData("name", e.target.value)}
+              placeholder="e.g., Mid-market SaaS in Europe"
+              className="w-full"
+            />
           </div>
 
-          {/* Industry */}
+          {/* Business Types */}
           <div className="space-y-2">
-            <Label htmlFor="industry">Industry</Label>
-            <Input
-              id="industry"
-              value={formData.industry}
-              onChange={(e) => updateFormData("industry", e.target.value)}
-              placeholder="e.g., Technology, Healthcare"
-              className="w-full"
+            <Label>Business Types</Label>
+            <TermPicker
+              terms={taxonomies.business_types}
+              value={formData.businessTypes}
+              onChange={(value) => updateFormData("businessTypes", value)}
             />
           </div>
 
-          {/* Company Size */}
+          {/* Industries */}
           <div className="space-y-2">
-            <Label htmlFor="companySize">Company Size</Label>
-            <Select 
-              value={formData.companySize}
-              onValueChange={(value) => updateFormData("companySize", value)}
-            >
-              <SelectTrigger className="w-full">
-                <SelectValue placeholder="Select company size" />
-              </SelectTrigger>
-              <SelectContent>
-                {COMPANY_SIZE_OPTIONS.map((option) => (
-                  <SelectItem key={option.value} value={option.value.toString()}>
-                    {option.label}
-                  </SelectItem>
-                ))}
-              </SelectContent>
-            </Select>
+            <Label>Industries</Label>
+            <TermPicker
+              terms={taxonomies.industries}
+              value={formData.industries}
+              onChange={(value) => updateFormData("industries", value)}
+            />
           </div>
 
-          {/* Buyer Role */}
+          {/* Company Sizes */}
           <div className="space-y-2">
-            <Label htmlFor="buyerRoles">Target Buyer Role</Label>
-            <Select 
+            <Label>Company Sizes</Label>
+            <TermPicker
+              terms={taxonomies.company_sizes}
+              value={formData.companySizes}
+              onChange={(value) => updateFormData("companySizes", value)}
+            />
+          </div>
+
+          {/* Buyer Roles */}
+          <div className="space-y-2">
+            <Label>Target Buyer Roles</Label>
+            <TermPicker
+              terms={taxonomies.buyer_roles}
               value={formData.buyerRoles}
-              onValueChange={(value) => updateFormData("buyerRoles", value)}
-            >
-              <SelectTrigger className="w-full">
-                <SelectValue placeholder="Select buyer role" />
-              </SelectTrigger>
-              <SelectContent>
-                {BUYER_ROLE_OPTIONS.map((option) => (
-                  <SelectItem key={option.value} value={option.value.toString()}>
-                    {option.label}
-                  </SelectItem>
-                ))}
-              </SelectContent>
-            </Select>
+              onChange={(value) => updateFormData("buyerRoles", value)}
+            />
           </div>
 
           {/* Problem Statement */}
@@ -0,0 +1,58 @@
+import { useEffect, useState } from "react";
+import { Badge } from "@/components/ui/badge";
+import { taxonomyService } from "@/services/taxonomyService";
+import { Taxonomies, TaxonomyTerm } from "@/models/taxonomy";
+
+const emptyTaxonomies: Taxonomies = {
+  industries: [],
+  company_sizes: [],
+  business_types: [],
+  buyer_roles: [],
+};
+
+// Loads the taxonomies the ICP criteria refer to
+export function useTaxonomies() {
+  const [taxonomies, setTaxonomies] = useState<Taxonomies>(emptyTaxonomies);
+
+  useEffect(() => {
+    taxonomyService
+      .listTaxonomies()
+      .then(setTaxonomies)
+      .catch((error) => console.error("Failed to fetch taxonomies:", error));
+  }, []);
+
+  return taxonomies;
+}
+
+// Names of the given term IDs, for display
+export function termNames(terms: TaxonomyTerm[], ids: number[] | undefined): string[] {
+  return (ids ?? []).map((id) => terms.find((term) => term.id === id)?.name ?? "Unknown");
+}
+
+interface TermPickerProps {
+  terms: TaxonomyTerm[];
+  value: number[];
+  onChange: (value: number[]) => void;
+}
+
+// Toggles any number of terms; none selected means no constraint
+export default function TermPicker({ terms, value, onChange }: TermPickerProps) {
+  const toggle = (id: number) => {
+    onChange(value.includes(id) ? value.filter((v) => v !== id) : [...value, id]);
+  };
+
+  return (
+    <div className="flex flex-wrap gap-2">
+      {terms.map((term) => (
+        <Badge
+          key={term.id}
+          variant={value.includes(term.id) ? "default" : "outline"}
+          className="cursor-pointer"
+          onClick={() => toggle(term.id)}
+        >
+          {term.name}
+        </Badge>
+      ))}
+    </div>
+  );
+}
@@ -20,7 +20,7 @@ import {
   Target
 } from "lucide-react";
 import { icpService } from "@/services/icpService";
-import { getBusinessTypeLabel } from "@/constants/icpConstants";
+import { termNames, useTaxonomies } from "./TermPicker";
 import { toast } from 'sonner';
 
 export default function ICPPage() {
@@ -32,6 +32,10 @@ export default function ICPPage() {
   const [isDeleting, setIsDeleting] = useState(false);
   const [isDeleteModalOpen, setIsDeleteModalOpen] = useState(false);
   const [icpToDelete, setIcpToDelete] = useState<string | null>(null);
+  const taxonomies = useTaxonomies();
+
+  // Comma-separated names, or "Any" when the criterion is not constrained
+  const describe = (names: string[]) => (names.length > 0 ? names.join(", ") : "Any");
 
   const handleConfirmDelete = async () => {
     if (!icpToDelete) return;
@@ -155,19 +159,19 @@ export default function ICPPage() {
                     <TableHead className="font-semibold">
                       <div className="flex items-center">
                         <Briefcase className="mr-2 h-4 w-4" />
-                        Business Type
+                        Business Types
                       </div>
                     </TableHead>
                     <TableHead className="font-semibold">
                       <div className="flex items-center">
                         <Building2 className="mr-2 h-4 w-4" />
-                        Industry
+                        Industries
                       </div>
                     </TableHead>
                     <TableHead className="font-semibold">
                       <div className="flex items-center">
                         <Users className="mr-2 h-4 w-4" />
-                        Company Size
+                        Company Sizes
                       </div>
                     </TableHead>
                     <TableHead className="font-semibold">Buyer Roles</TableHead>
@@ -178,19 +182,20 @@ export default function ICPPage() {
                   {icps.map((icp) => (
                     <TableRow key={icp.id} className="hover:bg-muted/50">
                       <TableCell className="font-medium">
-                        {typeof icp.business_type === "number" ? getBusinessTypeLabel(icp.business_type) : "-"}
+                        {icp.name && <div>{icp.name}</div>}
+                        {describe(termNames(taxonomies.business_types, icp.business_types))}
                       </TableCell>
                       <TableCell>
-                        {icp.industry || "-"}
+                        {describe(termNames(taxonomies.industries, icp.industries))}
                       </TableCell>
                       <TableCell>
                         <div className="flex flex-wrap gap-1">
-                          {typeof icp.company_size === "number" ? getBusinessTypeLabel(icp.company_size) : "-"}
+                          {describe(termNames(taxonomies.company_sizes, icp.company_sizes))}
                         </div>
                       </TableCell>
                       <TableCell>
                         <div className="flex flex-wrap gap-1 max-w-xs">
-                          {typeof icp.buyer_roles === "number" ? getBusinessTypeLabel(icp.buyer_roles) : "-"}
+                          {describe(termNames(taxonomies.buyer_roles, icp.buyer_roles))}
                         </div>
                       </TableCell>
                       <TableCell>
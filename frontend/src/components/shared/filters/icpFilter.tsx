@@ -2,18 +2,7 @@
 import * as React from "react"
 import { Combobox, ComboboxItem } from "../SearchDropdown/searchDropdown" // Adjust import path as needed
 import { icpService } from "@/services/icpService";
-
-export interface ICPProfile {
-  id: string;
-  user_id: number;
-  business_type: number;
-  industry: string;
-  company_size: number;
-  buyer_roles: number;
-  problem_statement: string;
-  created_at: string; // ISO date string
-  updated_at: string; // ISO date string
-}
+import { ICPProfile } from "@/models/icp";
 
 export interface ICPFilterProps {
   icpService: {
@@ -55,8 +44,8 @@ export function ICPFilter({
 
   // Convert ICPs to ComboboxItem format
   const icpItems: ComboboxItem[] = icps.map((icp) => ({
-    value: icp.id,
-    label: `${icp.industry} - ${icp.problem_statement.substring(0, 50)}${
+    value: String(icp.id),
+    label: `${icp.name || `ICP #${icp.id}`} - ${icp.problem_statement.substring(0, 50)}${
       icp.problem_statement.length > 50 ? "..." : ""
     }`,
   }));
@@ -65,7 +54,7 @@ export function ICPFilter({
     setSelectedICPId(value);
     
     // Find the selected ICP and pass it to parent
-    const selectedICP = value ? icps.find((icp) => icp.id === value) : null;
+    const selectedICP = value ? icps.find((icp) => String(icp.id) === value) : null;
     onICPSelect?.(selectedICP || null);
   };
 
@@ -86,7 +75,7 @@ export function ICPFilter({
       
       {selectedICPId && (
         <div className="text-xs text-muted-foreground">
-          Selected: {icps.find(icp => icp.id === selectedICPId)?.industry}
+          Selected: {icps.find(icp => String(icp.id) === selectedICPId)?.name}
         </div>
       )}
     </div>
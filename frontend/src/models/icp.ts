@@ -1,11 +1,21 @@
+// Every criterion is a list; an empty list places no constraint. Taxonomy
+// criteria hold term IDs from GET /api/taxonomies.
 export interface ICPProfile {
   id: string;
   user_id: number;
-  business_type: number;
-  industry: string;
-  company_size: number;
-  buyer_roles: number;
+  name: string;
+  business_types: number[];
+  industries: number[];
+  company_sizes: number[];
+  buyer_roles: number[];
+  geographies: string[]; // ISO-3166 country or region codes
+  required_technologies: string[];
+  excluded_technologies: string[];
+  funding_stages: string[];
+  revenue_bands: number[];
   problem_statement: string;
+  version: number;
+  archived_at?: string; // ISO date string
   created_at: string; // ISO date string
   updated_at: string; // ISO date string
 }
@@ -0,0 +1,15 @@
+export type TaxonomyKind = "industries" | "company_sizes" | "business_types" | "buyer_roles";
+
+export interface TaxonomyTerm {
+  kind: TaxonomyKind;
+  id: number;
+  name: string;
+  parent_id?: number;
+  aliases: string[];
+  position: number;
+  builtin: boolean;
+  created_at: string; // ISO date string
+  updated_at: string; // ISO date string
+}
+
+export type Taxonomies = Record<TaxonomyKind, TaxonomyTerm[]>;
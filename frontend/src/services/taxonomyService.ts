@@ -0,0 +1,13 @@
+// src/services/taxonomyService.ts
+import { apiClient } from "@/lib/apiClient";
+import { Taxonomies, TaxonomyKind, TaxonomyTerm } from "@/models/taxonomy";
+
+export const taxonomyService = {
+  // All taxonomies, each in display order
+  listTaxonomies: () =>
+    apiClient.get<Taxonomies>("/taxonomies"),
+
+  // One taxonomy
+  listTerms: (kind: TaxonomyKind) =>
+    apiClient.get<TaxonomyTerm[]>(`/taxonomies/${kind}`),
+};